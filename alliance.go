@@ -0,0 +1,80 @@
+package ogame
+
+import "time"
+
+// AllianceMemberStatus online status of an alliance member
+type AllianceMemberStatus string
+
+// Alliance member statuses
+const (
+	AllianceMemberOnline  AllianceMemberStatus = "online"  // Active in the last 15 minutes
+	AllianceMemberRecent  AllianceMemberStatus = "recent"  // Active in the last hour
+	AllianceMemberOffline AllianceMemberStatus = "offline" // Inactive for more than an hour
+	AllianceMemberUnknown AllianceMemberStatus = ""        // Rank not allowed to see the online status
+)
+
+// AllianceMember member of our alliance
+type AllianceMember struct {
+	PlayerID int64
+	Name     string
+	RankID   int64 // -1 for the founder
+	RankName string
+	Points   int64
+	Home     Coordinate
+	JoinedAt time.Time
+	Status   AllianceMemberStatus
+	Kickable bool
+}
+
+// AllianceOverview our alliance and its members
+type AllianceOverview struct {
+	ID       int64
+	Tag      string
+	Name     string
+	Class    AllianceClass
+	Homepage string
+	Members  []AllianceMember
+}
+
+// AlliancePermissions permissions of an alliance rank
+type AlliancePermissions struct {
+	SeeApplications  bool
+	EditApplications bool
+	SeeMembers       bool
+	KickMembers      bool
+	SeeOnlineStatus  bool
+	CircularMessage  bool
+	DisbandAlliance  bool
+	ManageAlliance   bool
+	RightHand        bool
+}
+
+// AllianceRank rank of our alliance
+type AllianceRank struct {
+	ID          int64
+	Name        string
+	Permissions AlliancePermissions
+}
+
+// AllianceApplication pending application to our alliance
+type AllianceApplication struct {
+	ID         int64
+	PlayerID   int64
+	PlayerName string
+	Points     int64
+	Rank       int64
+	Date       time.Time
+	Message    string
+}
+
+// AlliancePublicInfo public information of any alliance, from the alliance info page
+type AlliancePublicInfo struct {
+	ID               int64
+	Tag              string
+	Name             string
+	Members          int64
+	Class            AllianceClass
+	Homepage         string
+	Description      string
+	ApplicationsOpen bool
+}
@@ -0,0 +1,282 @@
+package ogame
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AuctionBid bid placed by the auction bidder
+type AuctionBid struct {
+	Item      string
+	Points    int64 // Bid value, resources weighted by the auction multiplier
+	Total     int64 // Our total bid on the auction, including the previous bids
+	Resources map[CelestialID]Resources
+	At        time.Time
+}
+
+// AuctionResources returns the resources available for bidding on every celestial of the auction
+func AuctionResources(auction Auction) map[CelestialID]Resources {
+	out := make(map[CelestialID]Resources)
+	for idStr, v := range auction.Resources {
+		id, err := strconv.ParseInt(idStr, 10, 64)
+		if err != nil {
+			continue
+		}
+		celestial, _ := v.(map[string]interface{})
+		input, _ := celestial["input"].(map[string]interface{})
+		out[CelestialID(id)] = Resources{
+			Metal:     int64(doCastF64(input["metal"])),
+			Crystal:   int64(doCastF64(input["crystal"])),
+			Deuterium: int64(doCastF64(input["deuterium"])),
+		}
+	}
+	return out
+}
+
+// resourceAt returns the metal (0), crystal (1) or deuterium (2) of r
+func resourceAt(r Resources, i int) int64 {
+	switch i {
+	case 0:
+		return r.Metal
+	case 1:
+		return r.Crystal
+	}
+	return r.Deuterium
+}
+
+// resourceAmount returns amount of metal (0), crystal (1) or deuterium (2)
+func resourceAmount(i int, amount int64) Resources {
+	switch i {
+	case 0:
+		return Resources{Metal: amount}
+	case 1:
+		return Resources{Crystal: amount}
+	}
+	return Resources{Deuterium: amount}
+}
+
+// AuctionBidder bids on the auctioneer at the last moment, up to a valuation per item and within a daily budget
+type AuctionBidder struct {
+	sync.Mutex
+	b                Wrapper
+	valuations       map[string]int64
+	rarityValuations map[string]int64
+	dailyBudget      int64
+	window           time.Duration
+	ratios           TradeRatios
+	reserve          Resources
+	spentDay         time.Time
+	spent            int64
+	item             AuctioneerItem
+	endsAt           time.Time
+	bids             []AuctionBid
+}
+
+// NewAuctionBidder ...
+func NewAuctionBidder(b Wrapper) *AuctionBidder {
+	a := new(AuctionBidder)
+	a.b = b
+	a.valuations = make(map[string]int64)
+	a.rarityValuations = make(map[string]int64)
+	a.window = 2 * time.Minute
+	a.ratios = DefaultTradeRatios
+	return a
+}
+
+// SetValuation sets the maximum total bid for an item, by name
+func (a *AuctionBidder) SetValuation(item string, maxBid int64) *AuctionBidder {
+	a.valuations[strings.ToLower(item)] = maxBid
+	return a
+}
+
+// SetRarityValuation sets the maximum total bid for the items of a rarity without valuation,
+// the rarity is known from the "new auction" event
+func (a *AuctionBidder) SetRarityValuation(rarity string, maxBid int64) *AuctionBidder {
+	a.rarityValuations[strings.ToLower(rarity)] = maxBid
+	return a
+}
+
+// SetDailyBudget sets the maximum bid points spent per day, no limit if 0
+func (a *AuctionBidder) SetDailyBudget(dailyBudget int64) *AuctionBidder {
+	a.dailyBudget = dailyBudget
+	return a
+}
+
+// SetWindow bids only when the auction ends within window (2 minutes by default)
+func (a *AuctionBidder) SetWindow(window time.Duration) *AuctionBidder {
+	a.window = window
+	return a
+}
+
+// SetRates sets the exchange ratios giving our value of the resources (3:2:1 by default), the resources giving the most bid points
+// for their value are spent first
+func (a *AuctionBidder) SetRates(ratios TradeRatios) *AuctionBidder {
+	a.ratios = ratios
+	return a
+}
+
+// SetReserve sets the resources kept on every celestial
+func (a *AuctionBidder) SetReserve(reserve Resources) *AuctionBidder {
+	a.reserve = reserve
+	return a
+}
+
+// Bids returns the bids placed
+func (a *AuctionBidder) Bids() []AuctionBid {
+	a.Lock()
+	defer a.Unlock()
+	return append([]AuctionBid{}, a.bids...)
+}
+
+// Spent returns the bid points spent today
+func (a *AuctionBidder) Spent() int64 {
+	a.Lock()
+	defer a.Unlock()
+	a.resetBudget()
+	return a.spent
+}
+
+func (a *AuctionBidder) resetBudget() {
+	now := a.b.ServerTime()
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	if !day.Equal(a.spentDay) {
+		a.spentDay = day
+		a.spent = 0
+	}
+}
+
+// HandleEvent keeps track of the auction item and end time, to be registered with RegisterAuctioneerCallback
+func (a *AuctionBidder) HandleEvent(evt interface{}) {
+	a.Lock()
+	defer a.Unlock()
+	now := a.b.ServerTime()
+	switch e := evt.(type) {
+	case AuctioneerNewAuction:
+		a.item = e.Item
+		a.endsAt = now.Add(time.Duration(e.Approx) * time.Second)
+	case AuctioneerTimeRemaining:
+		a.endsAt = now.Add(time.Duration(e.Approx) * time.Second)
+	case AuctioneerAuctionFinished, AuctioneerNextAuction:
+		a.item = AuctioneerItem{}
+		a.endsAt = time.Time{}
+	}
+}
+
+func (a *AuctionBidder) valuation(auction Auction) int64 {
+	if v, ok := a.valuations[auction.CurrentItem]; ok {
+		return v
+	}
+	if v, ok := a.valuations[auction.CurrentItemLong]; ok {
+		return v
+	}
+	return a.rarityValuations[strings.ToLower(a.item.Rarity)]
+}
+
+// Allocate returns the resources to spend for points bid points, the resources giving the most bid points for
+// their value are taken first, from the celestials having the most of them
+func (a *AuctionBidder) Allocate(auction Auction, points int64) (map[CelestialID]Resources, error) {
+	multipliers := [3]float64{auction.ResourceMultiplier.Metal, auction.ResourceMultiplier.Crystal, auction.ResourceMultiplier.Deuterium}
+	var rates [3]float64
+	for i := range rates {
+		rates[i] = a.ratios.MSU(resourceAmount(i, 1))
+	}
+	order := []int{0, 1, 2}
+	sort.SliceStable(order, func(i, j int) bool {
+		return multipliers[order[i]]/rates[order[i]] > multipliers[order[j]]/rates[order[j]]
+	})
+	available := AuctionResources(auction)
+	ids := make([]CelestialID, 0, len(available))
+	for id := range available {
+		ids = append(ids, id)
+	}
+	bid := make(map[CelestialID]Resources)
+	left := float64(points)
+	for _, res := range order {
+		if multipliers[res] <= 0 {
+			continue
+		}
+		sort.Slice(ids, func(i, j int) bool {
+			ri, rj := resourceAt(available[ids[i]], res), resourceAt(available[ids[j]], res)
+			return ri > rj || (ri == rj && ids[i] < ids[j])
+		})
+		for _, id := range ids {
+			if left <= 0 {
+				break
+			}
+			usable := resourceAt(available[id], res) - resourceAt(a.reserve, res)
+			if usable <= 0 {
+				continue
+			}
+			amount := MinInt(usable, int64(math.Ceil(left/multipliers[res])))
+			bid[id] = bid[id].Add(resourceAmount(res, amount))
+			left -= float64(amount) * multipliers[res]
+		}
+	}
+	if left > 0 {
+		return bid, errors.New("not enough resources to bid " + strconv.FormatInt(points, 10))
+	}
+	return bid, nil
+}
+
+// Tick bids the minimum needed to become the highest bidder when the auction is about to end, if the total
+// stays within the item valuation and the daily budget. Returns nil if no bid was placed.
+func (a *AuctionBidder) Tick() (*AuctionBid, error) {
+	a.Lock()
+	defer a.Unlock()
+	a.resetBudget()
+	auction, err := a.b.GetAuction()
+	if err != nil {
+		return nil, err
+	}
+	if auction.HasFinished {
+		return nil, nil
+	}
+	endsIn := time.Duration(auction.Endtime) * time.Second
+	if !a.endsAt.IsZero() {
+		endsIn = a.endsAt.Sub(a.b.ServerTime())
+	}
+	if endsIn > a.window {
+		return nil, nil
+	}
+	if auction.HighestBidderUserID != 0 && auction.HighestBidderUserID == a.b.GetCachedPlayer().PlayerID {
+		return nil, nil
+	}
+	points := MaxInt(auction.DeficitBid, auction.MinimumBid-auction.AlreadyBid)
+	total := auction.AlreadyBid + points
+	if points <= 0 || total > a.valuation(auction) {
+		return nil, nil
+	}
+	if a.dailyBudget > 0 && a.spent+points > a.dailyBudget {
+		return nil, nil
+	}
+	resources, err := a.Allocate(auction, points)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.b.DoAuction(resources); err != nil {
+		return nil, err
+	}
+	a.spent += points
+	bid := AuctionBid{Item: auction.CurrentItem, Points: points, Total: total, Resources: resources, At: a.b.ServerTime()}
+	a.bids = append(a.bids, bid)
+	return &bid, nil
+}
+
+// Run calls Tick every interval until stop is closed, the interval should be shorter than the bidding window
+func (a *AuctionBidder) Run(interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		_, _ = a.Tick()
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
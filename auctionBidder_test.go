@@ -0,0 +1,132 @@
+package ogame
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAuctioneerEvent(t *testing.T) {
+	parse := func(packet string) interface{} {
+		var out []interface{}
+		_ = json.Unmarshal([]byte(packet), &out)
+		evt, _ := parseAuctioneerEvent(out[0].(string), out[1])
+		return evt
+	}
+	assert.Equal(t, AuctioneerTimeRemaining{Approx: 1800},
+		parse(`["timeLeft","<span style=\"color:#99CC00;\"><b>approx. 30m</b></span> remaining until the auction ends"]`))
+	assert.Equal(t, AuctioneerNextAuction{Secs: 117},
+		parse(`["timeLeft","Next auction in:<br />\n<span class=\"nextAuction\" id=\"nextAuction\">117</span>"]`))
+	assert.Equal(t, AuctioneerNewBid{Sum: 5000, Price: 6000, Bids: 5, AuctionID: 42894,
+		Player: AuctioneerPlayer{ID: 219657, Name: "Payback", Link: "https://s129-en.ogame.gameforge.com/game/index.php?page=ingame&component=galaxy&galaxy=2&system=146"}},
+		parse(`["new bid",{"player":{"id":219657,"name":"Payback","link":"https://s129-en.ogame.gameforge.com/game/index.php?page=ingame&component=galaxy&galaxy=2&system=146"},"sum":5000,"price":6000,"bids":5,"auctionId":"42894"}]`))
+	assert.Equal(t, AuctioneerNewAuction{AuctionID: 42895, Approx: 2100,
+		Item: AuctioneerItem{UUID: "0968999df2fe956aa4a07aea74921f860af7d97f", Image: "55d4b1750985e4843023d7d0acd2b9bafb15f0b7", Rarity: "rare"}},
+		parse(`["new auction",{"info":"<span style=\"color:#99CC00;\"><b>approx. 35m</b></span> remaining until the auction ends","item":{"uuid":"0968999df2fe956aa4a07aea74921f860af7d97f","image":"55d4b1750985e4843023d7d0acd2b9bafb15f0b7","rarity":"rare"},"auctionId":42895}]`))
+	finished := parse(`["auction finished",{"sum":5000,"player":{"id":219657,"name":"Payback","link":""},"bids":5,"info":"Next auction in:<br />\n<span class=\"nextAuction\" id=\"nextAuction\">1072</span>","time":"08:42"}]`)
+	assert.Equal(t, AuctioneerAuctionFinished{Sum: 5000, Bids: 5, NextAuction: 1072, Time: "08:42",
+		Player: AuctioneerPlayer{ID: 219657, Name: "Payback"}}, finished)
+	_, ok := parseAuctioneerEvent("unknown", nil)
+	assert.False(t, ok)
+}
+
+type fakeAuctionWrapper struct {
+	*fakeBot
+	player      UserInfos
+	auction     Auction
+	auctionBids []map[CelestialID]Resources
+}
+
+func (w *fakeAuctionWrapper) GetCachedPlayer() UserInfos   { return w.player }
+func (w *fakeAuctionWrapper) GetAuction() (Auction, error) { return w.auction, nil }
+func (w *fakeAuctionWrapper) DoAuction(bid map[CelestialID]Resources) error {
+	w.auctionBids = append(w.auctionBids, bid)
+	return nil
+}
+
+func TestAuctionBidder(t *testing.T) {
+	w := &fakeAuctionWrapper{fakeBot: newFakeBot()}
+	w.player.PlayerID = 1
+	w.auction = Auction{Endtime: 1800, MinimumBid: 6000, DeficitBid: 1000, CurrentItem: "gold metal booster"}
+	w.auction.ResourceMultiplier.Metal = 1
+	w.auction.ResourceMultiplier.Crystal = 1.5
+	w.auction.ResourceMultiplier.Deuterium = 3
+	w.auction.Resources = map[string]interface{}{
+		"11": map[string]interface{}{"input": map[string]interface{}{"metal": 4000.0, "crystal": 10000.0, "deuterium": 0.0}},
+		"12": map[string]interface{}{"input": map[string]interface{}{"metal": 5000.0, "crystal": 0.0, "deuterium": 1000.0}},
+	}
+	a := NewAuctionBidder(w).
+		SetValuation("Gold Metal Booster", 10000).
+		SetDailyBudget(15000).
+		SetRates(TradeRatios{Metal: 7, Crystal: 3.5, Deuterium: 2}) // Metal gives the most points for its value, then deuterium
+
+	// Too early
+	bid, err := a.Tick()
+	assert.NoError(t, err)
+	assert.Nil(t, bid)
+
+	// Last moment, known from the events
+	a.HandleEvent(AuctioneerTimeRemaining{Approx: 60})
+	bid, err = a.Tick()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(6000), bid.Points)
+	assert.Equal(t, map[CelestialID]Resources{12: {Metal: 5000}, 11: {Metal: 1000}}, w.auctionBids[0])
+
+	// Outbid, the minimum bid is above the valuation
+	w.auction.AlreadyBid = 6000
+	w.auction.MinimumBid = 12000
+	bid, err = a.Tick()
+	assert.NoError(t, err)
+	assert.Nil(t, bid)
+
+	// Within the valuation but above the daily budget
+	a.SetValuation("gold metal booster", 20000)
+	w.auction.MinimumBid = 16000
+	bid, _ = a.Tick()
+	assert.Nil(t, bid)
+
+	// Metal above the reserve is not enough, deuterium is used
+	a.SetReserve(Resources{Metal: 1000})
+	w.auction.MinimumBid = 14000
+	bid, err = a.Tick()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(8000), bid.Points)
+	assert.Equal(t, int64(14000), bid.Total)
+	assert.Equal(t, map[CelestialID]Resources{12: {Metal: 4000, Deuterium: 334}, 11: {Metal: 3000}}, w.auctionBids[1])
+	assert.Equal(t, int64(14000), a.Spent())
+
+	// We are the highest bidder
+	w.auction.HighestBidderUserID = 1
+	bid, _ = a.Tick()
+	assert.Nil(t, bid)
+
+	// Budget is reset the next day, rarity valuation for unknown items
+	w.now = w.now.Add(24 * time.Hour)
+	w.auction = Auction{Endtime: 60, MinimumBid: 1000, CurrentItem: "unknown", ResourceMultiplier: w.auction.ResourceMultiplier, Resources: w.auction.Resources}
+	a.HandleEvent(AuctioneerNewAuction{Approx: 60, Item: AuctioneerItem{Rarity: "rare"}})
+	bid, _ = a.Tick()
+	assert.Nil(t, bid)
+	a.SetRarityValuation("rare", 1000)
+	bid, _ = a.Tick()
+	assert.Equal(t, int64(1000), bid.Points)
+	assert.Equal(t, int64(1000), a.Spent())
+}
+
+func TestAuctionBidder_AllocateDefaultRates(t *testing.T) {
+	auction := Auction{}
+	auction.ResourceMultiplier.Metal = 1
+	auction.ResourceMultiplier.Crystal = 2
+	auction.ResourceMultiplier.Deuterium = 3
+	auction.Resources = map[string]interface{}{
+		"11": map[string]interface{}{"input": map[string]interface{}{"metal": 10000.0, "crystal": 10000.0, "deuterium": 10000.0}},
+	}
+	// At 3:2:1, crystal gives 1.33 points per metal of value, metal and deuterium give 1
+	a := NewAuctionBidder(nil)
+	bid, err := a.Allocate(auction, 2000)
+	assert.NoError(t, err)
+	assert.Equal(t, map[CelestialID]Resources{11: {Crystal: 1000}}, bid)
+	bid, _ = a.SetRates(TradeRatios{Metal: 3, Crystal: 2, Deuterium: 1}).Allocate(auction, 2000)
+	assert.Equal(t, map[CelestialID]Resources{11: {Crystal: 1000}}, bid)
+}
@@ -0,0 +1,637 @@
+package ogame
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"math"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+// BuildQueueItem an entry of a celestial build queue.
+// Nbr is the level to reach for buildings and technologies, and the number of units to build for ships and defenses.
+type BuildQueueItem struct {
+	ItemID      int64
+	CelestialID CelestialID
+	ID          ID
+	Nbr         int64
+}
+
+// BuildQueueEstimate projected start and end of a build queue item
+type BuildQueueEstimate struct {
+	Item        BuildQueueItem
+	StartAt     time.Time
+	EndAt       time.Time
+	Unreachable bool // Resources are not produced, or the item can never be built
+}
+
+type buildLane int
+
+const (
+	buildingLane buildLane = iota
+	researchLane
+	shipyardLane
+)
+
+func laneOf(id ID) buildLane {
+	if id.IsTech() {
+		return researchLane
+	} else if id.IsShip() || id.IsDefense() {
+		return shipyardLane
+	}
+	return buildingLane
+}
+
+// techLevels all the levels/amounts of a celestial, as returned by GetTechs
+type techLevels struct {
+	resBuildings ResourcesBuildings
+	facilities   Facilities
+	ships        ShipsInfos
+	defenses     DefensesInfos
+	researches   Researches
+}
+
+func (t techLevels) ByID(id ID) int64 {
+	if id.IsResourceBuilding() {
+		return t.resBuildings.ByID(id)
+	} else if id.IsFacility() {
+		return t.facilities.ByID(id)
+	} else if id.IsTech() {
+		return t.researches.ByID(id)
+	} else if id.IsShip() {
+		return t.ships.ByID(id)
+	} else if id.IsDefense() {
+		return t.defenses.ByID(id)
+	}
+	return 0
+}
+
+func (t *techLevels) Set(id ID, val int64) {
+	if id.IsResourceBuilding() {
+		t.resBuildings.Set(id, val)
+	} else if id.IsFacility() {
+		t.facilities.Set(id, val)
+	} else if id.IsTech() {
+		t.researches.Set(id, val)
+	} else if id.IsShip() {
+		t.ships.Set(id, val)
+	} else if id.IsDefense() {
+		t.defenses.Set(id, val)
+	}
+}
+
+// missingRequirements returns the requirements of id that are not met, prerequisites first.
+// Nbr of the returned items is the level needed.
+func missingRequirements(id ID, level func(ID) int64) []Quantifiable {
+	out := make([]Quantifiable, 0)
+	var rec func(id ID)
+	rec = func(id ID) {
+		obj := Objs.ByID(id)
+		if obj == nil {
+			return
+		}
+		reqs := obj.GetRequirements()
+		ids := make([]ID, 0, len(reqs))
+		for reqID := range reqs {
+			ids = append(ids, reqID)
+		}
+		sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+		for _, reqID := range ids {
+			lvl := reqs[reqID]
+			if level(reqID) >= lvl {
+				continue
+			}
+			rec(reqID)
+			found := false
+			for i := range out {
+				if out[i].ID == reqID {
+					out[i].Nbr = MaxInt(out[i].Nbr, lvl)
+					found = true
+				}
+			}
+			if !found {
+				out = append(out, Quantifiable{ID: reqID, Nbr: lvl})
+			}
+		}
+	}
+	rec(id)
+	return out
+}
+
+// pendingRequirements missing requirements of id that are not already queued in items
+func pendingRequirements(id ID, levels techLevels, items []BuildQueueItem) []Quantifiable {
+	out := make([]Quantifiable, 0)
+	for _, req := range missingRequirements(id, levels.ByID) {
+		queued := false
+		for _, item := range items {
+			if item.ID == req.ID && item.Nbr >= req.Nbr {
+				queued = true
+				break
+			}
+		}
+		if !queued {
+			out = append(out, req)
+		}
+	}
+	return out
+}
+
+// BuildQueue persistent queue of constructions for every celestial
+type BuildQueue struct {
+	sync.Mutex
+	tickMu     sync.Mutex
+	b          Wrapper
+	filename   string
+	nextItemID int64
+	queues     map[CelestialID][]BuildQueueItem
+}
+
+// buildQueueOp change made by a tick to an item, applied to the queue once the tick is done
+// so that the queue is not locked during the network calls
+type buildQueueOp struct {
+	itemID int64
+	remove bool
+	done   int64            // units started, the item is removed when nothing is left
+	insert []BuildQueueItem // items inserted before the item
+}
+
+type buildQueueFile struct {
+	NextItemID int64
+	Queues     map[CelestialID][]BuildQueueItem
+}
+
+// NewBuildQueue creates an in memory build queue
+func NewBuildQueue(b Wrapper) *BuildQueue {
+	q := new(BuildQueue)
+	q.b = b
+	q.nextItemID = 1
+	q.queues = make(map[CelestialID][]BuildQueueItem)
+	return q
+}
+
+// NewFileBuildQueue creates a build queue saved in a json file
+func NewFileBuildQueue(b Wrapper, filename string) (*BuildQueue, error) {
+	q := NewBuildQueue(b)
+	q.filename = filename
+	by, err := ioutil.ReadFile(filename)
+	if os.IsNotExist(err) {
+		return q, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var content buildQueueFile
+	if err := json.Unmarshal(by, &content); err != nil {
+		return nil, err
+	}
+	q.nextItemID = MaxInt(content.NextItemID, 1)
+	if content.Queues != nil {
+		q.queues = content.Queues
+	}
+	return q, nil
+}
+
+func (q *BuildQueue) save() error {
+	if q.filename == "" {
+		return nil
+	}
+	by, err := json.Marshal(buildQueueFile{NextItemID: q.nextItemID, Queues: q.queues})
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(q.filename, by, 0644)
+}
+
+func (q *BuildQueue) newItem(celestialID CelestialID, id ID, nbr int64) BuildQueueItem {
+	item := BuildQueueItem{ItemID: q.nextItemID, CelestialID: celestialID, ID: id, Nbr: nbr}
+	q.nextItemID++
+	return item
+}
+
+func (q *BuildQueue) indexOf(celestialID CelestialID, itemID int64) int {
+	for i, item := range q.queues[celestialID] {
+		if item.ItemID == itemID {
+			return i
+		}
+	}
+	return -1
+}
+
+// Add appends an item at the end of the celestial queue
+func (q *BuildQueue) Add(celestialID CelestialID, id ID, nbr int64) (BuildQueueItem, error) {
+	if !id.IsValid() {
+		return BuildQueueItem{}, errors.New("invalid id " + id.String())
+	}
+	if nbr <= 0 {
+		return BuildQueueItem{}, errors.New("nbr must be positive")
+	}
+	q.Lock()
+	defer q.Unlock()
+	item := q.newItem(celestialID, id, nbr)
+	q.queues[celestialID] = append(q.queues[celestialID], item)
+	return item, q.save()
+}
+
+// Get returns the queue of a celestial
+func (q *BuildQueue) Get(celestialID CelestialID) []BuildQueueItem {
+	q.Lock()
+	defer q.Unlock()
+	return append([]BuildQueueItem{}, q.queues[celestialID]...)
+}
+
+// GetAll returns the queues of all celestials
+func (q *BuildQueue) GetAll() map[CelestialID][]BuildQueueItem {
+	q.Lock()
+	defer q.Unlock()
+	out := make(map[CelestialID][]BuildQueueItem, len(q.queues))
+	for celestialID, items := range q.queues {
+		out[celestialID] = append([]BuildQueueItem{}, items...)
+	}
+	return out
+}
+
+// Update changes the level/amount of an item
+func (q *BuildQueue) Update(celestialID CelestialID, itemID, nbr int64) (BuildQueueItem, error) {
+	if nbr <= 0 {
+		return BuildQueueItem{}, errors.New("nbr must be positive")
+	}
+	q.Lock()
+	defer q.Unlock()
+	idx := q.indexOf(celestialID, itemID)
+	if idx == -1 {
+		return BuildQueueItem{}, ErrBuildQueueItemNotFound
+	}
+	q.queues[celestialID][idx].Nbr = nbr
+	return q.queues[celestialID][idx], q.save()
+}
+
+// Move moves an item to a new position in the celestial queue
+func (q *BuildQueue) Move(celestialID CelestialID, itemID int64, index int) error {
+	q.Lock()
+	defer q.Unlock()
+	idx := q.indexOf(celestialID, itemID)
+	if idx == -1 {
+		return ErrBuildQueueItemNotFound
+	}
+	items := q.queues[celestialID]
+	item := items[idx]
+	items = append(items[:idx], items[idx+1:]...)
+	index = int(Clamp(int64(index), 0, int64(len(items))))
+	items = append(items[:index], append([]BuildQueueItem{item}, items[index:]...)...)
+	q.queues[celestialID] = items
+	return q.save()
+}
+
+// Remove removes an item from the celestial queue
+func (q *BuildQueue) Remove(celestialID CelestialID, itemID int64) error {
+	q.Lock()
+	defer q.Unlock()
+	idx := q.indexOf(celestialID, itemID)
+	if idx == -1 {
+		return ErrBuildQueueItemNotFound
+	}
+	q.removeAt(celestialID, idx)
+	return q.save()
+}
+
+// Clear removes all the items of a celestial queue
+func (q *BuildQueue) Clear(celestialID CelestialID) error {
+	q.Lock()
+	defer q.Unlock()
+	delete(q.queues, celestialID)
+	return q.save()
+}
+
+func (q *BuildQueue) removeAt(celestialID CelestialID, idx int) {
+	items := q.queues[celestialID]
+	items = append(items[:idx], items[idx+1:]...)
+	if len(items) == 0 {
+		delete(q.queues, celestialID)
+		return
+	}
+	q.queues[celestialID] = items
+}
+
+func (q *BuildQueue) getLevels(celestialID CelestialID) (levels techLevels, err error) {
+	levels.resBuildings, levels.facilities, levels.ships, levels.defenses, levels.researches, err = q.b.GetTechs(celestialID)
+	return
+}
+
+// apply applies the changes of a tick to the items that still exist in the celestial queue
+func (q *BuildQueue) apply(celestialID CelestialID, ops []buildQueueOp) {
+	for _, op := range ops {
+		idx := q.indexOf(celestialID, op.itemID)
+		if idx == -1 {
+			continue
+		}
+		switch {
+		case len(op.insert) > 0:
+			items := q.queues[celestialID]
+			newItems := make([]BuildQueueItem, 0, len(items)+len(op.insert))
+			newItems = append(newItems, items[:idx]...)
+			newItems = append(newItems, op.insert...)
+			q.queues[celestialID] = append(newItems, items[idx:]...)
+		case op.remove:
+			q.removeAt(celestialID, idx)
+		case op.done > 0:
+			q.queues[celestialID][idx].Nbr -= op.done
+			if q.queues[celestialID][idx].Nbr <= 0 {
+				q.removeAt(celestialID, idx)
+			}
+		}
+	}
+}
+
+// Tick starts the next items of every queue when the construction slot is free and the resources are available.
+// Completed items are removed, and missing prerequisites are inserted before the items that need them.
+// The queue is not locked during the network calls, the changes are applied to the items that still exist.
+// Returns the items that were started.
+func (q *BuildQueue) Tick() ([]BuildQueueItem, error) {
+	q.tickMu.Lock()
+	defer q.tickMu.Unlock()
+	q.Lock()
+	celestialIDs := make([]CelestialID, 0, len(q.queues))
+	for celestialID := range q.queues {
+		celestialIDs = append(celestialIDs, celestialID)
+	}
+	q.Unlock()
+	sort.Slice(celestialIDs, func(i, j int) bool { return celestialIDs[i] < celestialIDs[j] })
+	started := make([]BuildQueueItem, 0)
+	var firstErr error
+	for _, celestialID := range celestialIDs {
+		items, err := q.tick(celestialID)
+		started = append(started, items...)
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	q.Lock()
+	defer q.Unlock()
+	if err := q.save(); err != nil && firstErr == nil {
+		firstErr = err
+	}
+	return started, firstErr
+}
+
+func (q *BuildQueue) tick(celestialID CelestialID) ([]BuildQueueItem, error) {
+	q.Lock()
+	items := append([]BuildQueueItem{}, q.queues[celestialID]...)
+	q.Unlock()
+	if len(items) == 0 {
+		return nil, nil
+	}
+	ops := make([]buildQueueOp, 0)
+	defer func() {
+		q.Lock()
+		q.apply(celestialID, ops)
+		q.Unlock()
+	}()
+
+	levels, err := q.getLevels(celestialID)
+	if err != nil {
+		return nil, err
+	}
+	buildingID, _, researchID, _ := q.b.ConstructionsBeingBuilt(celestialID)
+	production, _, err := q.b.GetProduction(celestialID)
+	if err != nil {
+		return nil, err
+	}
+	resources, err := q.b.GetResources(celestialID)
+	if err != nil {
+		return nil, err
+	}
+
+	started := make([]BuildQueueItem, 0)
+	blocked := make(map[buildLane]bool)
+	isBusy := func(id ID) bool {
+		switch laneOf(id) {
+		case buildingLane:
+			return buildingID.IsSet() ||
+				(id == ResearchLabID && researchID.IsSet()) ||
+				((id == ShipyardID || id == NaniteFactoryID) && len(production) > 0)
+		case researchLane:
+			return researchID.IsSet() || buildingID == ResearchLabID
+		default:
+			return buildingID == ShipyardID || buildingID == NaniteFactoryID
+		}
+	}
+
+	var firstErr error
+	for i := 0; i < len(items); {
+		item := items[i]
+		lane := laneOf(item.ID)
+		isLevelable := lane != shipyardLane
+		if isLevelable && levels.ByID(item.ID) >= item.Nbr {
+			ops = append(ops, buildQueueOp{itemID: item.ItemID, remove: true})
+			items = append(items[:i], items[i+1:]...)
+			continue
+		}
+		if blocked[lane] {
+			i++
+			continue
+		}
+		if reqs := pendingRequirements(item.ID, levels, items[:i]); len(reqs) > 0 {
+			newItems := make([]BuildQueueItem, 0, len(items)+len(reqs))
+			newItems = append(newItems, items[:i]...)
+			op := buildQueueOp{itemID: item.ItemID}
+			q.Lock()
+			for _, req := range reqs {
+				op.insert = append(op.insert, q.newItem(celestialID, req.ID, req.Nbr))
+			}
+			q.Unlock()
+			ops = append(ops, op)
+			newItems = append(newItems, op.insert...)
+			items = append(newItems, items[i:]...)
+			continue
+		}
+		blocked[lane] = true
+		if len(missingRequirements(item.ID, levels.ByID)) > 0 {
+			i++
+			continue
+		}
+		if isBusy(item.ID) {
+			i++
+			continue
+		}
+		obj := Objs.ByID(item.ID)
+		if isLevelable {
+			price := obj.GetPrice(levels.ByID(item.ID) + 1)
+			if !resources.CanAfford(price) {
+				i++
+				continue
+			}
+			if item.ID.IsTech() {
+				err = q.b.BuildTechnology(celestialID, item.ID)
+			} else {
+				err = q.b.BuildBuilding(celestialID, item.ID)
+			}
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+				i++
+				continue
+			}
+			resources = resources.Sub(price)
+			if item.ID.IsTech() {
+				researchID = item.ID
+			} else {
+				buildingID = item.ID
+			}
+			started = append(started, item)
+			i++
+			continue
+		}
+		price := obj.GetPrice(1)
+		nbr := MinInt(resources.Div(price), item.Nbr)
+		if nbr <= 0 {
+			i++
+			continue
+		}
+		if err := q.b.BuildProduction(celestialID, item.ID, nbr); err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			i++
+			continue
+		}
+		resources = resources.Sub(price.Mul(nbr))
+		production = append(production, Quantifiable{ID: item.ID, Nbr: nbr})
+		started = append(started, BuildQueueItem{ItemID: item.ItemID, CelestialID: celestialID, ID: item.ID, Nbr: nbr})
+		ops = append(ops, buildQueueOp{itemID: item.ItemID, done: nbr})
+		if nbr == item.Nbr {
+			items = append(items[:i], items[i+1:]...)
+			continue
+		}
+		items[i].Nbr -= nbr
+		i++
+	}
+	return started, firstErr
+}
+
+// Estimate projects when every item of the celestial queue will start and end, using the current production
+func (q *BuildQueue) Estimate(celestialID CelestialID) ([]BuildQueueEstimate, error) {
+	q.Lock()
+	items := append([]BuildQueueItem{}, q.queues[celestialID]...)
+	q.Unlock()
+
+	levels, err := q.getLevels(celestialID)
+	if err != nil {
+		return nil, err
+	}
+	details, err := q.b.GetResourcesDetails(celestialID)
+	if err != nil {
+		return nil, err
+	}
+	_, buildingCountdown, _, researchCountdown := q.b.ConstructionsBeingBuilt(celestialID)
+	_, productionCountdown, err := q.b.GetProduction(celestialID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := q.b.ServerTime()
+	available := []float64{float64(details.Metal.Available), float64(details.Crystal.Available), float64(details.Deuterium.Available)}
+	perHour := []float64{float64(details.Metal.CurrentProduction), float64(details.Crystal.CurrentProduction), float64(details.Deuterium.CurrentProduction)}
+	laneFree := map[buildLane]time.Time{
+		buildingLane: now.Add(time.Duration(buildingCountdown) * time.Second),
+		researchLane: now.Add(time.Duration(researchCountdown) * time.Second),
+		shipyardLane: now.Add(time.Duration(productionCountdown) * time.Second),
+	}
+	cursor := now
+
+	// step simulates a single level (or a batch of units), returns false if the resources are never produced
+	step := func(id ID, nbr int64) (start, end time.Time, ok bool) {
+		lane := laneOf(id)
+		obj := Objs.ByID(id)
+		var price Resources
+		if lane == shipyardLane {
+			price = obj.GetPrice(1).Mul(nbr)
+		} else {
+			price = obj.GetPrice(nbr)
+		}
+		needed := []float64{float64(price.Metal), float64(price.Crystal), float64(price.Deuterium)}
+		var waitHours float64
+		for r := range needed {
+			if needed[r] <= available[r] {
+				continue
+			}
+			if perHour[r] <= 0 {
+				return start, end, false
+			}
+			waitHours = math.Max(waitHours, (needed[r]-available[r])/perHour[r])
+		}
+		start = cursor.Add(time.Duration(math.Ceil(waitHours*3600)) * time.Second)
+		if laneFree[lane].After(start) {
+			start = laneFree[lane]
+		}
+		elapsedHours := start.Sub(cursor).Hours()
+		for r := range available {
+			available[r] += perHour[r]*elapsedHours - needed[r]
+		}
+		cursor = start
+		end = start.Add(q.b.ConstructionTime(id, nbr, levels.facilities))
+		laneFree[lane] = end
+		return start, end, true
+	}
+
+	// estimate simulates the item, level by level for buildings and technologies
+	estimate := func(item BuildQueueItem) BuildQueueEstimate {
+		est := BuildQueueEstimate{Item: item}
+		if laneOf(item.ID) == shipyardLane {
+			start, end, ok := step(item.ID, item.Nbr)
+			est.StartAt, est.EndAt, est.Unreachable = start, end, !ok
+			levels.Set(item.ID, levels.ByID(item.ID)+item.Nbr)
+			return est
+		}
+		for lvl := levels.ByID(item.ID) + 1; lvl <= item.Nbr; lvl++ {
+			start, end, ok := step(item.ID, lvl)
+			if !ok {
+				est.Unreachable = true
+				return est
+			}
+			if est.StartAt.IsZero() {
+				est.StartAt = start
+			}
+			est.EndAt = end
+			levels.Set(item.ID, lvl)
+		}
+		if est.StartAt.IsZero() {
+			est.StartAt, est.EndAt = now, now
+		}
+		return est
+	}
+
+	out := make([]BuildQueueEstimate, 0, len(items))
+	unreachable := false
+	for i, item := range items {
+		// Prerequisites are not in the queue yet, they will be inserted by Tick
+		for _, req := range pendingRequirements(item.ID, levels, items[:i]) {
+			if est := estimate(BuildQueueItem{CelestialID: celestialID, ID: req.ID, Nbr: req.Nbr}); est.Unreachable {
+				unreachable = true
+			}
+		}
+		est := estimate(item)
+		if unreachable {
+			est.Unreachable = true
+		}
+		unreachable = est.Unreachable
+		out = append(out, est)
+	}
+	return out, nil
+}
+
+// Run calls Tick every interval until stop is closed
+func (q *BuildQueue) Run(interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		_, _ = q.Tick()
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
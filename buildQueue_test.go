@@ -0,0 +1,185 @@
+package ogame
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMissingRequirements(t *testing.T) {
+	var levels techLevels
+	levels.facilities.ResearchLab = 1
+	reqs := missingRequirements(ImpulseDriveID, levels.ByID)
+	assert.Equal(t, []Quantifiable{{ID: ResearchLabID, Nbr: 2}, {ID: EnergyTechnologyID, Nbr: 1}}, reqs)
+	assert.Equal(t, 0, len(missingRequirements(MetalMineID, levels.ByID)))
+}
+
+type fakeBuildQueueWrapper struct {
+	*fakeBot
+	resBuildings ResourcesBuildings
+	facilities   Facilities
+	buildingID   ID
+	researchID   ID
+	production   []Quantifiable
+	resources    Resources
+	details      ResourcesDetails
+	fetching     chan struct{}
+}
+
+func newFakeBuildQueueWrapper() *fakeBuildQueueWrapper {
+	return &fakeBuildQueueWrapper{fakeBot: newFakeBot()}
+}
+
+func (w *fakeBuildQueueWrapper) GetTechs(CelestialID) (ResourcesBuildings, Facilities, ShipsInfos, DefensesInfos, Researches, error) {
+	return w.resBuildings, w.facilities, ShipsInfos{}, DefensesInfos{}, w.researches, nil
+}
+
+func (w *fakeBuildQueueWrapper) ConstructionsBeingBuilt(CelestialID) (ID, int64, ID, int64) {
+	return w.buildingID, 0, w.researchID, 0
+}
+
+func (w *fakeBuildQueueWrapper) GetProduction(CelestialID) ([]Quantifiable, int64, error) {
+	return w.production, 0, nil
+}
+func (w *fakeBuildQueueWrapper) GetResources(CelestialID) (Resources, error) {
+	if w.fetching != nil {
+		w.fetching <- struct{}{}
+		<-w.fetching
+	}
+	return w.resources, nil
+}
+func (w *fakeBuildQueueWrapper) GetResourcesDetails(CelestialID) (ResourcesDetails, error) {
+	return w.details, nil
+}
+
+func (w *fakeBuildQueueWrapper) BuildBuilding(celestialID CelestialID, buildingID ID) error {
+	w.buildingID = buildingID
+	return nil
+}
+
+func (w *fakeBuildQueueWrapper) BuildTechnology(celestialID CelestialID, technologyID ID) error {
+	w.researchID = technologyID
+	return nil
+}
+
+func (w *fakeBuildQueueWrapper) BuildProduction(celestialID CelestialID, id ID, nbr int64) error {
+	w.production = append(w.production, Quantifiable{ID: id, Nbr: nbr})
+	return nil
+}
+
+func (w *fakeBuildQueueWrapper) ConstructionTime(id ID, nbr int64, facilities Facilities) time.Duration {
+	return Objs.ByID(id).ConstructionTime(nbr, 1, facilities, false, false)
+}
+
+func TestBuildQueue_CRUD(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "buildqueue")
+	defer os.RemoveAll(dir)
+	filename := filepath.Join(dir, "queue.json")
+	q, err := NewFileBuildQueue(nil, filename)
+	assert.NoError(t, err)
+	item1, _ := q.Add(1, MetalMineID, 5)
+	item2, _ := q.Add(1, LightFighterID, 10)
+	_, _ = q.Add(2, CrystalMineID, 3)
+	_, err = q.Add(1, ID(1234), 1)
+	assert.Error(t, err)
+	_, err = q.Add(1, MetalMineID, 0)
+	assert.Error(t, err)
+
+	_, _ = q.Update(1, item2.ItemID, 20)
+	assert.NoError(t, q.Move(1, item2.ItemID, 0))
+	assert.Equal(t, ErrBuildQueueItemNotFound, q.Remove(1, 123))
+
+	q2, err := NewFileBuildQueue(nil, filename)
+	assert.NoError(t, err)
+	items := q2.Get(1)
+	assert.Equal(t, 2, len(items))
+	assert.Equal(t, BuildQueueItem{ItemID: item2.ItemID, CelestialID: 1, ID: LightFighterID, Nbr: 20}, items[0])
+	assert.Equal(t, item1, items[1])
+	item, _ := q2.Add(1, DeuteriumSynthesizerID, 1)
+	assert.Equal(t, int64(4), item.ItemID)
+
+	assert.NoError(t, q2.Remove(1, item1.ItemID))
+	assert.NoError(t, q2.Clear(2))
+	assert.Equal(t, 1, len(q2.GetAll()))
+}
+
+func TestBuildQueue_Tick(t *testing.T) {
+	w := newFakeBuildQueueWrapper()
+	w.resources = Resources{Metal: 1000, Crystal: 1000, Deuterium: 1000}
+	q := NewBuildQueue(w)
+	_, _ = q.Add(1, MetalMineID, 1)
+	_, _ = q.Add(1, CrystalMineID, 1)
+	_, _ = q.Add(1, EspionageTechnologyID, 1)
+	_, _ = q.Add(1, RocketLauncherID, 5)
+
+	started, err := q.Tick()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(started))
+	assert.Equal(t, MetalMineID, started[0].ID)
+	ids := make([]ID, 0)
+	for _, item := range q.Get(1) {
+		ids = append(ids, item.ID)
+	}
+	assert.Equal(t, []ID{MetalMineID, CrystalMineID, ResearchLabID, EspionageTechnologyID, RoboticsFactoryID, ShipyardID, RocketLauncherID}, ids)
+	assert.Equal(t, int64(3), q.Get(1)[2].Nbr)
+
+	// Metal mine is done, the shipyard was built manually
+	w.resBuildings.MetalMine = 1
+	w.facilities.Shipyard = 1
+	w.buildingID = 0
+	w.resources = Resources{Metal: 10000, Crystal: 1000, Deuterium: 1000}
+	started, err = q.Tick()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(started))
+	assert.Equal(t, CrystalMineID, started[0].ID)
+	assert.Equal(t, BuildQueueItem{ItemID: 4, CelestialID: 1, ID: RocketLauncherID, Nbr: 4}, started[1])
+	items := q.Get(1)
+	assert.Equal(t, 5, len(items))
+	assert.Equal(t, RocketLauncherID, items[4].ID)
+	assert.Equal(t, int64(1), items[4].Nbr)
+}
+
+func TestBuildQueue_TickUnlocked(t *testing.T) {
+	w := newFakeBuildQueueWrapper()
+	w.resources = Resources{Metal: 1000, Crystal: 1000}
+	w.fetching = make(chan struct{})
+	q := NewBuildQueue(w)
+	item1, _ := q.Add(1, MetalMineID, 1)
+	_, _ = q.Add(1, CrystalMineID, 1)
+	done := make(chan []BuildQueueItem)
+	go func() {
+		started, _ := q.Tick()
+		done <- started
+	}()
+
+	// The queue can be edited while the tick fetches the planet
+	<-w.fetching
+	assert.NoError(t, q.Remove(1, item1.ItemID))
+	item3, _ := q.Add(1, SolarPlantID, 1)
+	w.fetching <- struct{}{}
+	started := <-done
+	assert.Equal(t, MetalMineID, started[0].ID)
+	assert.Equal(t, []BuildQueueItem{{ItemID: 2, CelestialID: 1, ID: CrystalMineID, Nbr: 1}, item3}, q.Get(1))
+}
+
+func TestBuildQueue_Estimate(t *testing.T) {
+	w := newFakeBuildQueueWrapper()
+	w.details.Metal.CurrentProduction = 3600
+	w.details.Crystal.CurrentProduction = 3600
+	q := NewBuildQueue(w)
+	_, _ = q.Add(1, MetalMineID, 2)
+	_, _ = q.Add(1, EspionageTechnologyID, 1)
+
+	estimates, err := q.Estimate(1)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(estimates))
+	assert.Equal(t, w.now.Add(60*time.Second), estimates[0].StartAt)
+	// Level 2 waits for its 90 metal once level 1 was paid
+	assert.Equal(t, w.now.Add(150*time.Second+w.ConstructionTime(MetalMineID, 2, Facilities{})), estimates[0].EndAt)
+	assert.False(t, estimates[0].Unreachable)
+	assert.True(t, estimates[1].Unreachable) // No deuterium production
+}
@@ -0,0 +1,57 @@
+package ogame
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// ChatConversation conversation listed on the chat page
+type ChatConversation struct {
+	PlayerID      int64 // 0 for the alliance conversation
+	PlayerName    string
+	AssociationID int64 // Alliance chat id, 0 for a player conversation
+	LastMessage   string
+	LastDate      time.Time
+	Unread        int64
+}
+
+// IsAlliance returns either or not the conversation is the alliance chat
+func (c ChatConversation) IsAlliance() bool {
+	return c.AssociationID != 0
+}
+
+// Buddy player in our buddy list
+type Buddy struct {
+	ID           int64 // Buddy relation id, used to remove the buddy
+	PlayerID     int64
+	PlayerName   string
+	Points       int64
+	Rank         int64
+	AllianceName string
+	Home         Coordinate
+	Online       bool
+}
+
+// IgnoredPlayer player in our ignore list
+type IgnoredPlayer struct {
+	PlayerID   int64
+	PlayerName string
+}
+
+// chatHistoryResp response of the ajaxChat history request
+type chatHistoryResp struct {
+	ChatItems []ChatMsg `json:"chatItems"`
+	NewToken  string    `json:"newToken"`
+}
+
+// parseChatHistory parses the messages of a conversation, most recent last
+func parseChatHistory(pageJSON []byte) ([]ChatMsg, string, error) {
+	var res chatHistoryResp
+	if err := json.Unmarshal(pageJSON, &res); err != nil {
+		return nil, "", err
+	}
+	if res.ChatItems == nil {
+		res.ChatItems = make([]ChatMsg, 0)
+	}
+	return res.ChatItems, res.NewToken, nil
+}
@@ -0,0 +1,23 @@
+package ogame
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseChatHistory(t *testing.T) {
+	pageJSON := []byte(`{"status":"OK","chatItems":[{"id":9001,"senderId":100234,"senderName":"Deimos","associationId":0,"text":"hi","date":1600022855},{"id":9002,"senderId":100001,"senderName":"Me","associationId":0,"text":"hello","date":1600022901}],"newToken":"e20cf0a6ca0e9b43a81ccb8fe7e7e2e3"}`)
+	msgs, token, err := parseChatHistory(pageJSON)
+	assert.NoError(t, err)
+	assert.Equal(t, "e20cf0a6ca0e9b43a81ccb8fe7e7e2e3", token)
+	assert.Equal(t, 2, len(msgs))
+	assert.Equal(t, ChatMsg{ID: 9001, SenderID: 100234, SenderName: "Deimos", Text: "hi", Date: 1600022855}, msgs[0])
+
+	msgs, _, err = parseChatHistory([]byte(`{"status":"OK","newToken":"abc"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(msgs))
+
+	_, _, err = parseChatHistory([]byte(`<html></html>`))
+	assert.Error(t, err)
+}
@@ -5,6 +5,7 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"time"
 
 	"github.com/alaingilbert/ogame"
 	"github.com/labstack/echo"
@@ -146,6 +147,12 @@ func main() {
 			Value:   "",
 			EnvVars: []string{"OGAMED_COOKIES_FILENAME"},
 		},
+		&cli.StringFlag{
+			Name:    "build-queue-filename",
+			Usage:   "Path build queue file, the build queue is disabled if empty",
+			Value:   "",
+			EnvVars: []string{"OGAMED_BUILD_QUEUE_FILENAME"},
+		},
 		&cli.BoolFlag{
 			Name:    "cors-enabled",
 			Usage:   "Enable CORS",
@@ -186,6 +193,7 @@ func start(c *cli.Context) error {
 	basicAuthUsername := c.String("basic-auth-username")
 	basicAuthPassword := c.String("basic-auth-password")
 	cookiesFilename := c.String("cookies-filename")
+	buildQueueFilename := c.String("build-queue-filename")
 	corsEnabled := c.Bool("cors-enabled")
 	njaApiKey := c.String("nja-api-key")
 
@@ -213,6 +221,16 @@ func start(c *cli.Context) error {
 		return err
 	}
 
+	var buildQueue *ogame.BuildQueue
+	if buildQueueFilename != "" {
+		if buildQueue, err = ogame.NewFileBuildQueue(bot, buildQueueFilename); err != nil {
+			return err
+		}
+		stopBuildQueue := make(chan struct{})
+		defer close(stopBuildQueue)
+		go buildQueue.Run(time.Minute, stopBuildQueue)
+	}
+
 	e := echo.New()
 	if corsEnabled {
 		e.Use(middleware.CORS())
@@ -220,6 +238,9 @@ func start(c *cli.Context) error {
 	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(ctx echo.Context) error {
 			ctx.Set("bot", bot)
+			if buildQueue != nil {
+				ctx.Set("buildQueue", buildQueue)
+			}
 			ctx.Set("version", version)
 			ctx.Set("commit", commit)
 			ctx.Set("date", date)
@@ -255,6 +276,7 @@ func start(c *cli.Context) error {
 	e.GET("/bot/server-url", ogame.ServerURLHandler)
 	e.GET("/bot/language", ogame.GetLanguageHandler)
 	e.GET("/bot/empire/type/:typeID", ogame.GetEmpireHandler)
+	e.GET("/bot/empire/celestials/:type", ogame.GetEmpireCelestialsHandler)
 	e.POST("/bot/page-content", ogame.PageContentHandler)
 	e.GET("/bot/login", ogame.LoginHandler)
 	e.GET("/bot/logout", ogame.LogoutHandler)
@@ -274,6 +296,25 @@ func start(c *cli.Context) error {
 	e.GET("/bot/has-geologist", ogame.HasGeologistHandler)
 	e.GET("/bot/has-technocrat", ogame.HasTechnocratHandler)
 	e.POST("/bot/send-message", ogame.SendMessageHandler)
+	e.GET("/bot/chat/conversations", ogame.GetChatConversationsHandler)
+	e.GET("/bot/chat/players/:playerID", ogame.GetChatHistoryHandler)
+	e.POST("/bot/chat/players/:playerID/read", ogame.MarkChatAsReadHandler)
+	e.GET("/bot/chat/alliances/:associationID", ogame.GetAllianceChatHistoryHandler)
+	e.POST("/bot/chat/alliances/:associationID/read", ogame.MarkAllianceChatAsReadHandler)
+	e.GET("/bot/buddies", ogame.GetBuddiesHandler)
+	e.POST("/bot/buddies", ogame.AddBuddyHandler)
+	e.DELETE("/bot/buddies/:buddyID", ogame.RemoveBuddyHandler)
+	e.GET("/bot/ignored-players", ogame.GetIgnoredPlayersHandler)
+	e.POST("/bot/ignored-players", ogame.IgnorePlayerHandler)
+	e.DELETE("/bot/ignored-players/:playerID", ogame.UnignorePlayerHandler)
+	e.GET("/bot/alliance", ogame.GetAllianceOverviewHandler)
+	e.GET("/bot/alliance/ranks", ogame.GetAllianceRanksHandler)
+	e.GET("/bot/alliance/applications", ogame.GetAllianceApplicationsHandler)
+	e.POST("/bot/alliance/applications/:applicationID/accept", ogame.AcceptAllianceApplicationHandler)
+	e.POST("/bot/alliance/applications/:applicationID/deny", ogame.DenyAllianceApplicationHandler)
+	e.POST("/bot/alliance/circular", ogame.SendAllianceCircularHandler)
+	e.POST("/bot/alliance/class", ogame.SetAllianceClassHandler)
+	e.GET("/bot/alliances/:allianceID", ogame.GetAlliancePublicInfoHandler)
 	e.GET("/bot/fleets", ogame.GetFleetsHandler)
 	e.GET("/bot/fleets/slots", ogame.GetSlotsHandler)
 	e.POST("/bot/fleets/:fleetID/cancel", ogame.CancelFleetHandler)
@@ -283,9 +324,18 @@ func start(c *cli.Context) error {
 	e.POST("/bot/delete-report/:messageID", ogame.DeleteMessageHandler)
 	e.POST("/bot/delete-all-espionage-reports", ogame.DeleteEspionageMessagesHandler)
 	e.POST("/bot/delete-all-reports/:tabIndex", ogame.DeleteMessagesFromTabHandler)
+	e.GET("/bot/messages/tabs/:tabID", ogame.GetMessagesHandler)
+	e.POST("/bot/messages/:messageID/favorite", ogame.FavoriteMessageHandler)
+	e.POST("/bot/messages/:messageID/unfavorite", ogame.UnfavoriteMessageHandler)
 	e.GET("/bot/attacks", ogame.GetAttacksHandler)
 	e.GET("/bot/get-auction", ogame.GetAuctionHandler)
 	e.POST("/bot/do-auction", ogame.DoAuctionHandler)
+	e.GET("/bot/marketplace/offers", ogame.GetMarketplaceOffersHandler)
+	e.POST("/bot/marketplace/offers", ogame.CreateMarketplaceOfferHandler)
+	e.POST("/bot/marketplace/offers/:offerID/buy", ogame.BuyMarketplaceHandler)
+	e.POST("/bot/marketplace/offers/:offerID/sell", ogame.SellMarketplaceHandler)
+	e.GET("/bot/marketplace/my-offers", ogame.GetMyMarketplaceOffersHandler)
+	e.POST("/bot/marketplace/my-offers/:offerID/cancel", ogame.CancelMarketplaceOfferHandler)
 	e.GET("/bot/galaxy-infos/:galaxy/:system", ogame.GalaxyInfosHandler)
 	e.GET("/bot/get-research", ogame.GetResearchHandler)
 	e.GET("/bot/buy-offer-of-the-day", ogame.BuyOfferOfTheDayHandler)
@@ -323,6 +373,40 @@ func start(c *cli.Context) error {
 	e.POST("/bot/planets/:planetID/send-ipm", ogame.SendIPMHandler)
 	e.GET("/bot/moons/:moonID/phalanx/:galaxy/:system/:position", ogame.PhalanxHandler)
 	e.POST("/bot/moons/:moonID/jump-gate", ogame.JumpGateHandler)
+	e.GET("/bot/highscore/:category/:type/:page", ogame.HighscoreHandler)
+	e.GET("/bot/all-resources", ogame.GetAllResourcesHandler)
+	e.POST("/bot/officers/recruit", ogame.RecruitOfficerHandler)
+	e.POST("/bot/send-message-alliance", ogame.SendMessageAllianceHandler)
+	e.GET("/bot/expedition-messages", ogame.GetExpeditionMessagesHandler)
+	e.GET("/bot/expedition-messages/:timestamp", ogame.GetExpeditionMessageAtHandler)
+	e.GET("/bot/combat-reports", ogame.GetCombatReportMessagesHandler)
+	e.GET("/bot/combat-reports/:galaxy/:system/:position", ogame.GetCombatReportSummaryForHandler)
+	e.GET("/bot/fleets/event-list", ogame.GetFleetsFromEventListHandler)
+	e.POST("/bot/fleets/:fleetID/union", ogame.CreateUnionHandler)
+	e.POST("/bot/flight-time", ogame.FlightTimeHandler)
+	e.GET("/bot/marketplace/messages", ogame.GetMarketplaceMessagesHandler)
+	e.POST("/bot/marketplace/messages/collect", ogame.CollectAllMarketplaceMessagesHandler)
+	e.POST("/bot/marketplace/messages/:messageID/collect", ogame.CollectMarketplaceMessageHandler)
+	e.GET("/bot/celestials", ogame.GetCelestialsHandler)
+	e.GET("/bot/celestials/:celestialID", ogame.GetCelestialHandler)
+	e.GET("/bot/celestials/:celestialID/active-items", ogame.GetActiveItemsHandler)
+	e.GET("/bot/celestials/:celestialID/dm-costs", ogame.GetDMCostsHandler)
+	e.POST("/bot/celestials/:celestialID/use-dm", ogame.UseDMHandler)
+	e.GET("/bot/celestials/:celestialID/resource-merchant", ogame.GetResourceMerchantHandler)
+	e.POST("/bot/celestials/:celestialID/resource-merchant/call", ogame.CallResourceMerchantHandler)
+	e.POST("/bot/celestials/:celestialID/resource-merchant/trade", ogame.TradeResourceMerchantHandler)
+	e.POST("/bot/celestials/:celestialID/ensure-fleet", ogame.EnsureFleetHandler)
+	e.GET("/bot/build-queues", ogame.GetBuildQueuesHandler)
+	e.GET("/bot/celestials/:celestialID/build-queue", ogame.GetBuildQueueHandler)
+	e.POST("/bot/celestials/:celestialID/build-queue", ogame.AddBuildQueueItemHandler)
+	e.DELETE("/bot/celestials/:celestialID/build-queue", ogame.ClearBuildQueueHandler)
+	e.GET("/bot/celestials/:celestialID/build-queue/estimate", ogame.GetBuildQueueEstimateHandler)
+	e.POST("/bot/celestials/:celestialID/build-queue/:itemID", ogame.UpdateBuildQueueItemHandler)
+	e.DELETE("/bot/celestials/:celestialID/build-queue/:itemID", ogame.DeleteBuildQueueItemHandler)
+	e.GET("/bot/planets/:planetID/resources-productions", ogame.GetResourcesProductionsHandler)
+	e.POST("/bot/planets/:planetID/abandon", ogame.AbandonHandler)
+	e.POST("/bot/planets/:planetID/destroy-rockets", ogame.DestroyRocketsHandler)
+	e.GET("/bot/moons/:moonID/jump-gate/destinations", ogame.JumpGateDestinationsHandler)
 	e.GET("/game/allianceInfo.php", ogame.GetAlliancePageContentHandler) // Example: //game/allianceInfo.php?allianceId=500127
 
 	// Get/Post Page Content
@@ -0,0 +1,383 @@
+package ogame
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DebrisSource where a debris field was found
+type DebrisSource string
+
+// Debris sources
+const (
+	CombatReportDebris DebrisSource = "combatReport"
+	GalaxyDebris       DebrisSource = "galaxy"
+	ExpeditionDebris   DebrisSource = "expedition" // Position 16
+)
+
+// DebrisField debris field worth harvesting
+type DebrisField struct {
+	Coordinate Coordinate // Always a debris type coordinate
+	Source     DebrisSource
+	Resources  Resources
+	SeenAt     time.Time
+}
+
+// HarvestRecord a harvest fleet sent by the DebrisHarvester
+type HarvestRecord struct {
+	Field       DebrisField
+	Origin      Celestial
+	Ships       ShipsInfos
+	Fleet       Fleet
+	SentAt      time.Time
+	Checked     bool      // Debris field was checked before arrival
+	Unchecked   bool      // Fleet arrived before the debris field could be checked
+	Recalled    bool      // Fleet recalled because the debris field was gone
+	Harvested   Resources // Resources carried back by the fleet
+	Done        bool
+	CompletedAt time.Time
+}
+
+// DebrisHarvester sends recyclers (or pathfinders) to our combat reports and scanned galaxy debris fields
+type DebrisHarvester struct {
+	sync.Mutex
+	b             Wrapper
+	store         GalaxyStore
+	origins       []Celestial
+	harvestShips  []ID
+	minDebris     int64
+	speed         Speed
+	recheckBefore time.Duration
+	records       []*HarvestRecord
+	lastSent      map[Coordinate]time.Time
+}
+
+// NewDebrisHarvester ...
+func NewDebrisHarvester(b Wrapper) *DebrisHarvester {
+	h := new(DebrisHarvester)
+	h.b = b
+	h.harvestShips = []ID{RecyclerID, PathfinderID}
+	h.minDebris = 1
+	h.speed = HundredPercent
+	h.recheckBefore = time.Minute
+	h.lastSent = make(map[Coordinate]time.Time)
+	return h
+}
+
+// SetGalaxyStore sets the store of the galaxy scanner, its debris fields are harvested as well
+func (h *DebrisHarvester) SetGalaxyStore(store GalaxyStore) *DebrisHarvester {
+	h.store = store
+	return h
+}
+
+// SetOrigins restricts the celestials harvest fleets are sent from (all celestials by default)
+func (h *DebrisHarvester) SetOrigins(origins ...interface{}) *DebrisHarvester {
+	h.origins = make([]Celestial, 0, len(origins))
+	for _, origin := range origins {
+		if c := h.b.GetCachedCelestial(origin); c != nil {
+			h.origins = append(h.origins, c)
+		}
+	}
+	return h
+}
+
+// SetHarvestShips sets the ships used to harvest, in order of preference (Recycler then Pathfinder by default).
+// Only pathfinders can harvest the expedition debris at position 16.
+func (h *DebrisHarvester) SetHarvestShips(ids ...ID) *DebrisHarvester {
+	h.harvestShips = ids
+	return h
+}
+
+// SetMinDebris sets the minimum amount of resources a debris field must have to be harvested
+func (h *DebrisHarvester) SetMinDebris(amount int64) *DebrisHarvester {
+	h.minDebris = amount
+	return h
+}
+
+// SetSpeed ...
+func (h *DebrisHarvester) SetSpeed(speed Speed) *DebrisHarvester {
+	h.speed = speed
+	return h
+}
+
+// SetRecheckBefore sets how long before arrival the debris field is checked again, the fleet is recalled if
+// someone else harvested it first
+func (h *DebrisHarvester) SetRecheckBefore(d time.Duration) *DebrisHarvester {
+	h.recheckBefore = d
+	return h
+}
+
+// Records returns the harvest fleets sent
+func (h *DebrisHarvester) Records() []HarvestRecord {
+	h.Lock()
+	defer h.Unlock()
+	out := make([]HarvestRecord, 0, len(h.records))
+	for _, r := range h.records {
+		out = append(out, *r)
+	}
+	return out
+}
+
+// Harvested returns the total of resources harvested
+func (h *DebrisHarvester) Harvested() (out Resources) {
+	h.Lock()
+	defer h.Unlock()
+	for _, r := range h.records {
+		out = out.Add(r.Harvested)
+	}
+	return
+}
+
+// FindDebrisFields returns the debris fields of our combat reports and of the galaxy store (if any)
+func (h *DebrisHarvester) FindDebrisFields() ([]DebrisField, error) {
+	fields := make(map[Coordinate]DebrisField)
+	add := func(field DebrisField) {
+		if field.Resources.Total() < h.minDebris {
+			return
+		}
+		if prev, ok := fields[field.Coordinate]; ok && prev.SeenAt.After(field.SeenAt) {
+			return
+		}
+		fields[field.Coordinate] = field
+	}
+	reports, err := h.b.GetCombatReportMessages()
+	if err != nil {
+		return nil, err
+	}
+	for _, report := range reports {
+		if report.DebrisField > 0 {
+			// Combat reports only give the total, the split is known once the galaxy is checked
+			add(DebrisField{Coordinate: report.Destination.Debris(), Source: CombatReportDebris,
+				Resources: Resources{Metal: report.DebrisField}, SeenAt: report.CreatedAt})
+		}
+	}
+	if h.store != nil {
+		snapshots, err := h.store.GetSystems()
+		if err != nil {
+			return nil, err
+		}
+		for _, snapshot := range snapshots {
+			for _, p := range snapshot.Planets {
+				if p.Debris.Metal+p.Debris.Crystal > 0 {
+					add(DebrisField{Coordinate: p.Coordinate.Debris(), Source: GalaxyDebris,
+						Resources: Resources{Metal: p.Debris.Metal, Crystal: p.Debris.Crystal}, SeenAt: snapshot.ScannedAt})
+				}
+			}
+			if snapshot.ExpeditionDebris.Metal+snapshot.ExpeditionDebris.Crystal > 0 {
+				coord := Coordinate{Galaxy: snapshot.Galaxy, System: snapshot.System, Position: 16, Type: DebrisType}
+				add(DebrisField{Coordinate: coord, Source: ExpeditionDebris,
+					Resources: Resources{Metal: snapshot.ExpeditionDebris.Metal, Crystal: snapshot.ExpeditionDebris.Crystal}, SeenAt: snapshot.ScannedAt})
+			}
+		}
+	}
+	out := make([]DebrisField, 0, len(fields))
+	for _, field := range fields {
+		out = append(out, field)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Resources.Total() > out[j].Resources.Total() })
+	return out, nil
+}
+
+// harvestShipsFor returns the ships needed to carry the debris field, from the available ones
+func (h *DebrisHarvester) harvestShipsFor(field DebrisField, available ShipsInfos) (ships ShipsInfos) {
+	techs := h.b.GetCachedResearch()
+	probeRaids := h.b.GetServer().Settings.EspionageProbeRaids == 1
+	isCollector := h.b.CharacterClass().IsCollector()
+	isPioneers := h.b.IsPioneers()
+	remaining := field.Resources.Total()
+	for _, id := range h.harvestShips {
+		if field.Coordinate.Position == 16 && id != PathfinderID {
+			continue
+		}
+		ship, ok := Objs.ByID(id).(Ship)
+		if !ok || remaining <= 0 {
+			continue
+		}
+		nbr := MinInt(available.ByID(id), Resources{Metal: remaining}.FitsIn(ship, techs, probeRaids, isCollector, isPioneers))
+		if nbr <= 0 {
+			continue
+		}
+		ships.Set(id, nbr)
+		remaining -= ship.GetCargoCapacity(techs, probeRaids, isCollector, isPioneers) * nbr
+	}
+	return ships
+}
+
+// dispatch sends harvest fleets from the nearest celestial having harvest ships
+func (h *DebrisHarvester) dispatch(field DebrisField) (*HarvestRecord, error) {
+	origins := h.origins
+	if origins == nil {
+		origins = h.b.GetCachedCelestials()
+	}
+	origins = append([]Celestial{}, origins...)
+	sort.SliceStable(origins, func(i, j int) bool {
+		return h.b.Distance(origins[i].GetCoordinate(), field.Coordinate) < h.b.Distance(origins[j].GetCoordinate(), field.Coordinate)
+	})
+	for _, origin := range origins {
+		available, err := h.b.GetShips(origin.GetID())
+		if err != nil {
+			return nil, err
+		}
+		ships := h.harvestShipsFor(field, available)
+		if !ships.HasShips() {
+			continue
+		}
+		fleet, err := NewFleetBuilder(h.b).
+			SetOrigin(origin).
+			SetDestination(field.Coordinate).
+			SetMission(RecycleDebrisField).
+			SetSpeed(h.speed).
+			SetShips(ships).
+			SendNow()
+		if err != nil {
+			return nil, err
+		}
+		return &HarvestRecord{Field: field, Origin: origin, Ships: ships, Fleet: fleet, SentAt: h.b.ServerTime()}, nil
+	}
+	return nil, nil
+}
+
+// recheck looks at the debris field shortly before arrival and recalls the fleet if it is gone
+func (h *DebrisHarvester) recheck(r *HarvestRecord) error {
+	coord := r.Field.Coordinate
+	systemInfos, err := h.b.GalaxyInfos(coord.Galaxy, coord.System)
+	if err != nil {
+		return err
+	}
+	r.Checked = true
+	var remaining int64
+	if coord.Position == 16 {
+		remaining = systemInfos.ExpeditionDebris.Metal + systemInfos.ExpeditionDebris.Crystal
+	} else if p := systemInfos.Position(coord.Position); p != nil {
+		remaining = p.Debris.Metal + p.Debris.Crystal
+	}
+	if remaining > 0 {
+		return nil
+	}
+	if err := h.b.CancelFleet(r.Fleet.ID); err != nil {
+		return err
+	}
+	r.Recalled = true
+	return nil
+}
+
+// update re-checks the fleets about to arrive, and records what the returning fleets carry
+func (h *DebrisHarvester) update() error {
+	now := h.b.ServerTime()
+	var fleets []Fleet
+	fleetsLoaded := false
+	for _, r := range h.records {
+		if r.Done {
+			continue
+		}
+		if !r.Checked && !r.Unchecked && !now.Before(r.Fleet.ArrivalTime.Add(-h.recheckBefore)) {
+			if now.Before(r.Fleet.ArrivalTime) {
+				if err := h.recheck(r); err != nil {
+					return err
+				}
+			} else {
+				r.Unchecked = true
+			}
+		}
+		if r.Recalled {
+			r.Done, r.CompletedAt = true, now
+			continue
+		}
+		if now.Before(r.Fleet.ArrivalTime) {
+			continue
+		}
+		if !fleetsLoaded {
+			var slots Slots
+			fleets, slots = h.b.GetFleets()
+			if slots.Total == 0 {
+				// The movement page always shows the slots, the page failed to load
+				return errors.New("failed to get the fleets")
+			}
+			fleetsLoaded = true
+		}
+		found := false
+		for _, fleet := range fleets {
+			if fleet.ID == r.Fleet.ID {
+				found = true
+				r.Harvested = Resources{Metal: fleet.Resources.Metal, Crystal: fleet.Resources.Crystal}
+			}
+		}
+		if !found {
+			// Fleet is back home, keep the last known cargo
+			r.Done, r.CompletedAt = true, now
+		}
+	}
+	return nil
+}
+
+// Tick updates the ongoing harvests, then sends fleets to the new debris fields
+func (h *DebrisHarvester) Tick() ([]HarvestRecord, error) {
+	h.Lock()
+	defer h.Unlock()
+	if err := h.update(); err != nil {
+		return nil, err
+	}
+	fields, err := h.FindDebrisFields()
+	if err != nil {
+		return nil, err
+	}
+	sent := make([]HarvestRecord, 0)
+	for _, field := range fields {
+		if lastSent, ok := h.lastSent[field.Coordinate]; ok && !field.SeenAt.After(lastSent) {
+			continue
+		}
+		record, err := h.dispatch(field)
+		if err != nil {
+			return sent, err
+		}
+		if record == nil {
+			continue
+		}
+		h.lastSent[field.Coordinate] = record.SentAt
+		h.records = append(h.records, record)
+		sent = append(sent, *record)
+	}
+	return sent, nil
+}
+
+// nextRecheck returns when the next debris field must be checked again, if any
+func (h *DebrisHarvester) nextRecheck() (next time.Time, ok bool) {
+	h.Lock()
+	defer h.Unlock()
+	for _, r := range h.records {
+		if r.Done || r.Checked || r.Unchecked {
+			continue
+		}
+		at := r.Fleet.ArrivalTime.Add(-h.recheckBefore)
+		if !ok || at.Before(next) {
+			next, ok = at, true
+		}
+	}
+	return
+}
+
+// Run calls Tick every interval until stop is closed.
+// A tick is also scheduled as soon as a debris field must be checked again before a fleet arrives.
+func (h *DebrisHarvester) Run(interval time.Duration, stop <-chan struct{}) {
+	for {
+		_, _ = h.Tick()
+		wait := interval
+		if next, ok := h.nextRecheck(); ok {
+			if untilNext := next.Sub(h.b.ServerTime()); untilNext < wait {
+				wait = untilNext
+			}
+		}
+		if wait < time.Second {
+			wait = time.Second
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-stop:
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package ogame
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeDebrisWrapper struct {
+	*fakeFleets
+	combatMsgs []CombatReportSummary
+	systems    map[Coordinate]SystemInfos
+	cancelled  []FleetID
+}
+
+func newFakeDebrisWrapper() *fakeDebrisWrapper {
+	return &fakeDebrisWrapper{fakeFleets: newFakeFleets(), systems: make(map[Coordinate]SystemInfos)}
+}
+
+func (w *fakeDebrisWrapper) GetCombatReportMessages() ([]CombatReportSummary, error) {
+	return w.combatMsgs, nil
+}
+
+func (w *fakeDebrisWrapper) GalaxyInfos(galaxy, system int64, opts ...Option) (SystemInfos, error) {
+	return w.systems[Coordinate{Galaxy: galaxy, System: system}], nil
+}
+
+func (w *fakeDebrisWrapper) CancelFleet(fleetID FleetID) error {
+	w.cancelled = append(w.cancelled, fleetID)
+	return nil
+}
+
+func TestDebrisHarvester(t *testing.T) {
+	w := newFakeDebrisWrapper()
+	near := Planet{ID: 1, Coordinate: Coordinate{1, 100, 8, PlanetType}}
+	far := Planet{ID: 2, Coordinate: Coordinate{2, 100, 8, PlanetType}}
+	w.celestials = []Celestial{far, near}
+	w.ships[1] = ShipsInfos{Recycler: 10}
+	w.ships[2] = ShipsInfos{Recycler: 50, Pathfinder: 5}
+	w.flightTime = 30 * time.Minute
+	w.combatMsgs = []CombatReportSummary{{ID: 1, Destination: Coordinate{1, 101, 5, PlanetType}, DebrisField: 50000, CreatedAt: w.now}}
+	store := NewMemoryGalaxyStore()
+	snapshot := GalaxySnapshot{Galaxy: 1, System: 105, ScannedAt: w.now}
+	snapshot.ExpeditionDebris.Metal = 20000
+	_ = store.SaveSystem(snapshot)
+	h := NewDebrisHarvester(w).SetGalaxyStore(store)
+
+	sent, err := h.Tick()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(sent))
+	assert.Equal(t, Coordinate{1, 101, 5, DebrisType}, sent[0].Field.Coordinate)
+	assert.Equal(t, near.GetID(), sent[0].Origin.GetID())
+	assert.Equal(t, ShipsInfos{Recycler: 3}, sent[0].Ships)
+	assert.Equal(t, ExpeditionDebris, sent[1].Field.Source)
+	assert.Equal(t, far.GetID(), sent[1].Origin.GetID())
+	assert.Equal(t, ShipsInfos{Pathfinder: 2}, sent[1].Ships)
+	assert.Equal(t, RecycleDebrisField, w.sentFleets[0].Mission)
+
+	// Same debris fields are not harvested twice
+	sent, err = h.Tick()
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(sent))
+
+	// Someone else harvested the combat debris field before we arrive
+	w.now = w.now.Add(29*time.Minute + 30*time.Second)
+	w.systems[Coordinate{Galaxy: 1, System: 101}] = SystemInfos{galaxy: 1, system: 101}
+	expeditionSystem := SystemInfos{galaxy: 1, system: 105}
+	expeditionSystem.ExpeditionDebris.Metal = 20000
+	w.systems[Coordinate{Galaxy: 1, System: 105}] = expeditionSystem
+	_, err = h.Tick()
+	assert.NoError(t, err)
+	assert.Equal(t, []FleetID{w.sentFleets[0].ID}, w.cancelled)
+	records := h.Records()
+	assert.True(t, records[0].Recalled)
+	assert.True(t, records[1].Checked)
+	assert.False(t, records[1].Recalled)
+	_, ok := h.nextRecheck()
+	assert.False(t, ok)
+
+	// Returning fleet carries the harvested resources
+	w.now = w.now.Add(time.Minute)
+	w.sentFleets[1].Resources = Resources{Metal: 20000}
+	_, err = h.Tick()
+	assert.NoError(t, err)
+	assert.Equal(t, Resources{Metal: 20000}, h.Harvested())
+
+	w.now = w.now.Add(2 * time.Hour)
+	_, err = h.Tick()
+	assert.NoError(t, err)
+	assert.True(t, h.Records()[1].Done)
+	assert.Equal(t, Resources{Metal: 20000}, h.Harvested())
+}
+
+func TestDebrisHarvester_ArrivedBeforeRecheck(t *testing.T) {
+	w := newFakeDebrisWrapper()
+	w.celestials = []Celestial{Planet{ID: 1, Coordinate: Coordinate{1, 100, 8, PlanetType}}}
+	w.ships[1] = ShipsInfos{Recycler: 10}
+	w.flightTime = 30 * time.Minute
+	w.combatMsgs = []CombatReportSummary{{ID: 1, Destination: Coordinate{1, 101, 5, PlanetType}, DebrisField: 50000, CreatedAt: w.now}}
+	h := NewDebrisHarvester(w).SetRecheckBefore(2 * time.Minute)
+	_, _ = h.Tick()
+	next, ok := h.nextRecheck()
+	assert.True(t, ok)
+	assert.Equal(t, w.now.Add(28*time.Minute), next)
+
+	// No tick happened between the recheck time and the arrival
+	w.now = w.now.Add(31 * time.Minute)
+	_, err := h.Tick()
+	assert.NoError(t, err)
+	record := h.Records()[0]
+	assert.False(t, record.Checked)
+	assert.True(t, record.Unchecked)
+	assert.Equal(t, 0, len(w.cancelled))
+	_, ok = h.nextRecheck()
+	assert.False(t, ok)
+}
+
+func TestDebrisHarvester_FleetsNotLoaded(t *testing.T) {
+	w := newFakeDebrisWrapper()
+	w.celestials = []Celestial{Planet{ID: 1, Coordinate: Coordinate{1, 100, 8, PlanetType}}}
+	w.ships[1] = ShipsInfos{Recycler: 10}
+	w.flightTime = 30 * time.Minute
+	w.combatMsgs = []CombatReportSummary{{ID: 1, Destination: Coordinate{1, 101, 5, PlanetType}, DebrisField: 50000, CreatedAt: w.now}}
+	h := NewDebrisHarvester(w)
+	_, _ = h.Tick()
+
+	// Failed movement page, the fleet is not considered back
+	w.now = w.now.Add(31 * time.Minute)
+	w.slots = Slots{}
+	_, err := h.Tick()
+	assert.Error(t, err)
+	assert.False(t, h.Records()[0].Done)
+}
@@ -0,0 +1,256 @@
+package ogame
+
+import (
+	"errors"
+	"strconv"
+)
+
+// DefenseThreat fleet that could attack our celestial
+type DefenseThreat struct {
+	Name       string
+	Ships      ShipsInfos
+	Researches Researches // Weapons, shielding and armour technologies of the attacker
+}
+
+// ThreatFromAttackEvent returns the threat of an attack seen in the event list, if the ships are known
+func ThreatFromAttackEvent(event AttackEvent, researches Researches) (DefenseThreat, bool) {
+	if event.Ships == nil || !event.Ships.HasShips() {
+		return DefenseThreat{}, false
+	}
+	return DefenseThreat{Name: event.AttackerName, Ships: *event.Ships, Researches: researches}, true
+}
+
+// ThreatFromMilitaryPoints returns a fleet of ship worth the military points (1 point per 1000 resources)
+func ThreatFromMilitaryPoints(name string, points int64, ship ID, researches Researches) DefenseThreat {
+	threat := DefenseThreat{Name: name, Researches: researches}
+	if obj := Objs.ByID(ship); obj != nil {
+		threat.Ships.Set(ship, points*1000/MaxInt(obj.GetPrice(1).Total(), 1))
+	}
+	return threat
+}
+
+// ThreatsFromHighscore returns a threat for every player of a military highscore having his homeworld within
+// systems of coord, their military points are converted into ships of type ship
+func ThreatsFromHighscore(highscore Highscore, coord Coordinate, systems int64, ship ID, researches Researches) []DefenseThreat {
+	out := make([]DefenseThreat, 0)
+	for _, player := range highscore.Players {
+		if player.Homeworld.Galaxy != coord.Galaxy || MaxInt(player.Homeworld.System-coord.System, coord.System-player.Homeworld.System) > systems {
+			continue
+		}
+		out = append(out, ThreatFromMilitaryPoints(player.Name, player.Score, ship, researches))
+	}
+	return out
+}
+
+// ThreatOutcome simulated result of a threat attacking our celestial
+type ThreatOutcome struct {
+	Threat         DefenseThreat
+	AttackerWin    int // Percentage of simulations won by the attacker
+	Loot           Resources
+	Debris         Resources
+	AttackerLosses Resources
+	Profit         int64 // Loot + debris - attacker losses
+}
+
+// DefenseAdvice defenses to build to make every threat unprofitable
+type DefenseAdvice struct {
+	CelestialID  CelestialID
+	Additions    DefensesInfos
+	Price        Resources
+	Outcomes     []ThreatOutcome // Outcomes once the additions are built
+	Unprofitable bool            // Every threat is unprofitable with the additions
+}
+
+// DefenseAdvisor recommends the cheapest defenses additions making attacks unprofitable
+type DefenseAdvisor struct {
+	b             Wrapper
+	threats       []DefenseThreat
+	simulations   int
+	maxSteps      int
+	stepCost      int64
+	plunderRatio  float64
+	fleetToDebris float64
+}
+
+// NewDefenseAdvisor ...
+func NewDefenseAdvisor(b Wrapper) *DefenseAdvisor {
+	a := new(DefenseAdvisor)
+	a.b = b
+	a.simulations = 20
+	a.maxSteps = 50
+	a.stepCost = 100000
+	a.plunderRatio = 0.5
+	a.fleetToDebris = float64(b.GetServer().Settings.DebrisFieldFactorShips) / 100
+	return a
+}
+
+// AddThreats adds fleets to simulate against
+func (a *DefenseAdvisor) AddThreats(threats ...DefenseThreat) *DefenseAdvisor {
+	a.threats = append(a.threats, threats...)
+	return a
+}
+
+// SetSimulations sets the number of simulations per battle
+func (a *DefenseAdvisor) SetSimulations(simulations int) *DefenseAdvisor {
+	a.simulations = simulations
+	return a
+}
+
+// SetMaxSteps sets the maximum number of additions tried
+func (a *DefenseAdvisor) SetMaxSteps(maxSteps int) *DefenseAdvisor {
+	a.maxSteps = maxSteps
+	return a
+}
+
+// SetStepCost sets the value of the defenses added at every step
+func (a *DefenseAdvisor) SetStepCost(stepCost int64) *DefenseAdvisor {
+	a.stepCost = stepCost
+	return a
+}
+
+// SetPlunderRatio sets the ratio of resources the attacker can loot
+func (a *DefenseAdvisor) SetPlunderRatio(plunderRatio float64) *DefenseAdvisor {
+	a.plunderRatio = plunderRatio
+	return a
+}
+
+// SetFleetToDebris sets the ratio of destroyed ships going to the debris field
+func (a *DefenseAdvisor) SetFleetToDebris(fleetToDebris float64) *DefenseAdvisor {
+	a.fleetToDebris = fleetToDebris
+	return a
+}
+
+type defenseTarget struct {
+	ships      ShipsInfos
+	defenses   DefensesInfos
+	researches Researches
+	resources  Resources
+}
+
+func (a *DefenseAdvisor) simulate(threat DefenseThreat, target defenseTarget) ThreatOutcome {
+	attacker := Attacker{ShipsInfos: threat.Ships, Weapon: int(threat.Researches.WeaponsTechnology),
+		Shield: int(threat.Researches.ShieldingTechnology), Armour: int(threat.Researches.ArmourTechnology)}
+	defender := Defender{ShipsInfos: target.ships, DefensesInfos: target.defenses,
+		Metal: int(target.resources.Metal), Crystal: int(target.resources.Crystal), Deuterium: int(target.resources.Deuterium),
+		Weapon: int(target.researches.WeaponsTechnology), Shield: int(target.researches.ShieldingTechnology), Armour: int(target.researches.ArmourTechnology)}
+	result := Simulate(attacker, defender, SimulatorParams{Simulations: a.simulations, FleetToDebris: a.fleetToDebris})
+	out := ThreatOutcome{Threat: threat, AttackerWin: result.AttackerWin}
+	lootRatio := a.plunderRatio * float64(result.AttackerWin) / 100
+	out.Loot = Resources{
+		Metal:     int64(float64(target.resources.Metal) * lootRatio),
+		Crystal:   int64(float64(target.resources.Crystal) * lootRatio),
+		Deuterium: int64(float64(target.resources.Deuterium) * lootRatio),
+	}
+	out.Debris = Resources{Metal: int64(result.Debris.Metal), Crystal: int64(result.Debris.Crystal)}
+	out.AttackerLosses = Resources{Metal: int64(result.AttackerLosses.Metal), Crystal: int64(result.AttackerLosses.Crystal),
+		Deuterium: int64(result.AttackerLosses.Deuterium)}
+	out.Profit = out.Loot.Total() + out.Debris.Total() - out.AttackerLosses.Total()
+	return out
+}
+
+func (a *DefenseAdvisor) evaluate(target defenseTarget) (outcomes []ThreatOutcome, profit int64) {
+	for _, threat := range a.threats {
+		outcome := a.simulate(threat, target)
+		outcomes = append(outcomes, outcome)
+		profit += MaxInt(outcome.Profit, 0)
+	}
+	return
+}
+
+func (a *DefenseAdvisor) target(celestialID CelestialID) (defenseTarget, ResourcesBuildings, Facilities, error) {
+	resBuildings, facilities, ships, defenses, researches, err := a.b.GetTechs(celestialID)
+	if err != nil {
+		return defenseTarget{}, resBuildings, facilities, err
+	}
+	resources, err := a.b.GetResources(celestialID)
+	if err != nil {
+		return defenseTarget{}, resBuildings, facilities, err
+	}
+	return defenseTarget{ships: ships, defenses: defenses, researches: researches, resources: resources}, resBuildings, facilities, nil
+}
+
+// Evaluate simulates every threat against the current defenses of a celestial
+func (a *DefenseAdvisor) Evaluate(celestial interface{}) ([]ThreatOutcome, error) {
+	c := a.b.GetCachedCelestial(celestial)
+	if c == nil {
+		return nil, errors.New("invalid celestial")
+	}
+	target, _, _, err := a.target(c.GetID())
+	if err != nil {
+		return nil, err
+	}
+	outcomes, _ := a.evaluate(target)
+	return outcomes, nil
+}
+
+// Advise greedily adds the defenses that reduce the most the attackers profit per resource spent, until every
+// threat is unprofitable. Only the defenses available with the celestial shipyard and our researches are used.
+func (a *DefenseAdvisor) Advise(celestial interface{}) (DefenseAdvice, error) {
+	c := a.b.GetCachedCelestial(celestial)
+	if c == nil {
+		return DefenseAdvice{}, errors.New("invalid celestial")
+	}
+	advice := DefenseAdvice{CelestialID: c.GetID()}
+	target, resBuildings, facilities, err := a.target(c.GetID())
+	if err != nil {
+		return advice, err
+	}
+	candidates := make([]Defense, 0)
+	for _, defense := range Defenses {
+		id := defense.GetID()
+		if id == AntiBallisticMissilesID || id == InterplanetaryMissilesID {
+			continue
+		}
+		if defense.IsAvailable(c.GetType(), resBuildings.Lazy(), facilities.Lazy(), target.researches.Lazy(), 0, a.b.CharacterClass()) {
+			candidates = append(candidates, defense)
+		}
+	}
+
+	outcomes, profit := a.evaluate(target)
+	for step := 0; step < a.maxSteps && profit > 0; step++ {
+		var bestID ID
+		var bestNbr int64
+		var bestOutcomes []ThreatOutcome
+		bestProfit := profit
+		bestScore := 0.0
+		for _, defense := range candidates {
+			id := defense.GetID()
+			nbr := MaxInt(a.stepCost/MaxInt(defense.GetPrice(1).Total(), 1), 1)
+			if id == SmallShieldDomeID || id == LargeShieldDomeID {
+				if target.defenses.ByID(id) > 0 {
+					continue
+				}
+				nbr = 1
+			}
+			try := target
+			try.defenses.Set(id, target.defenses.ByID(id)+nbr)
+			tryOutcomes, tryProfit := a.evaluate(try)
+			score := float64(profit-tryProfit) / float64(defense.GetPrice(nbr).Total())
+			if score > bestScore {
+				bestID, bestNbr, bestOutcomes, bestProfit, bestScore = id, nbr, tryOutcomes, tryProfit, score
+			}
+		}
+		if !bestID.IsSet() {
+			break
+		}
+		target.defenses.Set(bestID, target.defenses.ByID(bestID)+bestNbr)
+		advice.Additions.Set(bestID, advice.Additions.ByID(bestID)+bestNbr)
+		advice.Price = advice.Price.Add(Objs.ByID(bestID).GetPrice(bestNbr))
+		outcomes, profit = bestOutcomes, bestProfit
+	}
+	advice.Outcomes = outcomes
+	advice.Unprofitable = profit <= 0
+	return advice, nil
+}
+
+// Build queues the defenses of the advice in the celestial shipyard
+func (a *DefenseAdvisor) Build(advice DefenseAdvice) error {
+	for _, defense := range Defenses {
+		if nbr := advice.Additions.ByID(defense.GetID()); nbr > 0 {
+			if err := a.b.BuildDefense(advice.CelestialID, defense.GetID(), nbr); err != nil {
+				return errors.New("failed to build " + strconv.FormatInt(nbr, 10) + " " + defense.GetID().String() + ": " + err.Error())
+			}
+		}
+	}
+	return nil
+}
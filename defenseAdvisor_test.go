@@ -0,0 +1,66 @@
+package ogame
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestThreatsFromHighscore(t *testing.T) {
+	highscore := Highscore{Type: 3, Players: []HighscorePlayer{
+		{Name: "near", Score: 850, Homeworld: Coordinate{1, 120, 4, PlanetType}},
+		{Name: "far", Score: 850, Homeworld: Coordinate{1, 300, 4, PlanetType}},
+		{Name: "otherGalaxy", Score: 850, Homeworld: Coordinate{2, 100, 4, PlanetType}},
+	}}
+	threats := ThreatsFromHighscore(highscore, Coordinate{1, 100, 8, PlanetType}, 50, BattlecruiserID, Researches{})
+	assert.Equal(t, 1, len(threats))
+	assert.Equal(t, "near", threats[0].Name)
+	assert.Equal(t, ShipsInfos{Battlecruiser: 10}, threats[0].Ships)
+
+	_, ok := ThreatFromAttackEvent(AttackEvent{}, Researches{})
+	assert.False(t, ok)
+	threat, ok := ThreatFromAttackEvent(AttackEvent{AttackerName: "bob", Ships: &ShipsInfos{LightFighter: 5}}, Researches{})
+	assert.True(t, ok)
+	assert.Equal(t, ShipsInfos{LightFighter: 5}, threat.Ships)
+}
+
+type fakeDefenseWrapper struct {
+	*fakeBot
+	facilities Facilities
+	resources  Resources
+	built      []Quantifiable
+}
+
+func (w *fakeDefenseWrapper) GetTechs(CelestialID) (ResourcesBuildings, Facilities, ShipsInfos, DefensesInfos, Researches, error) {
+	return ResourcesBuildings{}, w.facilities, ShipsInfos{}, DefensesInfos{}, w.researches, nil
+}
+func (w *fakeDefenseWrapper) GetResources(CelestialID) (Resources, error) { return w.resources, nil }
+func (w *fakeDefenseWrapper) BuildDefense(celestialID CelestialID, defenseID ID, nbr int64) error {
+	w.built = append(w.built, Quantifiable{ID: defenseID, Nbr: nbr})
+	return nil
+}
+
+func TestDefenseAdvisor(t *testing.T) {
+	w := &fakeDefenseWrapper{fakeBot: newFakeBot()}
+	planet := Planet{ID: 1, Coordinate: Coordinate{1, 100, 8, PlanetType}}
+	w.celestials = []Celestial{planet}
+	w.facilities = Facilities{Shipyard: 1}
+	w.resources = Resources{Metal: 1000000}
+	a := NewDefenseAdvisor(w).AddThreats(DefenseThreat{Name: "raider", Ships: ShipsInfos{LightFighter: 10}})
+
+	outcomes, err := a.Evaluate(planet)
+	assert.NoError(t, err)
+	assert.Equal(t, 100, outcomes[0].AttackerWin)
+	assert.Equal(t, int64(500000), outcomes[0].Profit)
+
+	// Only rocket launchers are available with shipyard 1
+	advice, err := a.Advise(planet)
+	assert.NoError(t, err)
+	assert.True(t, advice.Unprofitable)
+	assert.Equal(t, DefensesInfos{RocketLauncher: 50}, advice.Additions)
+	assert.Equal(t, Resources{Metal: 100000}, advice.Price)
+	assert.True(t, advice.Outcomes[0].Profit < 0)
+
+	assert.NoError(t, a.Build(advice))
+	assert.Equal(t, []Quantifiable{{ID: RocketLauncherID, Nbr: 50}}, w.built)
+}
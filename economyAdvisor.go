@@ -0,0 +1,410 @@
+package ogame
+
+import (
+	"sort"
+	"time"
+)
+
+// TradeRatios exchange ratios used to convert resources in metal standard units (MSU).
+// 3:2:1 means 3 metal = 2 crystal = 1 deuterium.
+type TradeRatios struct {
+	Metal     float64
+	Crystal   float64
+	Deuterium float64
+}
+
+// DefaultTradeRatios ...
+var DefaultTradeRatios = TradeRatios{Metal: 3, Crystal: 2, Deuterium: 1}
+
+// MSU returns the value of the resources in metal standard units (energy is ignored)
+func (r TradeRatios) MSU(res Resources) float64 {
+	if r.Metal <= 0 || r.Crystal <= 0 || r.Deuterium <= 0 {
+		r = DefaultTradeRatios
+	}
+	return float64(res.Metal) + float64(res.Crystal)*r.Metal/r.Crystal + float64(res.Deuterium)*r.Metal/r.Deuterium
+}
+
+// EconomyParams account information affecting the production
+type EconomyParams struct {
+	UniverseSpeed  int64
+	CharacterClass CharacterClass
+	HasGeologist   bool
+	HasEngineer    bool
+	Ratios         TradeRatios
+}
+
+// PlanetEconomy production related information of a planet
+type PlanetEconomy struct {
+	CelestialID CelestialID
+	Temperature Temperature
+	Buildings   ResourcesBuildings
+	Facilities  Facilities
+	Crawlers    int64
+	Settings    ResourceSettings
+}
+
+// NewPlanetEconomy creates a planet economy from the empire, with all productions at 100%
+func NewPlanetEconomy(c EmpireCelestial) PlanetEconomy {
+	return PlanetEconomy{
+		CelestialID: c.ID,
+		Temperature: c.Temperature,
+		Buildings:   c.Supplies,
+		Facilities:  c.Facilities,
+		Crawlers:    c.Ships.Crawler,
+		Settings: ResourceSettings{MetalMine: 100, CrystalMine: 100, DeuteriumSynthesizer: 100, SolarPlant: 100,
+			FusionReactor: 100, SolarSatellite: 100, Crawler: 100},
+	}
+}
+
+// MaxCrawlers returns the number of crawlers that are effective on a planet
+func MaxCrawlers(buildings ResourcesBuildings) int64 {
+	return 8 * (buildings.MetalMine + buildings.CrystalMine + buildings.DeuteriumSynthesizer)
+}
+
+func (p EconomyParams) universeSpeed() int64 {
+	return MaxInt(p.UniverseSpeed, 1)
+}
+
+func (p EconomyParams) crawlerSetting(settings ResourceSettings) float64 {
+	maxSetting := int64(100)
+	if p.CharacterClass.IsCollector() {
+		maxSetting = 150
+	}
+	return float64(Clamp(settings.Crawler, 0, maxSetting)) / 100
+}
+
+// Energy returns the energy produced and consumed by a planet
+func (p EconomyParams) Energy(planet PlanetEconomy, researches Researches) (produced, needed int64) {
+	b, s := planet.Buildings, planet.Settings
+	bonus := 1.0
+	if p.CharacterClass.IsCollector() {
+		bonus += 0.1
+	}
+	if p.HasEngineer {
+		bonus += 0.1
+	}
+	produced = int64(float64(energyProduced(planet.Temperature, b, s, researches.EnergyTechnology)) * bonus)
+	crawlers := MinInt(planet.Crawlers, MaxCrawlers(b))
+	needed = energyNeeded(b, s) + int64(float64(crawlers*50)*p.crawlerSetting(s))
+	return produced, needed
+}
+
+// Production returns the hourly production of a planet, taking into account the energy, plasma technology,
+// crawlers, character class and geologist. Energy is the energy balance.
+func (p EconomyParams) Production(planet PlanetEconomy, researches Researches) Resources {
+	b, s := planet.Buildings, planet.Settings
+	speed := p.universeSpeed()
+	produced, needed := p.Energy(planet, researches)
+	ratio := 1.0
+	if needed > produced {
+		ratio = float64(produced) / float64(needed)
+	}
+	bonus := 0.0
+	if p.CharacterClass.IsCollector() {
+		bonus += 0.25
+	}
+	if p.HasGeologist {
+		bonus += 0.1
+	}
+	crawlerBonus := 0.0002
+	if p.CharacterClass.IsCollector() {
+		crawlerBonus = 0.0003
+	}
+	bonus += float64(MinInt(planet.Crawlers, MaxCrawlers(b))) * crawlerBonus * p.crawlerSetting(s) * ratio
+	metalSetting := float64(s.MetalMine) / 100
+	crystalSetting := float64(s.CrystalMine) / 100
+	deutSetting := float64(s.DeuteriumSynthesizer) / 100
+	temp := planet.Temperature.Mean()
+
+	// Bonuses apply to the mines production, without the basic income and the plasma technology
+	metal := MetalMine.Production(speed, metalSetting, ratio, researches.PlasmaTechnology, b.MetalMine)
+	metal += int64(float64(MetalMine.Production(speed, metalSetting, ratio, 0, b.MetalMine)-MetalMine.Production(speed, 0, 0, 0, 0)) * bonus)
+	crystal := CrystalMine.Production(speed, crystalSetting, ratio, researches.PlasmaTechnology, b.CrystalMine)
+	crystal += int64(float64(CrystalMine.Production(speed, crystalSetting, ratio, 0, b.CrystalMine)-CrystalMine.Production(speed, 0, 0, 0, 0)) * bonus)
+	deut := DeuteriumSynthesizer.Production(speed, temp, deutSetting, ratio, researches.PlasmaTechnology, b.DeuteriumSynthesizer)
+	deut += int64(float64(DeuteriumSynthesizer.Production(speed, temp, deutSetting, ratio, 0, b.DeuteriumSynthesizer)) * bonus)
+	deut -= FusionReactor.GetFuelConsumption(speed, float64(s.FusionReactor)/100, b.FusionReactor)
+	return Resources{Metal: metal, Crystal: crystal, Deuterium: deut, Energy: produced - needed}
+}
+
+// EconomyUpgrade an upgrade and its return on investment
+type EconomyUpgrade struct {
+	CelestialID CelestialID // 0 for account wide upgrades (researches)
+	ID          ID
+	Nbr         int64     // Level reached for buildings and technologies, number of units for ships
+	Price       Resources // Includes the energy fix
+	EnergyFix   *Quantifiable
+	Gain        Resources // Hourly production gained
+	PriceMSU    float64
+	GainMSU     float64
+	Payback     time.Duration
+}
+
+type economyAdvisor struct {
+	params     EconomyParams
+	researches Researches
+}
+
+// energyFix returns the cheapest solar plant levels or solar satellites needed to have at least target energy
+func (a economyAdvisor) energyFix(planet PlanetEconomy, target int64) (fix *Quantifiable, price Resources, fixed PlanetEconomy) {
+	fixed = planet
+	energy := a.params.Production(planet, a.researches).Energy
+	if energy >= target {
+		return nil, price, fixed
+	}
+	var bestMSU float64
+
+	perSatellite := SolarSatellite.Production(planet.Temperature, 1, false)
+	if perSatellite > 0 && planet.Settings.SolarSatellite > 0 {
+		sats := planet
+		nbr := int64(0)
+		for i := 0; i < 100 && energy < target; i++ {
+			step := MaxInt((target-energy)/perSatellite, 1)
+			sats.Buildings.SolarSatellite += step
+			nbr += step
+			energy = a.params.Production(sats, a.researches).Energy
+		}
+		if energy >= target {
+			fix, price, fixed = &Quantifiable{ID: SolarSatelliteID, Nbr: nbr}, SolarSatellite.GetPrice(nbr), sats
+			bestMSU = a.params.Ratios.MSU(price)
+		}
+	}
+
+	plant := planet
+	var plantPrice Resources
+	for i := 0; i < 10 && a.params.Production(plant, a.researches).Energy < target; i++ {
+		plant.Buildings.SolarPlant++
+		plantPrice = plantPrice.Add(SolarPlant.GetPrice(plant.Buildings.SolarPlant))
+	}
+	if a.params.Production(plant, a.researches).Energy >= target && (fix == nil || a.params.Ratios.MSU(plantPrice) < bestMSU) {
+		fix, price, fixed = &Quantifiable{ID: SolarPlantID, Nbr: plant.Buildings.SolarPlant}, plantPrice, plant
+	}
+	return fix, price, fixed
+}
+
+func (a economyAdvisor) newUpgrade(celestialID CelestialID, id ID, nbr int64, price, gain Resources) (EconomyUpgrade, bool) {
+	u := EconomyUpgrade{CelestialID: celestialID, ID: id, Nbr: nbr, Price: price, Gain: gain}
+	u.PriceMSU = a.params.Ratios.MSU(price)
+	u.GainMSU = a.params.Ratios.MSU(gain)
+	if u.GainMSU <= 0 {
+		return u, false
+	}
+	u.Payback = time.Duration(u.PriceMSU / u.GainMSU * float64(time.Hour))
+	return u, true
+}
+
+// planetUpgrade evaluates an upgrade of a planet, paying for the energy it would be missing
+func (a economyAdvisor) planetUpgrade(planet, upgraded PlanetEconomy, id ID, nbr int64, price Resources) (EconomyUpgrade, bool) {
+	before := a.params.Production(planet, a.researches)
+	fix, fixPrice, fixed := a.energyFix(upgraded, MinInt(before.Energy, 0))
+	after := a.params.Production(fixed, a.researches)
+	gain := Resources{Metal: after.Metal - before.Metal, Crystal: after.Crystal - before.Crystal, Deuterium: after.Deuterium - before.Deuterium}
+	u, ok := a.newUpgrade(planet.CelestialID, id, nbr, price.Add(fixPrice), gain)
+	u.EnergyFix = fix
+	return u, ok
+}
+
+func (a economyAdvisor) planetUpgrades(planet PlanetEconomy) []EconomyUpgrade {
+	out := make([]EconomyUpgrade, 0)
+	levels := techLevels{resBuildings: planet.Buildings, facilities: planet.Facilities, researches: a.researches}
+	for _, id := range []ID{MetalMineID, CrystalMineID, DeuteriumSynthesizerID, SolarPlantID, FusionReactorID} {
+		if len(missingRequirements(id, levels.ByID)) > 0 {
+			continue
+		}
+		upgraded := planet
+		lvl := planet.Buildings.ByID(id) + 1
+		upgraded.Buildings.Set(id, lvl)
+		if u, ok := a.planetUpgrade(planet, upgraded, id, lvl, Objs.ByID(id).GetPrice(lvl)); ok {
+			out = append(out, u)
+		}
+	}
+
+	// Solar satellites covering the current energy deficit
+	if energy := a.params.Production(planet, a.researches).Energy; energy < 0 && len(missingRequirements(SolarSatelliteID, levels.ByID)) == 0 {
+		if perSatellite := SolarSatellite.Production(planet.Temperature, 1, false); perSatellite > 0 {
+			nbr := (-energy + perSatellite - 1) / perSatellite
+			upgraded := planet
+			upgraded.Buildings.SolarSatellite += nbr
+			if u, ok := a.planetUpgrade(planet, upgraded, SolarSatelliteID, nbr, SolarSatellite.GetPrice(nbr)); ok {
+				out = append(out, u)
+			}
+		}
+	}
+
+	// Crawlers up to the maximum useful amount
+	if nbr := MaxCrawlers(planet.Buildings) - planet.Crawlers; nbr > 0 && len(missingRequirements(CrawlerID, levels.ByID)) == 0 {
+		upgraded := planet
+		upgraded.Crawlers += nbr
+		if u, ok := a.planetUpgrade(planet, upgraded, CrawlerID, nbr, Crawler.GetPrice(nbr)); ok {
+			out = append(out, u)
+		}
+	}
+	return out
+}
+
+// researchAvailable returns true if the research can be done from one of the planets
+func (a economyAdvisor) researchAvailable(id ID, planets []PlanetEconomy) bool {
+	for _, planet := range planets {
+		levels := techLevels{resBuildings: planet.Buildings, facilities: planet.Facilities, researches: a.researches}
+		if len(missingRequirements(id, levels.ByID)) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (a economyAdvisor) plasmaUpgrade(planets []PlanetEconomy) (EconomyUpgrade, bool) {
+	if !a.researchAvailable(PlasmaTechnologyID, planets) {
+		return EconomyUpgrade{}, false
+	}
+	upgraded := a
+	upgraded.researches.PlasmaTechnology++
+	var gain Resources
+	for _, planet := range planets {
+		before := a.params.Production(planet, a.researches)
+		after := upgraded.params.Production(planet, upgraded.researches)
+		gain = gain.Add(Resources{Metal: after.Metal - before.Metal, Crystal: after.Crystal - before.Crystal, Deuterium: after.Deuterium - before.Deuterium})
+	}
+	lvl := upgraded.researches.PlasmaTechnology
+	return a.newUpgrade(0, PlasmaTechnologyID, lvl, PlasmaTechnology.GetPrice(lvl), gain)
+}
+
+// colonyUpgrade astrophysics levels for a new colony, developed like an average planet of the account
+func (a economyAdvisor) colonyUpgrade(planets []PlanetEconomy) (EconomyUpgrade, bool) {
+	if !a.researchAvailable(AstrophysicsID, planets) {
+		return EconomyUpgrade{}, false
+	}
+	current := a.researches.Astrophysics
+	lvl := current + 1
+	if lvl%2 == 0 {
+		lvl++
+	}
+	var price Resources
+	for l := current + 1; l <= lvl; l++ {
+		price = price.Add(Astrophysics.GetPrice(l))
+	}
+
+	colony := PlanetEconomy{Settings: planets[0].Settings}
+	ids := []ID{MetalMineID, CrystalMineID, DeuteriumSynthesizerID, SolarPlantID}
+	var tempMin, tempMax int64
+	for _, planet := range planets {
+		for _, id := range ids {
+			colony.Buildings.Set(id, colony.Buildings.ByID(id)+planet.Buildings.ByID(id))
+		}
+		tempMin += planet.Temperature.Min
+		tempMax += planet.Temperature.Max
+	}
+	nbPlanets := int64(len(planets))
+	colony.Temperature = Temperature{Min: tempMin / nbPlanets, Max: tempMax / nbPlanets}
+	for _, id := range ids {
+		avg := colony.Buildings.ByID(id) / nbPlanets
+		colony.Buildings.Set(id, avg)
+		for l := int64(1); l <= avg; l++ {
+			price = price.Add(Objs.ByID(id).GetPrice(l))
+		}
+	}
+	fix, fixPrice, fixed := a.energyFix(colony, 0)
+	gain := a.params.Production(fixed, a.researches)
+	gain.Energy = 0
+	u, ok := a.newUpgrade(0, AstrophysicsID, lvl, price.Add(fixPrice), gain)
+	u.EnergyFix = fix
+	return u, ok
+}
+
+// RankEconomyUpgrades ranks the next economy upgrades of the account by payback time
+func RankEconomyUpgrades(planets []PlanetEconomy, researches Researches, params EconomyParams) []EconomyUpgrade {
+	a := economyAdvisor{params: params, researches: researches}
+	out := make([]EconomyUpgrade, 0)
+	for _, planet := range planets {
+		out = append(out, a.planetUpgrades(planet)...)
+	}
+	if u, ok := a.plasmaUpgrade(planets); ok {
+		out = append(out, u)
+	}
+	if u, ok := a.colonyUpgrade(planets); ok {
+		out = append(out, u)
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Payback < out[j].Payback })
+	return out
+}
+
+func (b *OGame) economyParams(ratios TradeRatios) EconomyParams {
+	return EconomyParams{
+		UniverseSpeed:  b.GetUniverseSpeed(),
+		CharacterClass: b.CharacterClass(),
+		HasGeologist:   b.hasGeologist,
+		HasEngineer:    b.hasEngineer,
+		Ratios:         ratios,
+	}
+}
+
+// GetEconomyUpgrades ranks the next economy upgrades of all the planets of the account by payback time
+func (b *OGame) GetEconomyUpgrades(ratios TradeRatios) ([]EconomyUpgrade, error) {
+	celestials, err := b.GetEmpire(PlanetType)
+	if err != nil {
+		return nil, err
+	}
+	planets := make([]PlanetEconomy, 0, len(celestials))
+	var researches Researches
+	for _, c := range celestials {
+		planets = append(planets, NewPlanetEconomy(c))
+		researches = c.Researches
+	}
+	params := b.economyParams(ratios)
+	return RankEconomyUpgrades(planets, researches, params), nil
+}
+
+// OptimizeResourceSettings finds the production percentages maximizing the value of the production (using the
+// params trade ratios) without energy deficit. Solar plant and solar satellites are always at 100%.
+func OptimizeResourceSettings(planet PlanetEconomy, researches Researches, params EconomyParams) ResourceSettings {
+	maxCrawler := int64(100)
+	if params.CharacterClass.IsCollector() {
+		maxCrawler = 150
+	}
+	best := ResourceSettings{SolarPlant: 100, SolarSatellite: 100}
+	bestMSU := -1.0
+	settings := best
+	for settings.MetalMine = 0; settings.MetalMine <= 100; settings.MetalMine += 10 {
+		for settings.CrystalMine = 0; settings.CrystalMine <= 100; settings.CrystalMine += 10 {
+			for settings.DeuteriumSynthesizer = 0; settings.DeuteriumSynthesizer <= 100; settings.DeuteriumSynthesizer += 10 {
+				for settings.FusionReactor = 0; settings.FusionReactor <= 100; settings.FusionReactor += 10 {
+					if planet.Buildings.FusionReactor == 0 && settings.FusionReactor > 0 {
+						break
+					}
+					for settings.Crawler = 0; settings.Crawler <= maxCrawler; settings.Crawler += 10 {
+						if planet.Crawlers == 0 && settings.Crawler > 0 {
+							break
+						}
+						planet.Settings = settings
+						prod := params.Production(planet, researches)
+						if prod.Energy < 0 {
+							continue
+						}
+						if msu := params.Ratios.MSU(prod); msu >= bestMSU {
+							best, bestMSU = settings, msu
+						}
+					}
+				}
+			}
+		}
+	}
+	return best
+}
+
+// ApplyOptimalResourceSettings computes and sets the optimal resource settings of every planet
+func (b *OGame) ApplyOptimalResourceSettings(ratios TradeRatios) (map[CelestialID]ResourceSettings, error) {
+	celestials, err := b.GetEmpire(PlanetType)
+	if err != nil {
+		return nil, err
+	}
+	params := b.economyParams(ratios)
+	out := make(map[CelestialID]ResourceSettings, len(celestials))
+	for _, c := range celestials {
+		settings := OptimizeResourceSettings(NewPlanetEconomy(c), c.Researches, params)
+		if err := b.SetResourceSettings(PlanetID(c.ID), settings); err != nil {
+			return out, err
+		}
+		out[c.ID] = settings
+	}
+	return out, nil
+}
@@ -0,0 +1,89 @@
+package ogame
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTradeRatios_MSU(t *testing.T) {
+	assert.Equal(t, 5.5, DefaultTradeRatios.MSU(Resources{Metal: 1, Crystal: 1, Deuterium: 1}))
+	assert.Equal(t, 5.5, TradeRatios{}.MSU(Resources{Metal: 1, Crystal: 1, Deuterium: 1}))
+	assert.Equal(t, 4.0, TradeRatios{Metal: 2, Crystal: 1, Deuterium: 1}.MSU(Resources{Crystal: 1, Deuterium: 1}))
+}
+
+func newTestPlanetEconomy() PlanetEconomy {
+	return NewPlanetEconomy(EmpireCelestial{
+		ID:          1,
+		Temperature: Temperature{Min: 10, Max: 50},
+		Supplies:    ResourcesBuildings{MetalMine: 20, CrystalMine: 15, DeuteriumSynthesizer: 10, SolarPlant: 20},
+		Facilities:  Facilities{Shipyard: 2, ResearchLab: 3},
+	})
+}
+
+func TestEconomyParams_Production(t *testing.T) {
+	planet := newTestPlanetEconomy()
+	researches := Researches{PlasmaTechnology: 5, EnergyTechnology: 3}
+	expected := getResourcesProductionsLight(planet.Buildings, researches, planet.Settings, planet.Temperature, 1)
+	prod := EconomyParams{UniverseSpeed: 1}.Production(planet, researches)
+	assert.Equal(t, expected.Metal, prod.Metal)
+	assert.Equal(t, expected.Crystal, prod.Crystal)
+	assert.Equal(t, expected.Deuterium, prod.Deuterium)
+	assert.True(t, prod.Energy > 0)
+
+	collector := EconomyParams{UniverseSpeed: 1, CharacterClass: Collector, HasGeologist: true}.Production(planet, researches)
+	assert.True(t, collector.Metal > prod.Metal)
+	assert.True(t, collector.Deuterium > prod.Deuterium)
+
+	planet.Buildings.SolarPlant = 0
+	assert.Equal(t, int64(30), EconomyParams{UniverseSpeed: 1}.Production(planet, researches).Metal)
+}
+
+func TestRankEconomyUpgrades(t *testing.T) {
+	planet := newTestPlanetEconomy()
+	researches := Researches{EnergyTechnology: 3, EspionageTechnology: 4, ImpulseDrive: 3}
+	upgrades := RankEconomyUpgrades([]PlanetEconomy{planet}, researches, EconomyParams{UniverseSpeed: 1})
+	assert.True(t, len(upgrades) > 0)
+	found := map[ID]EconomyUpgrade{}
+	for i, u := range upgrades {
+		if i > 0 {
+			assert.True(t, upgrades[i-1].Payback <= u.Payback)
+		}
+		found[u.ID] = u
+	}
+	metal, ok := found[MetalMineID]
+	assert.True(t, ok)
+	assert.Equal(t, int64(21), metal.Nbr)
+	assert.NotNil(t, metal.EnergyFix) // The solar plant can't power the next metal mine level
+	assert.True(t, metal.PriceMSU > DefaultTradeRatios.MSU(MetalMine.GetPrice(21)))
+	_, ok = found[PlasmaTechnologyID]
+	assert.False(t, ok) // Requirements are not met
+	astrophysics, ok := found[AstrophysicsID]
+	assert.True(t, ok)
+	assert.Equal(t, int64(1), astrophysics.Nbr)
+	_, ok = found[SolarPlantID]
+	assert.False(t, ok) // No energy deficit, a solar plant does not increase the production
+}
+
+func TestOptimizeResourceSettings(t *testing.T) {
+	planet := newTestPlanetEconomy()
+	researches := Researches{EnergyTechnology: 3}
+	params := EconomyParams{UniverseSpeed: 1}
+	settings := OptimizeResourceSettings(planet, researches, params)
+	assert.Equal(t, ResourceSettings{MetalMine: 100, CrystalMine: 100, DeuteriumSynthesizer: 100, SolarPlant: 100, SolarSatellite: 100}, settings)
+
+	// Not enough energy, the deuterium synthesizer is the least valuable per energy unit at 3:2:1
+	planet.Buildings.SolarPlant = 18
+	settings = OptimizeResourceSettings(planet, researches, params)
+	assert.Equal(t, int64(100), settings.MetalMine)
+	assert.True(t, settings.DeuteriumSynthesizer < 100)
+	planet.Settings = settings
+	assert.True(t, params.Production(planet, researches).Energy >= 0)
+
+	// Crawlers are worth their energy for a collector
+	planet.Buildings.SolarPlant = 30
+	planet.Crawlers = 100
+	params.CharacterClass = Collector
+	settings = OptimizeResourceSettings(planet, researches, params)
+	assert.Equal(t, int64(150), settings.Crawler)
+}
@@ -44,6 +44,27 @@ var ErrDeactivateHidePictures = errors.New("deactivate 'Hide pictures in reports
 // ErrEventsBoxNotDisplayed returned when trying to get attacks from a full page without event box
 var ErrEventsBoxNotDisplayed = errors.New("eventList box is not displayed")
 
+// ErrBuildQueueItemNotFound returned when a build queue item does not exist
+var ErrBuildQueueItemNotFound = errors.New("build queue item not found")
+
+// ErrMarketplaceOfferNotFound returned when trying to cancel an offer that is not one of our active offers
+var ErrMarketplaceOfferNotFound = errors.New("marketplace offer not found")
+
+// ErrNoMerchantCalled returned when trading with the resource merchant before calling one
+var ErrNoMerchantCalled = errors.New("no merchant called")
+
+// ErrEspionageCampaignStopped returned for the targets not handled when an espionage campaign is stopped
+var ErrEspionageCampaignStopped = errors.New("espionage campaign stopped")
+
+// ErrGalaxyScanStopped returned when a galaxy scan is stopped before the last system
+var ErrGalaxyScanStopped = errors.New("galaxy scan stopped")
+
+// ErrHighscoreCrawlStopped returned when a highscore crawl is stopped before the last page
+var ErrHighscoreCrawlStopped = errors.New("highscore crawl stopped")
+
+// ErrInterceptionStopped returned when an interception is stopped before its launch
+var ErrInterceptionStopped = errors.New("interception stopped")
+
 // Send fleet errors
 var (
 	ErrUnionNotFound                      = errors.New("union not found")
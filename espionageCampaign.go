@@ -0,0 +1,258 @@
+package ogame
+
+import (
+	"errors"
+	"time"
+)
+
+// EspionageResult result of the espionage of a single target
+type EspionageResult struct {
+	Coordinate   Coordinate
+	Probes       int64
+	Fleet        Fleet
+	Report       *EspionageReport
+	Loot         Resources
+	Ships        *ShipsInfos
+	Defenses     *DefensesInfos
+	LastActivity int64
+	Err          error
+}
+
+// EspionageCampaign sends probes to a list of targets and collects the reports
+type EspionageCampaign struct {
+	b                   Wrapper
+	origin              CelestialID
+	probes              int64
+	maxProbes           int64
+	maxCounterEspionage int64
+	keepSlots           int64
+	wantBuildings       bool
+	wantResearches      bool
+	retryDelay          time.Duration
+	reportDelay         time.Duration
+	reportRetries       int
+	priorReports        map[Coordinate]EspionageReport
+	priorProbes         map[Coordinate]int64
+}
+
+// NewEspionageCampaign ...
+func NewEspionageCampaign(b Wrapper, origin CelestialID) *EspionageCampaign {
+	c := new(EspionageCampaign)
+	c.b = b
+	c.origin = origin
+	c.probes = MaxInt(b.GetCachedPreferences().SpioAnz, 1)
+	c.maxProbes = 50
+	c.maxCounterEspionage = 50
+	c.retryDelay = 30 * time.Second
+	c.reportDelay = 5 * time.Second
+	c.reportRetries = 3
+	c.priorReports = make(map[Coordinate]EspionageReport)
+	c.priorProbes = make(map[Coordinate]int64)
+	return c
+}
+
+// SetProbes sets the number of probes sent to a target without prior report
+func (c *EspionageCampaign) SetProbes(probes int64) *EspionageCampaign {
+	c.probes = MaxInt(probes, 1)
+	return c
+}
+
+// SetMaxProbes sets the maximum number of probes sent to a single target
+func (c *EspionageCampaign) SetMaxProbes(maxProbes int64) *EspionageCampaign {
+	c.maxProbes = MaxInt(maxProbes, 1)
+	return c
+}
+
+// SetMaxCounterEspionage above this counter-espionage chance (%), the probes count is not increased
+func (c *EspionageCampaign) SetMaxCounterEspionage(maxCounterEspionage int64) *EspionageCampaign {
+	c.maxCounterEspionage = maxCounterEspionage
+	return c
+}
+
+// SetKeepSlots number of fleet slots that must stay free
+func (c *EspionageCampaign) SetKeepSlots(keepSlots int64) *EspionageCampaign {
+	c.keepSlots = keepSlots
+	return c
+}
+
+// SetWantBuildings increase probes until the buildings information is in the report
+func (c *EspionageCampaign) SetWantBuildings(wantBuildings bool) *EspionageCampaign {
+	c.wantBuildings = wantBuildings
+	return c
+}
+
+// SetWantResearches increase probes until the researches information is in the report
+func (c *EspionageCampaign) SetWantResearches(wantResearches bool) *EspionageCampaign {
+	c.wantResearches = wantResearches
+	return c
+}
+
+// SetRetryDelay delay before trying again to send probes when all slots are in use
+func (c *EspionageCampaign) SetRetryDelay(retryDelay time.Duration) *EspionageCampaign {
+	c.retryDelay = retryDelay
+	return c
+}
+
+// SetReportDelay delay to wait after the probes arrived before fetching the report
+func (c *EspionageCampaign) SetReportDelay(reportDelay time.Duration) *EspionageCampaign {
+	c.reportDelay = reportDelay
+	return c
+}
+
+// AddPriorReports reports used to adapt the number of probes sent
+func (c *EspionageCampaign) AddPriorReports(reports ...EspionageReport) *EspionageCampaign {
+	for _, report := range reports {
+		if prev, ok := c.priorReports[report.Coordinate]; ok && prev.Date.After(report.Date) {
+			continue
+		}
+		c.priorReports[report.Coordinate] = report
+	}
+	return c
+}
+
+func (c *EspionageCampaign) missingInformation(report EspionageReport) bool {
+	return !report.HasFleetInformation ||
+		!report.HasDefensesInformation ||
+		(c.wantBuildings && !report.HasBuildingsInformation) ||
+		(c.wantResearches && !report.HasResearchesInformation)
+}
+
+// ProbesFor returns the number of probes to send to a target
+func (c *EspionageCampaign) ProbesFor(coord Coordinate) int64 {
+	nbr := c.probes
+	if prev, ok := c.priorProbes[coord]; ok && prev > nbr {
+		nbr = prev
+	}
+	if report, ok := c.priorReports[coord]; ok {
+		if c.missingInformation(report) {
+			if report.CounterEspionage < c.maxCounterEspionage {
+				nbr *= 2
+			}
+		} else if report.CounterEspionage >= c.maxCounterEspionage {
+			nbr = c.probes
+		}
+	}
+	return Clamp(nbr, 1, c.maxProbes)
+}
+
+func (c *EspionageCampaign) sleep(d time.Duration, stop <-chan struct{}) error {
+	if !waitOrStop(d, stop) {
+		return ErrEspionageCampaignStopped
+	}
+	return nil
+}
+
+func (c *EspionageCampaign) waitFreeSlot(stop <-chan struct{}) error {
+	for {
+		slots := c.b.GetSlots()
+		if slots.InUse+c.keepSlots < slots.Total {
+			return nil
+		}
+		if err := c.sleep(c.retryDelay, stop); err != nil {
+			return err
+		}
+	}
+}
+
+func (c *EspionageCampaign) send(coord Coordinate, probes int64, stop <-chan struct{}) (Fleet, error) {
+	for {
+		if err := c.waitFreeSlot(stop); err != nil {
+			return Fleet{}, err
+		}
+		ships := []Quantifiable{{ID: EspionageProbeID, Nbr: probes}}
+		fleet, err := c.b.SendFleet(c.origin, ships, HundredPercent, coord, Spy, Resources{}, 0, 0)
+		if err == ErrAllSlotsInUse {
+			if err := c.sleep(c.retryDelay, stop); err != nil {
+				return Fleet{}, err
+			}
+			continue
+		}
+		return fleet, err
+	}
+}
+
+func (c *EspionageCampaign) fetchReport(coord Coordinate, sentAt time.Time, stop <-chan struct{}) (EspionageReport, error) {
+	var err error
+	for i := 0; i < c.reportRetries; i++ {
+		if err := c.sleep(c.reportDelay, stop); err != nil {
+			return EspionageReport{}, err
+		}
+		var report EspionageReport
+		report, err = c.b.GetEspionageReportFor(coord)
+		if err == nil {
+			if report.Date.Before(sentAt.Truncate(time.Minute)) {
+				err = errors.New("no new espionage report for " + coord.String())
+				continue
+			}
+			return report, nil
+		}
+	}
+	return EspionageReport{}, err
+}
+
+// Run sends probes to all targets, waits for the probes to arrive and collects the reports.
+// Closing stop aborts the campaign, the targets not sent yet get ErrEspionageCampaignStopped,
+// the ones already sent keep their fleet and have no report if it was not collected yet
+func (c *EspionageCampaign) Run(targets []Coordinate, stop <-chan struct{}) []EspionageResult {
+	results := make([]EspionageResult, len(targets))
+	for i, coord := range targets {
+		results[i].Coordinate = coord
+	}
+	var maxArriveIn int64
+	stopped := func() []EspionageResult {
+		for i := range results {
+			if results[i].Err == nil && results[i].Fleet.ID == 0 {
+				results[i].Err = ErrEspionageCampaignStopped
+			}
+		}
+		return results
+	}
+	sentAt := c.b.ServerTime()
+	for i, coord := range targets {
+		results[i].Probes = c.ProbesFor(coord)
+		fleet, err := c.send(coord, results[i].Probes, stop)
+		if err == ErrEspionageCampaignStopped {
+			return stopped()
+		} else if err != nil {
+			results[i].Err = err
+			continue
+		}
+		c.priorProbes[coord] = results[i].Probes
+		results[i].Fleet = fleet
+		maxArriveIn = MaxInt(maxArriveIn, fleet.ArriveIn)
+	}
+
+	if err := c.sleep(time.Duration(maxArriveIn)*time.Second, stop); err != nil {
+		return stopped()
+	}
+
+	characterClass := c.b.CharacterClass()
+	for i := range results {
+		if results[i].Err != nil {
+			continue
+		}
+		report, err := c.fetchReport(results[i].Coordinate, sentAt, stop)
+		if err == ErrEspionageCampaignStopped {
+			return results
+		} else if err != nil {
+			results[i].Err = err
+			continue
+		}
+		c.AddPriorReports(report)
+		results[i].Report = &report
+		results[i].Loot = report.Loot(characterClass)
+		results[i].Ships = report.ShipsInfos()
+		results[i].Defenses = report.DefensesInfos()
+		results[i].LastActivity = report.LastActivity
+	}
+	return results
+}
+
+// Reports returns the reports collected during the campaign
+func (c *EspionageCampaign) Reports() []EspionageReport {
+	out := make([]EspionageReport, 0, len(c.priorReports))
+	for _, report := range c.priorReports {
+		out = append(out, report)
+	}
+	return out
+}
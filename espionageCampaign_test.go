@@ -0,0 +1,98 @@
+package ogame
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeEspionageWrapper struct {
+	*fakeFleets
+	reports map[Coordinate]EspionageReport
+}
+
+func newFakeEspionageWrapper() *fakeEspionageWrapper {
+	return &fakeEspionageWrapper{fakeFleets: newFakeFleets(), reports: make(map[Coordinate]EspionageReport)}
+}
+
+func (w *fakeEspionageWrapper) GetCachedPreferences() Preferences { return Preferences{SpioAnz: 1} }
+func (w *fakeEspionageWrapper) GetEspionageReportFor(coord Coordinate) (EspionageReport, error) {
+	report, ok := w.reports[coord]
+	if !ok {
+		return EspionageReport{}, errors.New("espionage report not found for " + coord.String())
+	}
+	return report, nil
+}
+
+func TestEspionageCampaign_ProbesFor(t *testing.T) {
+	w := newFakeEspionageWrapper()
+	coord := Coordinate{1, 2, 3, PlanetType}
+	c := NewEspionageCampaign(w, CelestialID(1)).SetProbes(2).SetMaxProbes(6)
+	assert.Equal(t, int64(2), c.ProbesFor(coord))
+
+	c.AddPriorReports(EspionageReport{Coordinate: coord, HasFleetInformation: true})
+	assert.Equal(t, int64(4), c.ProbesFor(coord))
+	c.priorProbes[coord] = 4
+	assert.Equal(t, int64(6), c.ProbesFor(coord))
+
+	c.AddPriorReports(EspionageReport{Coordinate: coord, HasFleetInformation: true, CounterEspionage: 80})
+	assert.Equal(t, int64(4), c.ProbesFor(coord))
+
+	c.AddPriorReports(EspionageReport{Coordinate: coord, HasFleetInformation: true, HasDefensesInformation: true, CounterEspionage: 80})
+	assert.Equal(t, int64(2), c.ProbesFor(coord))
+
+	c.SetWantResearches(true)
+	c.AddPriorReports(EspionageReport{Coordinate: coord, HasFleetInformation: true, HasDefensesInformation: true})
+	assert.Equal(t, int64(6), c.ProbesFor(coord))
+}
+
+func TestEspionageCampaign_Run(t *testing.T) {
+	w := newFakeEspionageWrapper()
+	w.slots.Total = 1
+	target1 := Coordinate{1, 2, 3, PlanetType}
+	target2 := Coordinate{1, 2, 4, PlanetType}
+	report := EspionageReport{Coordinate: target1, Type: Report, Date: w.now, HasFleetInformation: true, HasDefensesInformation: true}
+	report.Metal = 1000
+	w.reports[target1] = report
+
+	c := NewEspionageCampaign(w, CelestialID(1)).SetReportDelay(0)
+	results := c.Run([]Coordinate{target1}, nil)
+	assert.Equal(t, 1, len(results))
+	assert.NoError(t, results[0].Err)
+	assert.Equal(t, int64(1), results[0].Probes)
+	assert.Equal(t, int64(1), w.sentFleets[0].Ships.EspionageProbe)
+	assert.Equal(t, Spy, w.sentFleets[0].Mission)
+	assert.Equal(t, int64(500), results[0].Loot.Metal)
+	assert.NotNil(t, results[0].Defenses)
+
+	w.slots.InUse = 0
+	w.slots.Total = 2
+	results = c.Run([]Coordinate{target2}, nil)
+	assert.Error(t, results[0].Err)
+	assert.Equal(t, 1, len(c.Reports()))
+}
+
+func TestEspionageCampaign_RunStopped(t *testing.T) {
+	w := newFakeEspionageWrapper()
+	w.slots.InUse = 1
+	w.slots.Total = 1
+	stop := make(chan struct{})
+	close(stop)
+	c := NewEspionageCampaign(w, CelestialID(1))
+	results := c.Run([]Coordinate{{1, 2, 3, PlanetType}, {1, 2, 4, PlanetType}}, stop)
+	assert.Equal(t, 2, len(results))
+	assert.Equal(t, ErrEspionageCampaignStopped, results[0].Err)
+	assert.Equal(t, ErrEspionageCampaignStopped, results[1].Err)
+	assert.Equal(t, Coordinate{1, 2, 4, PlanetType}, results[1].Coordinate)
+	assert.Equal(t, 0, len(w.sentFleets))
+
+	// The fleet already sent is kept, only the target waiting for a slot is stopped
+	w.slots.InUse = 0
+	results = c.Run([]Coordinate{{1, 2, 3, PlanetType}, {1, 2, 4, PlanetType}}, stop)
+	assert.NoError(t, results[0].Err)
+	assert.Equal(t, w.sentFleets[0].ID, results[0].Fleet.ID)
+	assert.Nil(t, results[0].Report)
+	assert.Equal(t, ErrEspionageCampaignStopped, results[1].Err)
+	assert.Equal(t, Coordinate{1, 2, 4, PlanetType}, results[1].Coordinate)
+}
@@ -0,0 +1,414 @@
+package ogame
+
+import (
+	"errors"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ExpeditionOutcome ...
+type ExpeditionOutcome string
+
+// Expedition outcomes
+const (
+	ExpeditionResources  ExpeditionOutcome = "resources"
+	ExpeditionShips      ExpeditionOutcome = "ships"
+	ExpeditionDarkMatter ExpeditionOutcome = "darkMatter"
+	ExpeditionItem       ExpeditionOutcome = "item"
+	ExpeditionCombat     ExpeditionOutcome = "combat"
+	ExpeditionDelay      ExpeditionOutcome = "delay"
+	ExpeditionEarly      ExpeditionOutcome = "early"
+	ExpeditionLost       ExpeditionOutcome = "lost"
+	ExpeditionNothing    ExpeditionOutcome = "nothing"
+)
+
+// ExpeditionLog outcome of an expedition, from the expedition messages
+type ExpeditionLog struct {
+	MessageID  int64
+	Coordinate Coordinate
+	Outcome    ExpeditionOutcome
+	Resources  Resources
+	CreatedAt  time.Time
+}
+
+var expeditionResourcesRgx = regexp.MustCompile(`(Metal|Crystal|Deuterium) ([\d.,]+)`)
+
+// ClassifyExpeditionMessage default classifier, based on the english expedition messages
+func ClassifyExpeditionMessage(msg ExpeditionMessage) (ExpeditionOutcome, Resources) {
+	content := msg.Content
+	var res Resources
+	switch {
+	case strings.Contains(content, "have been captured"):
+		for _, m := range expeditionResourcesRgx.FindAllStringSubmatch(content, -1) {
+			amount := ParseInt(m[2])
+			switch m[1] {
+			case "Metal":
+				res.Metal += amount
+			case "Crystal":
+				res.Crystal += amount
+			case "Deuterium":
+				res.Deuterium += amount
+			}
+		}
+		return ExpeditionResources, res
+	case strings.Contains(content, "now part of the fleet"):
+		return ExpeditionShips, res
+	case strings.Contains(content, "Dark Matter"):
+		return ExpeditionDarkMatter, res
+	case strings.Contains(content, "item"):
+		return ExpeditionItem, res
+	case strings.Contains(content, "pirates") || strings.Contains(content, "barbarians") || strings.Contains(content, "aliens") ||
+		strings.Contains(content, "unknown species"):
+		return ExpeditionCombat, res
+	case strings.Contains(content, "black hole"):
+		return ExpeditionLost, res
+	case strings.Contains(content, "earlier") || strings.Contains(content, "ahead of schedule"):
+		return ExpeditionEarly, res
+	case strings.Contains(content, "delay") || strings.Contains(content, "come back without") ||
+		strings.Contains(content, "cannot continue") || strings.Contains(content, "some time"):
+		return ExpeditionDelay, res
+	}
+	return ExpeditionNothing, res
+}
+
+// MaxExpeditionFind returns the maximum resources (in metal) an expedition can find, using the points of the top 1 player
+func MaxExpeditionFind(topScore int64, characterClass CharacterClass, universeSpeed int64) int64 {
+	var maxFind int64
+	switch {
+	case topScore < 10000:
+		maxFind = 40000
+	case topScore < 100000:
+		maxFind = 500000
+	case topScore < 1000000:
+		maxFind = 1200000
+	case topScore < 5000000:
+		maxFind = 1800000
+	case topScore < 25000000:
+		maxFind = 2400000
+	case topScore < 50000000:
+		maxFind = 3000000
+	case topScore < 75000000:
+		maxFind = 3600000
+	case topScore < 100000000:
+		maxFind = 4200000
+	default:
+		maxFind = 5000000
+	}
+	if characterClass.IsDiscoverer() {
+		maxFind = int64(float64(maxFind) * 1.5 * float64(MaxInt(universeSpeed, 1)))
+	}
+	return maxFind
+}
+
+type ongoingExpedition struct {
+	fleet  Fleet
+	system Coordinate
+}
+
+// ExpeditionManager keeps all expedition slots busy, rotating the target systems
+type ExpeditionManager struct {
+	sync.Mutex
+	b                  Wrapper
+	origin             Celestial
+	topScore           int64
+	cargoShip          ID
+	escortID           ID
+	escortNbr          int64
+	fromSystem         int64
+	toSystem           int64
+	duration           int64
+	speed              Speed
+	depletionWindow    int
+	depletionThreshold float64
+	classifier         func(ExpeditionMessage) (ExpeditionOutcome, Resources)
+	ongoing            []ongoingExpedition
+	sentAt             map[Coordinate][]time.Time
+	logs               []ExpeditionLog
+	seenMessages       map[int64]bool
+}
+
+// NewExpeditionManager ...
+func NewExpeditionManager(b Wrapper, origin interface{}) *ExpeditionManager {
+	m := new(ExpeditionManager)
+	m.b = b
+	m.origin = b.GetCachedCelestial(origin)
+	m.cargoShip = LargeCargoID
+	if m.origin != nil {
+		m.fromSystem = m.origin.GetCoordinate().System
+		m.toSystem = m.fromSystem
+	}
+	m.duration = 1
+	m.speed = HundredPercent
+	m.depletionWindow = 10
+	m.depletionThreshold = 0.6
+	m.classifier = ClassifyExpeditionMessage
+	m.sentAt = make(map[Coordinate][]time.Time)
+	m.seenMessages = make(map[int64]bool)
+	return m
+}
+
+// SetTopScore sets the points of the top 1 player, used to compute the cargo capacity needed
+func (m *ExpeditionManager) SetTopScore(topScore int64) *ExpeditionManager {
+	m.topScore = topScore
+	return m
+}
+
+// SetHighscore sets the top score from the first page of the points highscore
+func (m *ExpeditionManager) SetHighscore(highscore Highscore) *ExpeditionManager {
+	for _, player := range highscore.Players {
+		m.topScore = MaxInt(m.topScore, player.Score)
+	}
+	return m
+}
+
+// SetCargoShip sets the ship used to carry the finds (LargeCargo by default)
+func (m *ExpeditionManager) SetCargoShip(cargoShip ID) *ExpeditionManager {
+	m.cargoShip = cargoShip
+	return m
+}
+
+// SetEscort sets the combat ships sent with every expedition, by default 1 of the strongest combat ship available
+func (m *ExpeditionManager) SetEscort(id ID, nbr int64) *ExpeditionManager {
+	m.escortID = id
+	m.escortNbr = nbr
+	return m
+}
+
+// SetSystems sets the range of systems, in the origin galaxy, the expeditions are rotated across
+func (m *ExpeditionManager) SetSystems(fromSystem, toSystem int64) *ExpeditionManager {
+	m.fromSystem = MinInt(fromSystem, toSystem)
+	m.toSystem = MaxInt(fromSystem, toSystem)
+	return m
+}
+
+// SetDuration sets the expedition holding time in hours
+func (m *ExpeditionManager) SetDuration(duration int64) *ExpeditionManager {
+	m.duration = MaxInt(duration, 1)
+	return m
+}
+
+// SetSpeed ...
+func (m *ExpeditionManager) SetSpeed(speed Speed) *ExpeditionManager {
+	m.speed = speed
+	return m
+}
+
+// SetDepletion a system is considered depleted when the ratio of expeditions that found nothing or got delayed,
+// among the window last ones, is above threshold
+func (m *ExpeditionManager) SetDepletion(window int, threshold float64) *ExpeditionManager {
+	m.depletionWindow = window
+	m.depletionThreshold = threshold
+	return m
+}
+
+// SetClassifier sets the function used to get the outcome of an expedition message (english by default)
+func (m *ExpeditionManager) SetClassifier(classifier func(ExpeditionMessage) (ExpeditionOutcome, Resources)) *ExpeditionManager {
+	m.classifier = classifier
+	return m
+}
+
+// Logs returns the outcomes of the expeditions
+func (m *ExpeditionManager) Logs() []ExpeditionLog {
+	m.Lock()
+	defer m.Unlock()
+	return append([]ExpeditionLog{}, m.logs...)
+}
+
+var escortPreference = []ID{ReaperID, DestroyerID, BattlecruiserID, BattleshipID, CruiserID, HeavyFighterID, LightFighterID}
+
+// Fleet returns the expedition fleet composed from the available ships: enough cargo ships for the biggest find,
+// one pathfinder, one espionage probe and a combat escort
+func (m *ExpeditionManager) Fleet(available ShipsInfos) ShipsInfos {
+	var out ShipsInfos
+	techs := m.b.GetCachedResearch()
+	characterClass := m.b.CharacterClass()
+	maxFind := MaxExpeditionFind(m.topScore, characterClass, m.b.GetUniverseSpeed())
+	if ship, ok := Objs.ByID(m.cargoShip).(Ship); ok {
+		probeRaids := m.b.GetServer().Settings.EspionageProbeRaids == 1
+		nbr := Resources{Metal: maxFind}.FitsIn(ship, techs, probeRaids, characterClass.IsCollector(), m.b.IsPioneers())
+		out.Set(m.cargoShip, MinInt(nbr, available.ByID(m.cargoShip)))
+	}
+	out.Set(PathfinderID, out.ByID(PathfinderID)+MinInt(1, available.Pathfinder-out.Pathfinder))
+	out.Set(EspionageProbeID, out.ByID(EspionageProbeID)+MinInt(1, available.EspionageProbe-out.EspionageProbe))
+	if m.escortID.IsSet() {
+		out.Set(m.escortID, out.ByID(m.escortID)+MinInt(m.escortNbr, available.ByID(m.escortID)-out.ByID(m.escortID)))
+	} else {
+		for _, id := range escortPreference {
+			if available.ByID(id)-out.ByID(id) > 0 {
+				out.Set(id, out.ByID(id)+1)
+				break
+			}
+		}
+	}
+	return out
+}
+
+// Depleted returns true if the recent expeditions in the system mostly found nothing
+func (m *ExpeditionManager) Depleted(galaxy, system int64) bool {
+	m.Lock()
+	defer m.Unlock()
+	return m.depleted(galaxy, system)
+}
+
+func (m *ExpeditionManager) depleted(galaxy, system int64) bool {
+	recent := make([]ExpeditionLog, 0, m.depletionWindow)
+	for i := len(m.logs) - 1; i >= 0 && len(recent) < m.depletionWindow; i-- {
+		if m.logs[i].Coordinate.Galaxy == galaxy && m.logs[i].Coordinate.System == system {
+			recent = append(recent, m.logs[i])
+		}
+	}
+	if len(recent) < m.depletionWindow || len(recent) == 0 {
+		return false
+	}
+	var bad int
+	for _, log := range recent {
+		if log.Outcome == ExpeditionNothing || log.Outcome == ExpeditionDelay {
+			bad++
+		}
+	}
+	return float64(bad)/float64(len(recent)) >= m.depletionThreshold
+}
+
+// NextSystem returns the expedition destination with the least expeditions sent in the last 24 hours,
+// preferring systems that are not depleted
+func (m *ExpeditionManager) NextSystem() Coordinate {
+	m.Lock()
+	defer m.Unlock()
+	return m.nextSystem()
+}
+
+func (m *ExpeditionManager) nextSystem() Coordinate {
+	galaxy := m.origin.GetCoordinate().Galaxy
+	since := m.b.ServerTime().Add(-24 * time.Hour)
+	candidates := make([]Coordinate, 0)
+	for system := m.fromSystem; system <= m.toSystem; system++ {
+		candidates = append(candidates, Coordinate{Galaxy: galaxy, System: system, Position: 16, Type: PlanetType})
+	}
+	recentCount := func(coord Coordinate) (n int) {
+		for _, t := range m.sentAt[coord] {
+			if t.After(since) {
+				n++
+			}
+		}
+		return
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		di, dj := m.depleted(galaxy, candidates[i].System), m.depleted(galaxy, candidates[j].System)
+		if di != dj {
+			return dj
+		}
+		return recentCount(candidates[i]) < recentCount(candidates[j])
+	})
+	return candidates[0]
+}
+
+// collect logs the outcome of the expeditions that came back
+func (m *ExpeditionManager) collect() error {
+	now := m.b.ServerTime()
+	ongoing := make([]ongoingExpedition, 0, len(m.ongoing))
+	for _, expedition := range m.ongoing {
+		if now.Before(expedition.fleet.BackTime) {
+			ongoing = append(ongoing, expedition)
+		}
+	}
+	returned := len(ongoing) < len(m.ongoing)
+	m.ongoing = ongoing
+	if !returned {
+		return nil
+	}
+	msgs, err := m.b.GetExpeditionMessages()
+	if err != nil {
+		return err
+	}
+	sort.SliceStable(msgs, func(i, j int) bool { return msgs[i].CreatedAt.Before(msgs[j].CreatedAt) })
+	for _, msg := range msgs {
+		if m.seenMessages[msg.ID] {
+			continue
+		}
+		m.seenMessages[msg.ID] = true
+		outcome, res := m.classifier(msg)
+		m.logs = append(m.logs, ExpeditionLog{MessageID: msg.ID, Coordinate: msg.Coordinate, Outcome: outcome, Resources: res, CreatedAt: msg.CreatedAt})
+	}
+	return nil
+}
+
+// Tick logs the returned expeditions, then sends new expeditions until all expedition slots are in use
+func (m *ExpeditionManager) Tick() ([]Fleet, error) {
+	m.Lock()
+	defer m.Unlock()
+	if m.origin == nil {
+		return nil, errors.New("invalid origin")
+	}
+	if err := m.collect(); err != nil {
+		return nil, err
+	}
+	sent := make([]Fleet, 0)
+	for {
+		slots := m.b.GetSlots()
+		if slots.ExpInUse >= slots.ExpTotal || slots.InUse >= slots.Total {
+			return sent, nil
+		}
+		available, err := m.b.GetShips(m.origin.GetID())
+		if err != nil {
+			return sent, err
+		}
+		ships := m.Fleet(available)
+		if !ships.HasShips() {
+			return sent, errors.New("no ships available for expedition")
+		}
+		system := m.nextSystem()
+		fleet, err := NewFleetBuilder(m.b).
+			SetOrigin(m.origin).
+			SetDestination(system).
+			SetMission(Expedition).
+			SetSpeed(m.speed).
+			SetShips(ships).
+			SetDuration(m.duration).
+			SendNow()
+		if err != nil {
+			return sent, err
+		}
+		m.sentAt[system] = append(m.sentAt[system], m.b.ServerTime())
+		m.ongoing = append(m.ongoing, ongoingExpedition{fleet: fleet, system: system})
+		sent = append(sent, fleet)
+	}
+}
+
+// nextReturn returns when the first ongoing expedition comes back
+func (m *ExpeditionManager) nextReturn() (next time.Time, ok bool) {
+	m.Lock()
+	defer m.Unlock()
+	for _, expedition := range m.ongoing {
+		if !ok || expedition.fleet.BackTime.Before(next) {
+			next, ok = expedition.fleet.BackTime, true
+		}
+	}
+	return
+}
+
+// Run calls Tick every interval until stop is closed.
+// A tick is also scheduled as soon as an expedition comes back, to send it again.
+func (m *ExpeditionManager) Run(interval time.Duration, stop <-chan struct{}) {
+	for {
+		_, _ = m.Tick()
+		wait := interval
+		if next, ok := m.nextReturn(); ok {
+			if untilNext := next.Sub(m.b.ServerTime()); untilNext < wait {
+				wait = untilNext
+			}
+		}
+		if wait < time.Second {
+			wait = time.Second
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-stop:
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
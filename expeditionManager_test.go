@@ -0,0 +1,104 @@
+package ogame
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifyExpeditionMessage(t *testing.T) {
+	outcome, res := ClassifyExpeditionMessage(ExpeditionMessage{Content: "Your expedition discovered a small asteroid from which some resources could be harvested.Metal 900.000 have been captured."})
+	assert.Equal(t, ExpeditionResources, outcome)
+	assert.Equal(t, Resources{Metal: 900000}, res)
+	outcome, _ = ClassifyExpeditionMessage(ExpeditionMessage{Content: "We came across the remains of a previous expedition! The following ships are now part of the fleet:"})
+	assert.Equal(t, ExpeditionShips, outcome)
+	outcome, _ = ClassifyExpeditionMessage(ExpeditionMessage{Content: "The expedition was able to capture and store some Dark Matter."})
+	assert.Equal(t, ExpeditionDarkMatter, outcome)
+	outcome, _ = ClassifyExpeditionMessage(ExpeditionMessage{Content: "Some primitive barbarians are attacking us with spaceships."})
+	assert.Equal(t, ExpeditionCombat, outcome)
+	outcome, _ = ClassifyExpeditionMessage(ExpeditionMessage{Content: "Besides some quaint, small pets from a unknown marsh planet, this expedition brings nothing thrilling back."})
+	assert.Equal(t, ExpeditionNothing, outcome)
+}
+
+func TestMaxExpeditionFind(t *testing.T) {
+	assert.Equal(t, int64(40000), MaxExpeditionFind(5000, NoClass, 1))
+	assert.Equal(t, int64(2400000), MaxExpeditionFind(10000000, Collector, 1))
+	assert.Equal(t, int64(3600000), MaxExpeditionFind(10000000, Discoverer, 1))
+	assert.Equal(t, int64(5000000), MaxExpeditionFind(200000000, General, 1))
+}
+
+type fakeExpeditionWrapper struct {
+	*fakeFleets
+	expeditionMsgs []ExpeditionMessage
+}
+
+func (w *fakeExpeditionWrapper) GetExpeditionMessages() ([]ExpeditionMessage, error) {
+	return w.expeditionMsgs, nil
+}
+
+func TestExpeditionManager_Fleet(t *testing.T) {
+	w := &fakeExpeditionWrapper{fakeFleets: newFakeFleets()}
+	origin := Planet{ID: 1, Coordinate: Coordinate{1, 100, 8, PlanetType}}
+	w.celestials = []Celestial{origin}
+	m := NewExpeditionManager(w, origin).SetTopScore(500000)
+	fleet := m.Fleet(ShipsInfos{LargeCargo: 1000, Pathfinder: 3, EspionageProbe: 5, Battleship: 2, Destroyer: 1})
+	assert.Equal(t, ShipsInfos{LargeCargo: 48, Pathfinder: 1, EspionageProbe: 1, Destroyer: 1}, fleet)
+
+	fleet = m.SetEscort(BattleshipID, 5).Fleet(ShipsInfos{LargeCargo: 10, Battleship: 2})
+	assert.Equal(t, ShipsInfos{LargeCargo: 10, Battleship: 2}, fleet)
+}
+
+func TestExpeditionManager_Tick(t *testing.T) {
+	w := &fakeExpeditionWrapper{fakeFleets: newFakeFleets()}
+	origin := Planet{ID: 1, Coordinate: Coordinate{1, 100, 8, PlanetType}}
+	w.celestials = []Celestial{origin}
+	w.ships[1] = ShipsInfos{LargeCargo: 1000, EspionageProbe: 10, LightFighter: 10}
+	w.slots.ExpTotal = 3
+	m := NewExpeditionManager(w, origin).SetTopScore(5000).SetSystems(99, 101)
+
+	fleets, err := m.Tick()
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(fleets))
+	assert.Equal(t, Coordinate{1, 99, 16, PlanetType}, fleets[0].Destination)
+	assert.Equal(t, Coordinate{1, 100, 16, PlanetType}, fleets[1].Destination)
+	assert.Equal(t, Coordinate{1, 101, 16, PlanetType}, fleets[2].Destination)
+	assert.Equal(t, Expedition, w.sentFleets[0].Mission)
+	assert.Equal(t, ShipsInfos{LargeCargo: 2, EspionageProbe: 1, LightFighter: 1}, fleets[0].Ships)
+
+	next, ok := m.nextReturn()
+	assert.True(t, ok)
+	assert.Equal(t, w.sentFleets[0].BackTime, next)
+
+	// Nothing to do while all the expedition slots are in use
+	fleets, err = m.Tick()
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(fleets))
+
+	// Fleets came back, system 99 keeps finding nothing
+	w.now = w.now.Add(2 * time.Hour)
+	w.slots.InUse, w.slots.ExpInUse = 0, 0
+	for i := 0; i < 10; i++ {
+		w.expeditionMsgs = append(w.expeditionMsgs, ExpeditionMessage{ID: int64(i + 1), Coordinate: Coordinate{1, 99, 16, PlanetType},
+			Content: "This expedition brings nothing thrilling back.", CreatedAt: w.now.Add(time.Duration(i) * time.Minute)})
+	}
+	w.expeditionMsgs = append(w.expeditionMsgs, ExpeditionMessage{ID: 11, Coordinate: Coordinate{1, 100, 16, PlanetType},
+		Content: "Metal 1.000 have been captured.", CreatedAt: w.now.Add(-time.Minute)})
+	fleets, err = m.Tick()
+	assert.NoError(t, err)
+	assert.Equal(t, 11, len(m.Logs()))
+	assert.True(t, m.Depleted(1, 99))
+	assert.False(t, m.Depleted(1, 100))
+	assert.Equal(t, Resources{Metal: 1000}, m.Logs()[0].Resources)
+	assert.Equal(t, 3, len(fleets))
+	for _, fleet := range fleets {
+		assert.NotEqual(t, int64(99), fleet.Destination.System)
+	}
+
+	// Messages are only logged once
+	w.slots.InUse, w.slots.ExpInUse = 0, 0
+	w.now = w.now.Add(2 * time.Hour)
+	_, err = m.Tick()
+	assert.NoError(t, err)
+	assert.Equal(t, 11, len(m.Logs()))
+}
@@ -49,6 +49,54 @@ func (e ExtractorV6) ExtractMarketplaceMessages(pageHTML []byte, location *time.
 	panic("implement me")
 }
 
+// ExtractMarketplaceOffers ...
+func (e ExtractorV6) ExtractMarketplaceOffers(pageHTML []byte) ([]MarketplaceOffer, int64, error) {
+	return nil, 0, errors.New("marketplace not supported in v6")
+}
+
+// ExtractChatConversations ...
+func (e ExtractorV6) ExtractChatConversations(pageHTML []byte, location *time.Location) ([]ChatConversation, error) {
+	return nil, errors.New("chat not supported in v6")
+}
+
+// ExtractBuddies ...
+func (e ExtractorV6) ExtractBuddies(pageHTML []byte) ([]Buddy, error) {
+	return nil, errors.New("buddies not supported in v6")
+}
+
+// ExtractIgnoredPlayers ...
+func (e ExtractorV6) ExtractIgnoredPlayers(pageHTML []byte) ([]IgnoredPlayer, error) {
+	return nil, errors.New("buddies not supported in v6")
+}
+
+// ExtractBuddiesToken ...
+func (e ExtractorV6) ExtractBuddiesToken(pageHTML []byte) (string, error) {
+	return "", errors.New("buddies not supported in v6")
+}
+
+// The alliance management pages are only parsed from v7 on, there is no v6 sample of those
+// pages to build the selectors from.
+
+// ExtractAllianceOverview ...
+func (e ExtractorV6) ExtractAllianceOverview(pageHTML []byte, location *time.Location) (AllianceOverview, error) {
+	return AllianceOverview{}, errors.New("alliance management not supported in v6")
+}
+
+// ExtractAllianceRanks ...
+func (e ExtractorV6) ExtractAllianceRanks(pageHTML []byte) ([]AllianceRank, error) {
+	return nil, errors.New("alliance management not supported in v6")
+}
+
+// ExtractAllianceApplications ...
+func (e ExtractorV6) ExtractAllianceApplications(pageHTML []byte, location *time.Location) ([]AllianceApplication, error) {
+	return nil, errors.New("alliance management not supported in v6")
+}
+
+// ExtractAllianceToken ...
+func (e ExtractorV6) ExtractAllianceToken(pageHTML []byte) (string, error) {
+	return "", errors.New("alliance management not supported in v6")
+}
+
 // ExtractExpeditionMessages ...
 func (e ExtractorV6) ExtractExpeditionMessages(pageHTML []byte, location *time.Location) ([]ExpeditionMessage, int64, error) {
 	panic("implement me")
@@ -181,12 +229,30 @@ func (e ExtractorV6) extractAttacks(pageHTML []byte, clock clockwork.Clock, ownC
 	return e.ExtractAttacksFromDoc(doc, clock, ownCoords)
 }
 
+// ExtractMessages ...
+func (e ExtractorV6) ExtractMessages(pageHTML []byte, location *time.Location) ([]Message, int64, error) {
+	doc, _ := goquery.NewDocumentFromReader(bytes.NewReader(pageHTML))
+	return e.ExtractMessagesFromDoc(doc, location)
+}
+
+// ExtractAlliancePublicInfo ...
+func (e ExtractorV6) ExtractAlliancePublicInfo(pageHTML []byte) (AlliancePublicInfo, error) {
+	doc, _ := goquery.NewDocumentFromReader(bytes.NewReader(pageHTML))
+	return e.ExtractAlliancePublicInfoFromDoc(doc)
+}
+
 // ExtractOfferOfTheDay ...
 func (e ExtractorV6) ExtractOfferOfTheDay(pageHTML []byte) (int64, string, PlanetResources, Multiplier, error) {
 	doc, _ := goquery.NewDocumentFromReader(bytes.NewReader(pageHTML))
 	return e.ExtractOfferOfTheDayFromDoc(doc)
 }
 
+// ExtractResourceMerchant ...
+func (e ExtractorV6) ExtractResourceMerchant(pageHTML []byte) (ResourceMerchant, error) {
+	doc, _ := goquery.NewDocumentFromReader(bytes.NewReader(pageHTML))
+	return e.ExtractResourceMerchantFromDoc(doc)
+}
+
 // ExtractResourcesBuildings ...
 func (e ExtractorV6) ExtractResourcesBuildings(pageHTML []byte) (ResourcesBuildings, error) {
 	doc, _ := goquery.NewDocumentFromReader(bytes.NewReader(pageHTML))
@@ -455,11 +521,26 @@ func (e ExtractorV6) ExtractAttacksFromDoc(doc *goquery.Document, clock clockwor
 	return extractAttacksFromDocV6(doc, clock, ownCoords)
 }
 
+// ExtractMessagesFromDoc ...
+func (e ExtractorV6) ExtractMessagesFromDoc(doc *goquery.Document, location *time.Location) ([]Message, int64, error) {
+	return extractMessagesFromDocV6(doc, location)
+}
+
+// ExtractAlliancePublicInfoFromDoc ...
+func (e ExtractorV6) ExtractAlliancePublicInfoFromDoc(doc *goquery.Document) (AlliancePublicInfo, error) {
+	return extractAlliancePublicInfoFromDocV6(doc)
+}
+
 // ExtractOfferOfTheDayFromDoc ...
 func (e ExtractorV6) ExtractOfferOfTheDayFromDoc(doc *goquery.Document) (price int64, importToken string, planetResources PlanetResources, multiplier Multiplier, err error) {
 	return extractOfferOfTheDayFromDocV6(doc)
 }
 
+// ExtractResourceMerchantFromDoc ...
+func (e ExtractorV6) ExtractResourceMerchantFromDoc(doc *goquery.Document) (ResourceMerchant, error) {
+	return extractResourceMerchantFromDocV6(doc)
+}
+
 // ExtractProductionFromDoc extracts ships/defenses production from the shipyard page
 func (e ExtractorV6) ExtractProductionFromDoc(doc *goquery.Document) ([]Quantifiable, error) {
 	return extractProductionFromDocV6(doc)
@@ -46,6 +46,58 @@ func (e ExtractorV7) ExtractMarketplaceMessages(pageHTML []byte, location *time.
 	return e.ExtractMarketplaceMessagesFromDoc(doc, location)
 }
 
+// ExtractMarketplaceOffers ...
+func (e ExtractorV7) ExtractMarketplaceOffers(pageHTML []byte) ([]MarketplaceOffer, int64, error) {
+	doc, _ := goquery.NewDocumentFromReader(bytes.NewReader(pageHTML))
+	return e.ExtractMarketplaceOffersFromDoc(doc)
+}
+
+// ExtractChatConversations ...
+func (e ExtractorV7) ExtractChatConversations(pageHTML []byte, location *time.Location) ([]ChatConversation, error) {
+	doc, _ := goquery.NewDocumentFromReader(bytes.NewReader(pageHTML))
+	return e.ExtractChatConversationsFromDoc(doc, location)
+}
+
+// ExtractBuddies ...
+func (e ExtractorV7) ExtractBuddies(pageHTML []byte) ([]Buddy, error) {
+	doc, _ := goquery.NewDocumentFromReader(bytes.NewReader(pageHTML))
+	return e.ExtractBuddiesFromDoc(doc)
+}
+
+// ExtractIgnoredPlayers ...
+func (e ExtractorV7) ExtractIgnoredPlayers(pageHTML []byte) ([]IgnoredPlayer, error) {
+	doc, _ := goquery.NewDocumentFromReader(bytes.NewReader(pageHTML))
+	return e.ExtractIgnoredPlayersFromDoc(doc)
+}
+
+// ExtractBuddiesToken ...
+func (e ExtractorV7) ExtractBuddiesToken(pageHTML []byte) (string, error) {
+	return extractBuddiesTokenV7(pageHTML)
+}
+
+// ExtractAllianceOverview ...
+func (e ExtractorV7) ExtractAllianceOverview(pageHTML []byte, location *time.Location) (AllianceOverview, error) {
+	doc, _ := goquery.NewDocumentFromReader(bytes.NewReader(pageHTML))
+	return e.ExtractAllianceOverviewFromDoc(doc, location)
+}
+
+// ExtractAllianceRanks ...
+func (e ExtractorV7) ExtractAllianceRanks(pageHTML []byte) ([]AllianceRank, error) {
+	doc, _ := goquery.NewDocumentFromReader(bytes.NewReader(pageHTML))
+	return e.ExtractAllianceRanksFromDoc(doc)
+}
+
+// ExtractAllianceApplications ...
+func (e ExtractorV7) ExtractAllianceApplications(pageHTML []byte, location *time.Location) ([]AllianceApplication, error) {
+	doc, _ := goquery.NewDocumentFromReader(bytes.NewReader(pageHTML))
+	return e.ExtractAllianceApplicationsFromDoc(doc, location)
+}
+
+// ExtractAllianceToken ...
+func (e ExtractorV7) ExtractAllianceToken(pageHTML []byte) (string, error) {
+	return extractAllianceTokenV7(pageHTML)
+}
+
 // ExtractDefense ...
 func (e ExtractorV7) ExtractDefense(pageHTML []byte) (DefensesInfos, error) {
 	doc, _ := goquery.NewDocumentFromReader(bytes.NewReader(pageHTML))
@@ -170,6 +222,41 @@ func (e ExtractorV7) ExtractMarketplaceMessagesFromDoc(doc *goquery.Document, lo
 	return extractMarketplaceMessagesFromDocV7(doc, location)
 }
 
+// ExtractMarketplaceOffersFromDoc ...
+func (e ExtractorV7) ExtractMarketplaceOffersFromDoc(doc *goquery.Document) ([]MarketplaceOffer, int64, error) {
+	return extractMarketplaceOffersFromDocV7(doc)
+}
+
+// ExtractChatConversationsFromDoc ...
+func (e ExtractorV7) ExtractChatConversationsFromDoc(doc *goquery.Document, location *time.Location) ([]ChatConversation, error) {
+	return extractChatConversationsFromDocV7(doc, location)
+}
+
+// ExtractBuddiesFromDoc ...
+func (e ExtractorV7) ExtractBuddiesFromDoc(doc *goquery.Document) ([]Buddy, error) {
+	return extractBuddiesFromDocV7(doc)
+}
+
+// ExtractIgnoredPlayersFromDoc ...
+func (e ExtractorV7) ExtractIgnoredPlayersFromDoc(doc *goquery.Document) ([]IgnoredPlayer, error) {
+	return extractIgnoredPlayersFromDocV7(doc)
+}
+
+// ExtractAllianceOverviewFromDoc ...
+func (e ExtractorV7) ExtractAllianceOverviewFromDoc(doc *goquery.Document, location *time.Location) (AllianceOverview, error) {
+	return extractAllianceOverviewFromDocV7(doc, location)
+}
+
+// ExtractAllianceRanksFromDoc ...
+func (e ExtractorV7) ExtractAllianceRanksFromDoc(doc *goquery.Document) ([]AllianceRank, error) {
+	return extractAllianceRanksFromDocV7(doc)
+}
+
+// ExtractAllianceApplicationsFromDoc ...
+func (e ExtractorV7) ExtractAllianceApplicationsFromDoc(doc *goquery.Document, location *time.Location) ([]AllianceApplication, error) {
+	return extractAllianceApplicationsFromDocV7(doc, location)
+}
+
 // ExtractFacilitiesFromDoc ...
 func (e ExtractorV7) ExtractFacilitiesFromDoc(doc *goquery.Document) (Facilities, error) {
 	return extractFacilitiesFromDocV7(doc)
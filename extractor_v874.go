@@ -26,6 +26,17 @@ func (e ExtractorV874) ExtractOfferOfTheDayFromDoc(doc *goquery.Document) (price
 	return extractOfferOfTheDayFromDocV874(doc)
 }
 
+// ExtractResourceMerchant ...
+func (e ExtractorV874) ExtractResourceMerchant(pageHTML []byte) (ResourceMerchant, error) {
+	doc, _ := goquery.NewDocumentFromReader(bytes.NewReader(pageHTML))
+	return e.ExtractResourceMerchantFromDoc(doc)
+}
+
+// ExtractResourceMerchantFromDoc ...
+func (e ExtractorV874) ExtractResourceMerchantFromDoc(doc *goquery.Document) (ResourceMerchant, error) {
+	return extractResourceMerchantFromDocV874(doc)
+}
+
 // ExtractAuction ...
 func (e ExtractorV874) ExtractAuction(pageHTML []byte) (Auction, error) {
 	doc, _ := goquery.NewDocumentFromReader(bytes.NewReader(pageHTML))
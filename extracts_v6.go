@@ -552,6 +552,48 @@ func extractOfferOfTheDayFromDocV6(doc *goquery.Document) (price int64, importTo
 	return
 }
 
+// extractResourceMerchantFromDocV6 extracts the resource merchant from page "traderresources".
+// No capture of that page with a merchant called is available yet, so the token accepts both the
+// per-trader name (like importToken/auctioneerToken) and the plain "token" used since v7.2.
+func extractResourceMerchantFromDocV6(doc *goquery.Document) (ResourceMerchant, error) {
+	script := doc.Find("script").Text()
+	m := regexp.MustCompile(`var (?:resourcesToken|token)\s?=\s?"([^"]*)";`).FindStringSubmatch(script)
+	if len(m) != 2 {
+		return ResourceMerchant{}, errors.New("failed to extract resource merchant token")
+	}
+	return extractResourceMerchantScript(script, m[1])
+}
+
+// extractResourceMerchantScript extracts the resource merchant from the script variables of the resource market
+func extractResourceMerchantScript(script, token string) (ResourceMerchant, error) {
+	merchant := ResourceMerchant{Token: token}
+	m := regexp.MustCompile(`var planetResources\s?=\s?({[^;]*});`).FindStringSubmatch(script)
+	if len(m) != 2 {
+		return ResourceMerchant{}, errors.New("failed to extract resource merchant raw planet resources")
+	}
+	if err := json.Unmarshal([]byte(m[1]), &merchant.PlanetResources); err != nil {
+		return ResourceMerchant{}, err
+	}
+	m = regexp.MustCompile(`var callMerchantCost\s?=\s?(\d+);`).FindStringSubmatch(script)
+	if len(m) == 2 {
+		merchant.CallCost, _ = strconv.ParseInt(m[1], 10, 64)
+	}
+	m = regexp.MustCompile(`var merchantType\s?=\s?"([^"]*)";`).FindStringSubmatch(script)
+	if len(m) != 2 {
+		// No merchant called
+		return merchant, nil
+	}
+	merchant.Resource = MerchantResource(m[1])
+	m = regexp.MustCompile(`var exchangeRates\s?=\s?({[^;]*});`).FindStringSubmatch(script)
+	if len(m) != 2 {
+		return ResourceMerchant{}, errors.New("failed to extract resource merchant exchange rates")
+	}
+	if err := json.Unmarshal([]byte(m[1]), &merchant.Rates); err != nil {
+		return ResourceMerchant{}, err
+	}
+	return merchant, nil
+}
+
 func extractProductionFromDocV6(doc *goquery.Document) ([]Quantifiable, error) {
 	res := make([]Quantifiable, 0)
 	active := doc.Find("table.construction")
@@ -667,6 +709,60 @@ func extractEspionageReportMessageIDsFromDocV6(doc *goquery.Document) ([]Espiona
 	return msgs, nbPage
 }
 
+func extractMessagesFromDocV6(doc *goquery.Document, location *time.Location) ([]Message, int64, error) {
+	msgs := make([]Message, 0)
+	tab, _ := strconv.ParseInt(doc.Find("ul.pagination li").Last().AttrOr("data-tab", ""), 10, 64)
+	nbPage, _ := strconv.ParseInt(doc.Find("ul.pagination li").Last().AttrOr("data-page", "1"), 10, 64)
+	doc.Find("li.msg").Each(func(i int, s *goquery.Selection) {
+		id, err := strconv.ParseInt(s.AttrOr("data-msg-id", ""), 10, 64)
+		if err != nil {
+			return
+		}
+		msg := Message{ID: id, Tab: MessagesTab(tab)}
+		title := s.Find("span.msg_title")
+		msg.Title = strings.TrimSpace(title.Text())
+		msg.Sender = strings.TrimSpace(s.Find("span.msg_sender").Text())
+		msg.SenderID, _ = strconv.ParseInt(s.Find("[data-playerid]").AttrOr("data-playerid", ""), 10, 64)
+		msg.CreatedAt, _ = time.ParseInLocation("02.01.2006 15:04:05", strings.TrimSpace(s.Find(".msg_date").Text()), location)
+		msg.New = s.HasClass("msg_new")
+		msg.Favorite = s.Find(".icon_favorited").Length() > 0
+		if link := title.Find("a"); link.Length() > 0 {
+			msg.Coordinate = extractCoordV6(link.Text())
+			msg.Coordinate.Type = PlanetType
+			if link.Find("figure").HasClass("moon") {
+				msg.Coordinate.Type = MoonType
+			}
+		}
+		s.Find(".msg_content br").ReplaceWithHtml("\n")
+		msg.Content = strings.TrimSpace(s.Find(".msg_content").Text())
+		msg.Type = detectMessageType(msg.Tab, msg.Title)
+		if s.Find("span.espionageDefText").Length() > 0 {
+			msg.Type = ForeignEspionageMessage
+		}
+		msgs = append(msgs, msg)
+	})
+	return msgs, nbPage, nil
+}
+
+func extractAlliancePublicInfoFromDocV6(doc *goquery.Document) (AlliancePublicInfo, error) {
+	s := doc.Find("#allianceinfo")
+	if s.Length() == 0 {
+		return AlliancePublicInfo{}, errors.New("failed to find alliance info")
+	}
+	info := AlliancePublicInfo{}
+	info.ID, _ = strconv.ParseInt(s.AttrOr("data-alliance-id", ""), 10, 64)
+	info.Tag = strings.TrimSpace(s.Find("td.allyTag").Text())
+	info.Name = strings.TrimSpace(s.Find("td.allyName").Text())
+	info.Members = ParseInt(s.Find("td.allyMembers").Text())
+	class, _ := strconv.ParseInt(s.Find("td.allyClass [data-alliance-class]").AttrOr("data-alliance-class", ""), 10, 64)
+	info.Class = AllianceClass(class)
+	info.Homepage = s.Find("td.allyHomepage a").AttrOr("href", "")
+	s.Find("div.allyDescription br").ReplaceWithHtml("\n")
+	info.Description = strings.TrimSpace(s.Find("div.allyDescription").Text())
+	info.ApplicationsOpen = s.Find("a.applyAlliance").Length() > 0
+	return info, nil
+}
+
 func extractCombatReportMessagesFromDocV6(doc *goquery.Document) ([]CombatReportSummary, int64) {
 	msgs := make([]CombatReportSummary, 0)
 	nbPage, _ := strconv.ParseInt(doc.Find("ul.pagination li").Last().AttrOr("data-page", "1"), 10, 64)
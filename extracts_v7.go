@@ -759,9 +759,229 @@ func extractMarketplaceMessagesFromDocV7(doc *goquery.Document, location *time.L
 				msg.CreatedAt, _ = time.ParseInLocation("02.01.2006 15:04:05", s.Find(".msg_date").Text(), location)
 				msg.Token = token
 				msg.MarketTransactionID = marketTransactionID
+				s.Find(".msg_content br").ReplaceWithHtml("\n")
+				msg.Content = strings.TrimSpace(s.Find(".msg_content").Text())
 				msgs = append(msgs, msg)
 			}
 		}
 	})
 	return msgs, nbPage, nil
 }
+
+func extractMarketplaceOffersFromDocV7(doc *goquery.Document) ([]MarketplaceOffer, int64, error) {
+	offers := make([]MarketplaceOffer, 0)
+	nbPage, _ := strconv.ParseInt(doc.Find("ul.pagination li").Last().AttrOr("data-page", "1"), 10, 64)
+	doc.Find("div.row.item").Each(func(i int, s *goquery.Selection) {
+		id, err := strconv.ParseInt(s.AttrOr("data-itemid", ""), 10, 64)
+		if err != nil {
+			return
+		}
+		offer := MarketplaceOffer{ID: id}
+		thumbnail := s.Find(".thumbnail-wrap")
+		itemType, _ := strconv.ParseInt(thumbnail.AttrOr("data-item-type", ""), 10, 64)
+		offer.Item.Type = MarketplaceItemType(itemType)
+		itemID := thumbnail.AttrOr("data-item-id", "")
+		if offer.Item.Type == ItemsMarketplaceItemType {
+			offer.Item.Ref = itemID
+		} else {
+			offer.Item.ID = ID(ParseInt(itemID))
+		}
+		offer.Quantity = ParseInt(s.Find(".quantity").Text())
+		offer.Player = strings.TrimSpace(s.Find(".playerName").Text())
+		price := s.Find(".price .text")
+		priceType, _ := strconv.ParseInt(price.AttrOr("data-price-type", ""), 10, 64)
+		offer.PriceType = MarketplaceResource(priceType)
+		offer.Price = ParseInt(price.Text())
+		if offer.Quantity > 0 {
+			offer.PricePerUnit = float64(offer.Price) / float64(offer.Quantity)
+		}
+		if cancel := s.Find("a.cancelItem"); cancel.Length() > 0 {
+			offer.Cancelable = true
+			offer.Token = cancel.AttrOr("data-token", "")
+		}
+		offers = append(offers, offer)
+	})
+	return offers, nbPage, nil
+}
+
+func extractChatConversationsFromDocV7(doc *goquery.Document, location *time.Location) ([]ChatConversation, error) {
+	conversations := make([]ChatConversation, 0)
+	doc.Find("li.playerlist_item").Each(func(i int, s *goquery.Selection) {
+		conversation := ChatConversation{}
+		conversation.PlayerID, _ = strconv.ParseInt(s.AttrOr("data-playerid", ""), 10, 64)
+		conversation.AssociationID, _ = strconv.ParseInt(s.AttrOr("data-associationid", ""), 10, 64)
+		if conversation.PlayerID == 0 && conversation.AssociationID == 0 {
+			return
+		}
+		conversation.PlayerName = strings.TrimSpace(s.Find(".playername").Text())
+		conversation.LastMessage = strings.TrimSpace(s.Find(".msg_content").Text())
+		conversation.LastDate, _ = time.ParseInLocation("02.01.2006 15:04:05", strings.TrimSpace(s.Find(".msg_date").Text()), location)
+		conversation.Unread = ParseInt(s.Find(".new_msg_count").Text())
+		conversations = append(conversations, conversation)
+	})
+	if len(conversations) > 0 {
+		return conversations, nil
+	}
+	// The chat bar is on every page, its messages only carry the time of the day
+	doc.Find("div#chatBar li.chat_bar_list_item").Each(func(i int, s *goquery.Selection) {
+		conversation := ChatConversation{}
+		conversation.PlayerID, _ = strconv.ParseInt(s.AttrOr("data-playerid", ""), 10, 64)
+		conversation.AssociationID, _ = strconv.ParseInt(s.AttrOr("data-associationid", ""), 10, 64)
+		if conversation.PlayerID == 0 && conversation.AssociationID == 0 {
+			return
+		}
+		conversation.PlayerName = strings.TrimSpace(s.Find("span.cb_playername").Text())
+		conversation.LastMessage = strings.TrimSpace(s.Find("li.chat_msg").Last().Find(".msg_content").Text())
+		conversation.Unread = ParseInt(s.Find("span.new_msg_count").AttrOr("data-new-messages", ""))
+		conversations = append(conversations, conversation)
+	})
+	return conversations, nil
+}
+
+func extractBuddiesFromDocV7(doc *goquery.Document) ([]Buddy, error) {
+	buddies := make([]Buddy, 0)
+	doc.Find("table#buddylist tbody tr").Each(func(i int, s *goquery.Selection) {
+		id, err := strconv.ParseInt(s.AttrOr("data-buddyid", ""), 10, 64)
+		if err != nil {
+			return
+		}
+		player := s.Find("td.playername a")
+		buddy := Buddy{ID: id}
+		buddy.PlayerID, _ = strconv.ParseInt(player.AttrOr("data-playerid", ""), 10, 64)
+		buddy.PlayerName = strings.TrimSpace(player.Text())
+		buddy.Points = ParseInt(s.Find("td.points").Text())
+		buddy.Rank = ParseInt(s.Find("td.rank").Text())
+		if alliance := strings.TrimSpace(s.Find("td.alliance").Text()); alliance != "-" {
+			buddy.AllianceName = alliance
+		}
+		buddy.Home = extractCoordV6(s.Find("td.home").Text())
+		buddy.Home.Type = PlanetType
+		buddy.Online = s.Find("td.status span.online").Length() > 0
+		buddies = append(buddies, buddy)
+	})
+	return buddies, nil
+}
+
+func extractIgnoredPlayersFromDocV7(doc *goquery.Document) ([]IgnoredPlayer, error) {
+	players := make([]IgnoredPlayer, 0)
+	doc.Find("table#ignorelist tr").Each(func(i int, s *goquery.Selection) {
+		playerID, err := strconv.ParseInt(s.AttrOr("data-playerid", ""), 10, 64)
+		if err != nil {
+			return
+		}
+		players = append(players, IgnoredPlayer{PlayerID: playerID, PlayerName: strings.TrimSpace(s.Find("td.playername").Text())})
+	})
+	return players, nil
+}
+
+func extractBuddiesTokenV7(pageHTML []byte) (string, error) {
+	m := regexp.MustCompile(`var buddiesToken\s?=\s?"([^"]*)";`).FindSubmatch(pageHTML)
+	if len(m) != 2 {
+		return "", errors.New("failed to extract buddies token")
+	}
+	return string(m[1]), nil
+}
+
+func extractAllianceOverviewFromDocV7(doc *goquery.Document, location *time.Location) (AllianceOverview, error) {
+	info := doc.Find("div.allianceInfo")
+	if info.Length() == 0 {
+		return AllianceOverview{}, errors.New("failed to find alliance info")
+	}
+	overview := AllianceOverview{Members: make([]AllianceMember, 0)}
+	overview.ID, _ = strconv.ParseInt(info.AttrOr("data-alliance-id", ""), 10, 64)
+	overview.Tag = strings.TrimSpace(info.Find("td.allyTag").Text())
+	overview.Name = strings.TrimSpace(info.Find("td.allyName").Text())
+	overview.Class = extractAllianceClassV7(info.Find("td.allyClass"))
+	overview.Homepage = info.Find("td.allyHomepage a").AttrOr("href", "")
+	doc.Find("table#member-list tbody tr").Each(func(i int, s *goquery.Selection) {
+		playerID, err := strconv.ParseInt(s.AttrOr("data-playerid", ""), 10, 64)
+		if err != nil {
+			return
+		}
+		member := AllianceMember{PlayerID: playerID}
+		member.Name = strings.TrimSpace(s.Find("td.member_name").Text())
+		rank := s.Find("td.member_rank")
+		member.RankID, _ = strconv.ParseInt(rank.AttrOr("data-rank-id", ""), 10, 64)
+		member.RankName = strings.TrimSpace(rank.Text())
+		member.Points = ParseInt(s.Find("td.member_score").Text())
+		member.Home = extractCoordV6(s.Find("td.member_home").Text())
+		member.Home.Type = PlanetType
+		member.JoinedAt, _ = time.ParseInLocation("02.01.2006 15:04:05", strings.TrimSpace(s.Find("td.member_joined").Text()), location)
+		status := s.Find("td.member_online span")
+		if status.HasClass("online") {
+			member.Status = AllianceMemberOnline
+		} else if status.HasClass("min15") {
+			member.Status = AllianceMemberRecent
+		} else if status.HasClass("offline") {
+			member.Status = AllianceMemberOffline
+		}
+		member.Kickable = s.Find("a.kickMember").Length() > 0
+		overview.Members = append(overview.Members, member)
+	})
+	return overview, nil
+}
+
+func extractAllianceClassV7(s *goquery.Selection) AllianceClass {
+	class, _ := strconv.ParseInt(s.Find("[data-alliance-class]").AttrOr("data-alliance-class", ""), 10, 64)
+	return AllianceClass(class)
+}
+
+func extractAllianceRanksFromDocV7(doc *goquery.Document) ([]AllianceRank, error) {
+	ranks := make([]AllianceRank, 0)
+	doc.Find("table#ranks tbody tr").Each(func(i int, s *goquery.Selection) {
+		id, err := strconv.ParseInt(s.AttrOr("data-rank-id", ""), 10, 64)
+		if err != nil {
+			return
+		}
+		rank := AllianceRank{ID: id, Name: strings.TrimSpace(s.Find("td.rank_name").Text())}
+		prefix := "rights[" + strconv.FormatInt(id, 10) + "]"
+		checked := func(permission string) bool {
+			_, exists := s.Find(`input[name="` + prefix + "[" + permission + `]"]`).Attr("checked")
+			return exists
+		}
+		rank.Permissions = AlliancePermissions{
+			SeeApplications:  checked("seeApplications"),
+			EditApplications: checked("editApplications"),
+			SeeMembers:       checked("seeMembers"),
+			KickMembers:      checked("kickMembers"),
+			SeeOnlineStatus:  checked("seeOnlineStatus"),
+			CircularMessage:  checked("circularMessage"),
+			DisbandAlliance:  checked("disbandAlliance"),
+			ManageAlliance:   checked("manageAlliance"),
+			RightHand:        checked("rightHand"),
+		}
+		ranks = append(ranks, rank)
+	})
+	return ranks, nil
+}
+
+func extractAllianceApplicationsFromDocV7(doc *goquery.Document, location *time.Location) ([]AllianceApplication, error) {
+	applications := make([]AllianceApplication, 0)
+	doc.Find("table#applications tr.application").Each(func(i int, s *goquery.Selection) {
+		idStr := s.AttrOr("data-application-id", "")
+		id, err := strconv.ParseInt(idStr, 10, 64)
+		if err != nil {
+			return
+		}
+		player := s.Find("td.applicant_name a")
+		application := AllianceApplication{ID: id}
+		application.PlayerID, _ = strconv.ParseInt(player.AttrOr("data-playerid", ""), 10, 64)
+		application.PlayerName = strings.TrimSpace(player.Text())
+		application.Points = ParseInt(s.Find("td.applicant_score").Text())
+		application.Rank = ParseInt(s.Find("td.applicant_rank").Text())
+		application.Date, _ = time.ParseInLocation("02.01.2006 15:04:05", strings.TrimSpace(s.Find("td.application_date").Text()), location)
+		message := doc.Find(`tr.application_text[data-application-id="` + idStr + `"] td.application_message`)
+		message.Find("br").ReplaceWithHtml("\n")
+		application.Message = strings.TrimSpace(message.Text())
+		applications = append(applications, application)
+	})
+	return applications, nil
+}
+
+func extractAllianceTokenV7(pageHTML []byte) (string, error) {
+	m := regexp.MustCompile(`<input type="hidden" name="token" value="([^"]+)"`).FindSubmatch(pageHTML)
+	if len(m) != 2 {
+		return "", errors.New("failed to extract alliance token")
+	}
+	return string(m[1]), nil
+}
@@ -42,6 +42,15 @@ func extractOfferOfTheDayFromDocV874(doc *goquery.Document) (price int64, import
 	return
 }
 
+func extractResourceMerchantFromDocV874(doc *goquery.Document) (ResourceMerchant, error) {
+	script := doc.Find("script").Text()
+	m := regexp.MustCompile(`var token\s?=\s?"([^"]*)";`).FindStringSubmatch(script)
+	if len(m) != 2 {
+		return ResourceMerchant{}, errors.New("failed to extract resource merchant token")
+	}
+	return extractResourceMerchantScript(script, m[1])
+}
+
 // extractAuctionFromDocV874 extract auction information from page "traderAuctioneer"
 func extractAuctionFromDocV874(doc *goquery.Document) (Auction, error) {
 	auction := Auction{}
@@ -53,6 +53,34 @@ func (f Facilities) ByID(id ID) int64 {
 	return 0
 }
 
+// Set sets the facility level by facility id
+func (f *Facilities) Set(id ID, val int64) {
+	switch id {
+	case RoboticsFactoryID:
+		f.RoboticsFactory = val
+	case ShipyardID:
+		f.Shipyard = val
+	case ResearchLabID:
+		f.ResearchLab = val
+	case AllianceDepotID:
+		f.AllianceDepot = val
+	case MissileSiloID:
+		f.MissileSilo = val
+	case NaniteFactoryID:
+		f.NaniteFactory = val
+	case TerraformerID:
+		f.Terraformer = val
+	case SpaceDockID:
+		f.SpaceDock = val
+	case LunarBaseID:
+		f.LunarBase = val
+	case SensorPhalanxID:
+		f.SensorPhalanx = val
+	case JumpGateID:
+		f.JumpGate = val
+	}
+}
+
 func (f Facilities) String() string {
 	return "\n" +
 		"RoboticsFactory: " + strconv.FormatInt(f.RoboticsFactory, 10) + "\n" +
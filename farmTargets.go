@@ -0,0 +1,238 @@
+package ogame
+
+import (
+	"sort"
+	"time"
+)
+
+// FarmTarget a ranked farming target
+type FarmTarget struct {
+	Coordinate     Coordinate
+	PlayerID       int64
+	PlayerName     string
+	Report         *EspionageReport
+	ReportAge      time.Duration
+	NeedsEspionage bool // No report, report too old or missing fleet/defenses information
+	Defended       bool // Report shows ships or defenses
+	Loot           Resources
+	CargoShipID    ID
+	CargoShips     int64 // Number of cargo ships needed to carry the loot
+	Distance       int64
+	FlightTime     int64 // One way, in seconds
+	Fuel           int64
+	LootPerHour    int64 // Value of the loot minus the fuel, for a round trip
+}
+
+// FarmTargetFinder ranks farming targets around an origin using the galaxy and espionage reports
+type FarmTargetFinder struct {
+	b               Wrapper
+	origin          Coordinate
+	cargo           Ship
+	speed           Speed
+	maxReportAge    time.Duration
+	maxPlayerScore  int64
+	includeActive   bool
+	includeDefended bool
+	reports         map[Coordinate]EspionageReport
+	scores          map[int64]int64
+}
+
+// NewFarmTargetFinder ...
+func NewFarmTargetFinder(b Wrapper, origin Coordinate) *FarmTargetFinder {
+	f := new(FarmTargetFinder)
+	f.b = b
+	f.origin = origin
+	f.cargo = SmallCargo
+	f.speed = HundredPercent
+	f.maxReportAge = 6 * time.Hour
+	f.reports = make(map[Coordinate]EspionageReport)
+	f.scores = make(map[int64]int64)
+	return f
+}
+
+// SetCargoShip sets the ship used to carry the loot (SmallCargo by default)
+func (f *FarmTargetFinder) SetCargoShip(ship Ship) *FarmTargetFinder {
+	f.cargo = ship
+	return f
+}
+
+// SetSpeed ...
+func (f *FarmTargetFinder) SetSpeed(speed Speed) *FarmTargetFinder {
+	f.speed = speed
+	return f
+}
+
+// SetMaxReportAge reports older than this are flagged as needing a new espionage
+func (f *FarmTargetFinder) SetMaxReportAge(maxReportAge time.Duration) *FarmTargetFinder {
+	f.maxReportAge = maxReportAge
+	return f
+}
+
+// SetMaxPlayerScore skip players having more points than maxScore in the provided highscores
+func (f *FarmTargetFinder) SetMaxPlayerScore(maxScore int64) *FarmTargetFinder {
+	f.maxPlayerScore = maxScore
+	return f
+}
+
+// SetIncludeActive also consider players that are not inactive
+func (f *FarmTargetFinder) SetIncludeActive(includeActive bool) *FarmTargetFinder {
+	f.includeActive = includeActive
+	return f
+}
+
+// SetIncludeDefended also consider targets having ships or defenses
+func (f *FarmTargetFinder) SetIncludeDefended(includeDefended bool) *FarmTargetFinder {
+	f.includeDefended = includeDefended
+	return f
+}
+
+// AddReports keeps the most recent espionage report of every coordinate
+func (f *FarmTargetFinder) AddReports(reports ...EspionageReport) *FarmTargetFinder {
+	for _, report := range reports {
+		if report.Type != Report {
+			continue
+		}
+		if prev, ok := f.reports[report.Coordinate]; ok && prev.Date.After(report.Date) {
+			continue
+		}
+		f.reports[report.Coordinate] = report
+	}
+	return f
+}
+
+// AddHighscore keeps the score of every players in the highscore page
+func (f *FarmTargetFinder) AddHighscore(highscores ...Highscore) *FarmTargetFinder {
+	for _, highscore := range highscores {
+		for _, player := range highscore.Players {
+			f.scores[player.ID] = player.Score
+		}
+	}
+	return f
+}
+
+// FetchReports fetches all the espionage reports from the messages
+func (f *FarmTargetFinder) FetchReports() error {
+	summaries, err := f.b.GetEspionageReportMessages()
+	if err != nil {
+		return err
+	}
+	for _, summary := range summaries {
+		if summary.Type != Report {
+			continue
+		}
+		report, err := f.b.GetEspionageReport(summary.ID)
+		if err != nil {
+			return err
+		}
+		f.AddReports(report)
+	}
+	return nil
+}
+
+func (f *FarmTargetFinder) isCandidate(p PlanetInfos) bool {
+	if p.Destroyed || p.Vacation || p.Newbie || p.StrongPlayer || p.Administrator || p.Banned {
+		return false
+	}
+	if !p.Inactive && !f.includeActive {
+		return false
+	}
+	if score, ok := f.scores[p.Player.ID]; ok && f.maxPlayerScore > 0 && score > f.maxPlayerScore {
+		return false
+	}
+	return true
+}
+
+// estimated resources produced by the target since the report was made
+func (f *FarmTargetFinder) producedSince(report EspionageReport, elapsed time.Duration) Resources {
+	resBuildings := report.ResourcesBuildings()
+	if resBuildings == nil {
+		return Resources{}
+	}
+	var researches Researches
+	if r := report.Researches(); r != nil {
+		researches = *r
+	}
+	resSettings := ResourceSettings{MetalMine: 100, CrystalMine: 100, DeuteriumSynthesizer: 100, SolarPlant: 100, FusionReactor: 100, SolarSatellite: 100}
+	temperature := PositionTemperature(report.Coordinate.Position)
+	productions := getResourcesProductionsLight(*resBuildings, researches, resSettings, temperature, f.b.GetUniverseSpeed())
+	hours := elapsed.Hours()
+	return Resources{
+		Metal:     int64(float64(productions.Metal) * hours),
+		Crystal:   int64(float64(productions.Crystal) * hours),
+		Deuterium: int64(float64(MaxInt(productions.Deuterium, 0)) * hours),
+	}
+}
+
+func (f *FarmTargetFinder) newTarget(p PlanetInfos, now time.Time) (FarmTarget, bool) {
+	characterClass := f.b.CharacterClass()
+	target := FarmTarget{Coordinate: p.Coordinate, PlayerID: p.Player.ID, PlayerName: p.Player.Name, CargoShipID: f.cargo.GetID()}
+	target.Distance = f.b.Distance(f.origin, p.Coordinate)
+	report, found := f.reports[p.Coordinate]
+	if !found {
+		target.NeedsEspionage = true
+		target.FlightTime, target.Fuel = f.b.FlightTime(f.origin, p.Coordinate, f.speed, newShipsInfos(f.cargo.GetID(), 1), Attack)
+		return target, true
+	}
+	target.Report = &report
+	target.ReportAge = now.Sub(report.Date)
+	target.NeedsEspionage = target.ReportAge > f.maxReportAge || !report.HasFleetInformation || !report.HasDefensesInformation
+	target.Defended = !report.IsDefenceless() && report.HasFleetInformation && report.HasDefensesInformation
+	if target.Defended && !f.includeDefended {
+		return target, false
+	}
+
+	// Flight time with a single ship to estimate the production until the fleet lands
+	secs, _ := f.b.FlightTime(f.origin, p.Coordinate, f.speed, newShipsInfos(f.cargo.GetID(), 1), Attack)
+	elapsed := target.ReportAge + time.Duration(secs)*time.Second
+	plunderRatio := report.PlunderRatio(characterClass)
+	produced := f.producedSince(report, elapsed)
+	target.Loot = report.Loot(characterClass).Add(Resources{
+		Metal:     int64(float64(produced.Metal) * plunderRatio),
+		Crystal:   int64(float64(produced.Crystal) * plunderRatio),
+		Deuterium: int64(float64(produced.Deuterium) * plunderRatio),
+	})
+
+	probeRaids := f.b.GetServer().Settings.EspionageProbeRaids == 1
+	target.CargoShips = MaxInt(target.Loot.FitsIn(f.cargo, f.b.GetCachedResearch(), probeRaids, characterClass.IsCollector(), f.b.IsPioneers()), 1)
+	target.FlightTime, target.Fuel = f.b.FlightTime(f.origin, p.Coordinate, f.speed, newShipsInfos(f.cargo.GetID(), target.CargoShips), Attack)
+	if target.FlightTime > 0 {
+		net := target.Loot.Value() - Resources{Deuterium: target.Fuel}.Value()
+		target.LootPerHour = int64(float64(net) / (float64(2*target.FlightTime) / 3600))
+	}
+	return target, true
+}
+
+// Rank returns the targets sorted by best loot per hour. Targets without any report are listed last, closest first.
+// Targets with an outdated or incomplete report keep their rank and have NeedsEspionage set.
+func (f *FarmTargetFinder) Rank(planets []PlanetInfos) []FarmTarget {
+	now := f.b.ServerTime()
+	out := make([]FarmTarget, 0)
+	for _, p := range planets {
+		if !f.isCandidate(p) {
+			continue
+		}
+		if target, ok := f.newTarget(p, now); ok {
+			out = append(out, target)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		hasLootI, hasLootJ := out[i].Report != nil, out[j].Report != nil
+		if hasLootI != hasLootJ {
+			return hasLootI
+		}
+		if out[i].LootPerHour != out[j].LootPerHour {
+			return out[i].LootPerHour > out[j].LootPerHour
+		}
+		return out[i].Distance < out[j].Distance
+	})
+	return out
+}
+
+// RankFromStore ranks the planets from a galaxy store
+func (f *FarmTargetFinder) RankFromStore(store GalaxyStore) ([]FarmTarget, error) {
+	planets, err := FindPlanets(store)
+	if err != nil {
+		return nil, err
+	}
+	return f.Rank(planets), nil
+}
@@ -0,0 +1,66 @@
+package ogame
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFarmTargetFinder_Rank(t *testing.T) {
+	w := newFakeBot()
+	origin := Coordinate{1, 100, 8, PlanetType}
+	newPlanet := func(system, position, playerID int64) PlanetInfos {
+		p := PlanetInfos{Coordinate: Coordinate{1, system, position, PlanetType}, Inactive: true}
+		p.Player.ID = playerID
+		return p
+	}
+	near := newPlanet(101, 5, 1)
+	far := newPlanet(150, 5, 2)
+	noReport := newPlanet(100, 9, 3)
+	vacation := newPlanet(100, 10, 4)
+	vacation.Vacation = true
+	defended := newPlanet(102, 5, 5)
+	strong := newPlanet(103, 5, 6)
+
+	newReport := func(coord Coordinate, metal int64, age time.Duration) EspionageReport {
+		r := EspionageReport{Coordinate: coord, Type: Report, Date: w.now.Add(-age), IsInactive: true,
+			HasFleetInformation: true, HasDefensesInformation: true}
+		r.Metal = metal
+		return r
+	}
+	defendedReport := newReport(defended.Coordinate, 1000000, time.Minute)
+	defendedReport.RocketLauncher = I64Ptr(10)
+
+	f := NewFarmTargetFinder(w, origin).
+		SetMaxPlayerScore(1000).
+		AddReports(newReport(near.Coordinate, 100000, time.Minute), newReport(far.Coordinate, 100000, time.Minute), defendedReport).
+		AddReports(newReport(near.Coordinate, 1, 2*time.Hour)). // older report is ignored
+		AddHighscore(Highscore{Players: []HighscorePlayer{{ID: 6, Score: 5000}}})
+	targets := f.Rank([]PlanetInfos{far, noReport, near, vacation, defended, strong})
+
+	assert.Equal(t, 3, len(targets))
+	assert.Equal(t, near.Coordinate, targets[0].Coordinate)
+	assert.Equal(t, int64(50000), targets[0].Loot.Metal)
+	assert.Equal(t, int64(10), targets[0].CargoShips)
+	assert.False(t, targets[0].NeedsEspionage)
+	assert.True(t, targets[0].LootPerHour > targets[1].LootPerHour)
+	assert.Equal(t, far.Coordinate, targets[1].Coordinate)
+	assert.Equal(t, noReport.Coordinate, targets[2].Coordinate)
+	assert.True(t, targets[2].NeedsEspionage)
+
+	targets = f.SetIncludeDefended(true).Rank([]PlanetInfos{defended})
+	assert.Equal(t, 1, len(targets))
+	assert.True(t, targets[0].Defended)
+}
+
+func TestFarmTargetFinder_ProducedSince(t *testing.T) {
+	f := NewFarmTargetFinder(newFakeBot(), Coordinate{1, 100, 8, PlanetType})
+	report := EspionageReport{HasBuildingsInformation: true, DeuteriumSynthesizer: I64Ptr(10), SolarPlant: I64Ptr(20)}
+	report.Coordinate = Coordinate{1, 101, 1, PlanetType}
+	hot := f.producedSince(report, time.Hour)
+	report.Coordinate.Position = 15
+	cold := f.producedSince(report, time.Hour)
+	assert.True(t, hot.Deuterium > 0)
+	assert.True(t, cold.Deuterium > hot.Deuterium)
+}
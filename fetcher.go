@@ -57,6 +57,11 @@ const (
 	BuffActivationAjaxPage     = "buffActivation"
 	AuctioneerAjaxPage         = "auctioneer"
 	HighscoreContentAjaxPage   = "highscoreContent"
+
+	// alliance ajax pages
+	AllianceManagementAjaxPage   = "allianceManagement"
+	AllianceApplicationsAjaxPage = "allianceApplications"
+	AllianceBroadcastAjaxPage    = "allianceBroadcast"
 )
 
 func (b *OGame) getPage(page string, celestialID CelestialID, opts ...Option) ([]byte, error) {
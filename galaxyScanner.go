@@ -0,0 +1,370 @@
+package ogame
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+// GalaxySnapshot planets information of a system at the time it was scanned
+type GalaxySnapshot struct {
+	Galaxy           int64
+	System           int64
+	ScannedAt        time.Time
+	Planets          []PlanetInfos
+	ExpeditionDebris struct {
+		Metal             int64
+		Crystal           int64
+		PathfindersNeeded int64
+	}
+}
+
+// NewGalaxySnapshot creates a snapshot from a system infos
+func NewGalaxySnapshot(systemInfos SystemInfos, scannedAt time.Time) GalaxySnapshot {
+	snapshot := GalaxySnapshot{Galaxy: systemInfos.Galaxy(), System: systemInfos.System(), ScannedAt: scannedAt}
+	snapshot.ExpeditionDebris = systemInfos.ExpeditionDebris
+	systemInfos.Each(func(planetInfo *PlanetInfos) {
+		if planetInfo == nil {
+			return
+		}
+		p := *planetInfo
+		p.Date = scannedAt
+		snapshot.Planets = append(snapshot.Planets, p)
+	})
+	return snapshot
+}
+
+// Position returns the planet at position idx in the snapshot
+func (s GalaxySnapshot) Position(idx int64) *PlanetInfos {
+	for i := range s.Planets {
+		if s.Planets[i].Coordinate.Position == idx {
+			return &s.Planets[i]
+		}
+	}
+	return nil
+}
+
+// GalaxyStore persists the systems scanned by the GalaxyScanner
+type GalaxyStore interface {
+	SaveSystem(GalaxySnapshot) error
+	GetSystem(galaxy, system int64) (GalaxySnapshot, bool, error)
+	GetSystems() ([]GalaxySnapshot, error)
+}
+
+type galaxyKey struct {
+	galaxy int64
+	system int64
+}
+
+// MemoryGalaxyStore keeps the last snapshot of every system in memory
+type MemoryGalaxyStore struct {
+	sync.RWMutex
+	systems map[galaxyKey]GalaxySnapshot
+}
+
+// NewMemoryGalaxyStore ...
+func NewMemoryGalaxyStore() *MemoryGalaxyStore {
+	return &MemoryGalaxyStore{systems: make(map[galaxyKey]GalaxySnapshot)}
+}
+
+// SaveSystem ...
+func (s *MemoryGalaxyStore) SaveSystem(snapshot GalaxySnapshot) error {
+	s.Lock()
+	defer s.Unlock()
+	s.systems[galaxyKey{snapshot.Galaxy, snapshot.System}] = snapshot
+	return nil
+}
+
+// GetSystem ...
+func (s *MemoryGalaxyStore) GetSystem(galaxy, system int64) (GalaxySnapshot, bool, error) {
+	s.RLock()
+	defer s.RUnlock()
+	snapshot, found := s.systems[galaxyKey{galaxy, system}]
+	return snapshot, found, nil
+}
+
+// GetSystems returns all snapshots sorted by galaxy and system
+func (s *MemoryGalaxyStore) GetSystems() ([]GalaxySnapshot, error) {
+	s.RLock()
+	defer s.RUnlock()
+	out := make([]GalaxySnapshot, 0, len(s.systems))
+	for _, snapshot := range s.systems {
+		out = append(out, snapshot)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Galaxy != out[j].Galaxy {
+			return out[i].Galaxy < out[j].Galaxy
+		}
+		return out[i].System < out[j].System
+	})
+	return out, nil
+}
+
+// FileGalaxyStore memory store that is written as json in a file every batchSize saves and on Flush
+type FileGalaxyStore struct {
+	*MemoryGalaxyStore
+	file *jsonFileStore
+}
+
+// NewFileGalaxyStore creates a store backed by filename, loading existing snapshots if the file exists
+func NewFileGalaxyStore(filename string) (*FileGalaxyStore, error) {
+	s := &FileGalaxyStore{MemoryGalaxyStore: NewMemoryGalaxyStore()}
+	s.file = newJSONFileStore(filename, 100, func() interface{} {
+		snapshots, _ := s.MemoryGalaxyStore.GetSystems()
+		return snapshots
+	})
+	var snapshots []GalaxySnapshot
+	if err := s.file.load(&snapshots); err != nil {
+		return nil, err
+	}
+	for _, snapshot := range snapshots {
+		_ = s.MemoryGalaxyStore.SaveSystem(snapshot)
+	}
+	return s, nil
+}
+
+// SetBatchSize sets after how many saved systems the file is written
+func (s *FileGalaxyStore) SetBatchSize(batchSize int64) *FileGalaxyStore {
+	s.file.batchSize = batchSize
+	return s
+}
+
+// SaveSystem ...
+func (s *FileGalaxyStore) SaveSystem(snapshot GalaxySnapshot) error {
+	return s.file.save(func() { _ = s.MemoryGalaxyStore.SaveSystem(snapshot) })
+}
+
+// Flush writes the systems saved since the last write
+func (s *FileGalaxyStore) Flush() error {
+	return s.file.flush()
+}
+
+// GalaxyChangeType type of change detected between two scans of a system
+type GalaxyChangeType int64
+
+// Galaxy change types
+const (
+	NewColonyChange GalaxyChangeType = iota + 1
+	AbandonedPlanetChange
+	MoonCreatedChange
+	MoonDestroyedChange
+)
+
+func (t GalaxyChangeType) String() string {
+	switch t {
+	case NewColonyChange:
+		return "NewColony"
+	case AbandonedPlanetChange:
+		return "AbandonedPlanet"
+	case MoonCreatedChange:
+		return "MoonCreated"
+	case MoonDestroyedChange:
+		return "MoonDestroyed"
+	default:
+		return "Unknown"
+	}
+}
+
+// GalaxyChange a change detected at a coordinate between two scans
+type GalaxyChange struct {
+	Type       GalaxyChangeType
+	Coordinate Coordinate
+	Previous   *PlanetInfos
+	Current    *PlanetInfos
+}
+
+func isInhabited(p *PlanetInfos) bool {
+	return p != nil && !p.Destroyed
+}
+
+func hasMoon(p *PlanetInfos) bool {
+	return isInhabited(p) && p.Moon != nil
+}
+
+// DiffGalaxySnapshots returns the changes between two snapshots of the same system
+func DiffGalaxySnapshots(prev, curr GalaxySnapshot) (out []GalaxyChange) {
+	var i int64
+	for i = 1; i <= 15; i++ {
+		before, after := prev.Position(i), curr.Position(i)
+		coord := Coordinate{Galaxy: curr.Galaxy, System: curr.System, Position: i, Type: PlanetType}
+		ownerChanged := isInhabited(before) && isInhabited(after) && before.Player.ID != after.Player.ID
+		if isInhabited(before) && (!isInhabited(after) || ownerChanged) {
+			out = append(out, GalaxyChange{Type: AbandonedPlanetChange, Coordinate: coord, Previous: before, Current: after})
+		}
+		if isInhabited(after) && (!isInhabited(before) || ownerChanged) {
+			out = append(out, GalaxyChange{Type: NewColonyChange, Coordinate: coord, Previous: before, Current: after})
+		}
+		if !hasMoon(before) && hasMoon(after) {
+			out = append(out, GalaxyChange{Type: MoonCreatedChange, Coordinate: coord.Moon(), Previous: before, Current: after})
+		} else if hasMoon(before) && !hasMoon(after) && !ownerChanged && isInhabited(after) {
+			out = append(out, GalaxyChange{Type: MoonDestroyedChange, Coordinate: coord.Moon(), Previous: before, Current: after})
+		}
+	}
+	return
+}
+
+// GalaxyFilter returns true if the planet should be kept
+type GalaxyFilter func(PlanetInfos) bool
+
+// GalaxyByPlayer planets owned by playerID
+func GalaxyByPlayer(playerID int64) GalaxyFilter {
+	return func(p PlanetInfos) bool { return p.Player.ID == playerID }
+}
+
+// GalaxyByAlliance planets owned by members of allianceID
+func GalaxyByAlliance(allianceID int64) GalaxyFilter {
+	return func(p PlanetInfos) bool { return p.Alliance != nil && p.Alliance.ID == allianceID }
+}
+
+// GalaxyByRankRange planets owned by players ranked within [min, max]
+func GalaxyByRankRange(min, max int64) GalaxyFilter {
+	return func(p PlanetInfos) bool { return p.Player.Rank >= min && p.Player.Rank <= max }
+}
+
+// GalaxyInactive planets owned by inactive players that are not in vacation mode
+func GalaxyInactive(p PlanetInfos) bool {
+	return p.Inactive && !p.Vacation
+}
+
+// GalaxyVacation planets owned by players in vacation mode
+func GalaxyVacation(p PlanetInfos) bool {
+	return p.Vacation
+}
+
+// GalaxyHasMoon planets having a moon
+func GalaxyHasMoon(p PlanetInfos) bool {
+	return p.Moon != nil
+}
+
+// GalaxyDebrisAbove planets having a debris field worth more than the given amount of resources
+func GalaxyDebrisAbove(amount int64) GalaxyFilter {
+	return func(p PlanetInfos) bool { return p.Debris.Metal+p.Debris.Crystal > amount }
+}
+
+// FindPlanets returns the stored planets matching all filters
+func FindPlanets(store GalaxyStore, filters ...GalaxyFilter) ([]PlanetInfos, error) {
+	snapshots, err := store.GetSystems()
+	if err != nil {
+		return nil, err
+	}
+	out := make([]PlanetInfos, 0)
+	for _, snapshot := range snapshots {
+	planetsLoop:
+		for _, p := range snapshot.Planets {
+			if p.Destroyed {
+				continue
+			}
+			for _, filter := range filters {
+				if !filter(p) {
+					continue planetsLoop
+				}
+			}
+			out = append(out, p)
+		}
+	}
+	return out, nil
+}
+
+// GalaxyScanner crawls the galaxy and keeps the result in a GalaxyStore
+type GalaxyScanner struct {
+	b        Wrapper
+	store    GalaxyStore
+	delay    time.Duration
+	onChange []func(GalaxyChange)
+}
+
+// NewGalaxyScanner ...
+func NewGalaxyScanner(b Wrapper, store GalaxyStore) *GalaxyScanner {
+	return &GalaxyScanner{b: b, store: store, delay: time.Second}
+}
+
+// SetDelay sets the minimum delay between two systems scans
+func (s *GalaxyScanner) SetDelay(delay time.Duration) *GalaxyScanner {
+	s.delay = delay
+	return s
+}
+
+// OnChange register a callback that is called for every change detected while scanning
+func (s *GalaxyScanner) OnChange(clb func(GalaxyChange)) *GalaxyScanner {
+	s.onChange = append(s.onChange, clb)
+	return s
+}
+
+// Store returns the store used by the scanner
+func (s *GalaxyScanner) Store() GalaxyStore {
+	return s.store
+}
+
+// ScanSystem scans a single system, saves it and returns the changes since the previous scan
+func (s *GalaxyScanner) ScanSystem(galaxy, system int64) ([]GalaxyChange, error) {
+	systemInfos, err := s.b.GalaxyInfos(galaxy, system)
+	if err != nil {
+		return nil, err
+	}
+	snapshot := NewGalaxySnapshot(systemInfos, s.b.ServerTime())
+	prev, found, err := s.store.GetSystem(galaxy, system)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.store.SaveSystem(snapshot); err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, nil
+	}
+	changes := DiffGalaxySnapshots(prev, snapshot)
+	for _, change := range changes {
+		for _, clb := range s.onChange {
+			clb(change)
+		}
+	}
+	return changes, nil
+}
+
+// flush writes the store if it buffers its saves
+func (s *GalaxyScanner) flush() error {
+	if f, ok := s.store.(interface{ Flush() error }); ok {
+		return f.Flush()
+	}
+	return nil
+}
+
+// Scan scans systems [fromSystem, toSystem] of a galaxy.
+// Closing stop aborts the scan with ErrGalaxyScanStopped, the systems already scanned are kept
+func (s *GalaxyScanner) Scan(galaxy, fromSystem, toSystem int64, stop <-chan struct{}) ([]GalaxyChange, error) {
+	if fromSystem > toSystem {
+		return nil, errors.New("invalid systems range")
+	}
+	out := make([]GalaxyChange, 0)
+	for system := fromSystem; system <= toSystem; system++ {
+		if system > fromSystem && !waitOrStop(s.delay, stop) {
+			_ = s.flush()
+			return out, ErrGalaxyScanStopped
+		}
+		changes, err := s.ScanSystem(galaxy, system)
+		if err != nil {
+			_ = s.flush()
+			return out, err
+		}
+		out = append(out, changes...)
+	}
+	return out, s.flush()
+}
+
+// ScanUniverse scans every systems of every galaxies, closing stop aborts the scan
+func (s *GalaxyScanner) ScanUniverse(stop <-chan struct{}) ([]GalaxyChange, error) {
+	out := make([]GalaxyChange, 0)
+	var galaxy int64
+	for galaxy = 1; galaxy <= s.b.GetServer().Settings.UniverseSize; galaxy++ {
+		if galaxy > 1 && !waitOrStop(s.delay, stop) {
+			return out, ErrGalaxyScanStopped
+		}
+		changes, err := s.Scan(galaxy, 1, s.b.GetNbSystems(), stop)
+		out = append(out, changes...)
+		if err != nil {
+			return out, err
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,110 @@
+package ogame
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestPlanetInfos(position, playerID int64) *PlanetInfos {
+	p := &PlanetInfos{Coordinate: Coordinate{Galaxy: 1, System: 2, Position: position, Type: PlanetType}}
+	p.Player.ID = playerID
+	return p
+}
+
+func TestNewGalaxySnapshot(t *testing.T) {
+	si := SystemInfos{galaxy: 1, system: 2}
+	si.planets[3] = newTestPlanetInfos(4, 123)
+	si.ExpeditionDebris.Metal = 1000
+	now := time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC)
+	snapshot := NewGalaxySnapshot(si, now)
+	assert.Equal(t, int64(1), snapshot.Galaxy)
+	assert.Equal(t, int64(2), snapshot.System)
+	assert.Equal(t, 1, len(snapshot.Planets))
+	assert.Equal(t, now, snapshot.Planets[0].Date)
+	assert.Equal(t, int64(1000), snapshot.ExpeditionDebris.Metal)
+	assert.Equal(t, int64(123), snapshot.Position(4).Player.ID)
+	assert.Nil(t, snapshot.Position(5))
+}
+
+func TestDiffGalaxySnapshots(t *testing.T) {
+	prev := GalaxySnapshot{Galaxy: 1, System: 2}
+	curr := GalaxySnapshot{Galaxy: 1, System: 2}
+	prev.Planets = []PlanetInfos{*newTestPlanetInfos(1, 10), *newTestPlanetInfos(2, 20), *newTestPlanetInfos(3, 30)}
+	withMoon := newTestPlanetInfos(3, 30)
+	withMoon.Moon = &MoonInfos{ID: 1}
+	destroyed := newTestPlanetInfos(2, 20)
+	destroyed.Destroyed = true
+	curr.Planets = []PlanetInfos{*destroyed, *withMoon, *newTestPlanetInfos(4, 40)}
+
+	changes := DiffGalaxySnapshots(prev, curr)
+	assert.Equal(t, 4, len(changes))
+	assert.Equal(t, AbandonedPlanetChange, changes[0].Type)
+	assert.Equal(t, int64(1), changes[0].Coordinate.Position)
+	assert.Nil(t, changes[0].Current)
+	assert.Equal(t, AbandonedPlanetChange, changes[1].Type)
+	assert.Equal(t, int64(2), changes[1].Coordinate.Position)
+	assert.Equal(t, MoonCreatedChange, changes[2].Type)
+	assert.Equal(t, Coordinate{1, 2, 3, MoonType}, changes[2].Coordinate)
+	assert.Equal(t, NewColonyChange, changes[3].Type)
+	assert.Equal(t, int64(4), changes[3].Coordinate.Position)
+
+	changes = DiffGalaxySnapshots(curr, prev)
+	assert.Equal(t, MoonDestroyedChange, changes[len(changes)-2].Type)
+}
+
+func TestFindPlanets(t *testing.T) {
+	store := NewMemoryGalaxyStore()
+	inactive := newTestPlanetInfos(1, 10)
+	inactive.Inactive = true
+	inactive.Player.Rank = 500
+	inactive.Debris.Metal = 5000
+	member := newTestPlanetInfos(2, 20)
+	member.Alliance = &AllianceInfos{ID: 7}
+	member.Moon = &MoonInfos{ID: 2}
+	member.Player.Rank = 10
+	_ = store.SaveSystem(GalaxySnapshot{Galaxy: 1, System: 2, Planets: []PlanetInfos{*inactive, *member}})
+
+	res, _ := FindPlanets(store, GalaxyInactive)
+	assert.Equal(t, 1, len(res))
+	assert.Equal(t, int64(10), res[0].Player.ID)
+	res, _ = FindPlanets(store, GalaxyByAlliance(7), GalaxyHasMoon)
+	assert.Equal(t, 1, len(res))
+	assert.Equal(t, int64(20), res[0].Player.ID)
+	res, _ = FindPlanets(store, GalaxyByRankRange(1, 100))
+	assert.Equal(t, 1, len(res))
+	res, _ = FindPlanets(store, GalaxyDebrisAbove(1000))
+	assert.Equal(t, 1, len(res))
+	res, _ = FindPlanets(store, GalaxyByPlayer(30))
+	assert.Equal(t, 0, len(res))
+	res, _ = FindPlanets(store)
+	assert.Equal(t, 2, len(res))
+}
+
+func TestFileGalaxyStore(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "galaxy")
+	defer os.RemoveAll(dir)
+	filename := filepath.Join(dir, "galaxy.json")
+	store, err := NewFileGalaxyStore(filename)
+	assert.NoError(t, err)
+	p := newTestPlanetInfos(1, 10)
+	p.Moon = &MoonInfos{ID: 3}
+	_ = store.SaveSystem(GalaxySnapshot{Galaxy: 1, System: 2, Planets: []PlanetInfos{*p}})
+	_ = store.SaveSystem(GalaxySnapshot{Galaxy: 1, System: 1})
+	_, err = os.Stat(filename)
+	assert.True(t, os.IsNotExist(err))
+	assert.NoError(t, store.Flush())
+
+	store2, err := NewFileGalaxyStore(filename)
+	assert.NoError(t, err)
+	snapshots, _ := store2.GetSystems()
+	assert.Equal(t, 2, len(snapshots))
+	assert.Equal(t, int64(1), snapshots[0].System)
+	snapshot, found, _ := store2.GetSystem(1, 2)
+	assert.True(t, found)
+	assert.Equal(t, int64(3), snapshot.Planets[0].Moon.ID)
+}
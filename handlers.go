@@ -3,6 +3,7 @@ package ogame
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -1084,6 +1085,24 @@ func GetEmpireHandler(c echo.Context) error {
 	return c.JSON(http.StatusOK, SuccessResp(getEmpire))
 }
 
+// GetEmpireCelestialsHandler ...
+func GetEmpireCelestialsHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	celestialTypeInt, err := strconv.ParseInt(c.Param("type"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, err.Error()))
+	}
+	celestialType := CelestialType(celestialTypeInt)
+	if celestialType != PlanetType && celestialType != MoonType { // only accept planet/moon types
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid type"))
+	}
+	celestials, err := bot.GetEmpire(celestialType)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResp(500, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(celestials))
+}
+
 // DeleteMessageHandler ...
 func DeleteMessageHandler(c echo.Context) error {
 	bot := c.Get("bot").(*OGame)
@@ -1449,3 +1468,1035 @@ func GetCaptchaSolverHandler(c echo.Context) error {
 	}
 	return c.Redirect(http.StatusTemporaryRedirect, "/")
 }
+
+func buildQueueParams(c echo.Context) (*BuildQueue, CelestialID, error) {
+	buildQueue, ok := c.Get("buildQueue").(*BuildQueue)
+	if !ok {
+		return nil, 0, errors.New("build queue not enabled")
+	}
+	celestialID, err := strconv.ParseInt(c.Param("celestialID"), 10, 64)
+	if err != nil {
+		return nil, 0, errors.New("invalid celestial id")
+	}
+	return buildQueue, CelestialID(celestialID), nil
+}
+
+// GetBuildQueuesHandler ...
+func GetBuildQueuesHandler(c echo.Context) error {
+	buildQueue, ok := c.Get("buildQueue").(*BuildQueue)
+	if !ok {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "build queue not enabled"))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(buildQueue.GetAll()))
+}
+
+// GetBuildQueueHandler ...
+func GetBuildQueueHandler(c echo.Context) error {
+	buildQueue, celestialID, err := buildQueueParams(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(buildQueue.Get(celestialID)))
+}
+
+// GetBuildQueueEstimateHandler ...
+func GetBuildQueueEstimateHandler(c echo.Context) error {
+	buildQueue, celestialID, err := buildQueueParams(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, err.Error()))
+	}
+	estimates, err := buildQueue.Estimate(celestialID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResp(500, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(estimates))
+}
+
+// AddBuildQueueItemHandler ...
+func AddBuildQueueItemHandler(c echo.Context) error {
+	buildQueue, celestialID, err := buildQueueParams(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, err.Error()))
+	}
+	var body struct {
+		OgameID int64 `json:"ogameID"`
+		Nbr     int64 `json:"nbr"`
+	}
+	if err := bindJSONBody(c, &body); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid body"))
+	}
+	item, err := buildQueue.Add(celestialID, ID(body.OgameID), body.Nbr)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(item))
+}
+
+// UpdateBuildQueueItemHandler updates the nbr of an item and/or moves it to a new index
+func UpdateBuildQueueItemHandler(c echo.Context) error {
+	buildQueue, celestialID, err := buildQueueParams(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, err.Error()))
+	}
+	itemID, err := strconv.ParseInt(c.Param("itemID"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid item id"))
+	}
+	var body struct {
+		Nbr   *int64 `json:"nbr"`
+		Index *int64 `json:"index"`
+	}
+	if err := bindJSONBody(c, &body); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid body"))
+	}
+	if body.Nbr != nil {
+		if _, err := buildQueue.Update(celestialID, itemID, *body.Nbr); err != nil {
+			return c.JSON(http.StatusBadRequest, ErrorResp(400, err.Error()))
+		}
+	}
+	if body.Index != nil {
+		if err := buildQueue.Move(celestialID, itemID, int(*body.Index)); err != nil {
+			return c.JSON(http.StatusBadRequest, ErrorResp(400, err.Error()))
+		}
+	}
+	return c.JSON(http.StatusOK, SuccessResp(buildQueue.Get(celestialID)))
+}
+
+// DeleteBuildQueueItemHandler ...
+func DeleteBuildQueueItemHandler(c echo.Context) error {
+	buildQueue, celestialID, err := buildQueueParams(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, err.Error()))
+	}
+	itemID, err := strconv.ParseInt(c.Param("itemID"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid item id"))
+	}
+	if err := buildQueue.Remove(celestialID, itemID); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(nil))
+}
+
+// ClearBuildQueueHandler ...
+func ClearBuildQueueHandler(c echo.Context) error {
+	buildQueue, celestialID, err := buildQueueParams(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, err.Error()))
+	}
+	if err := buildQueue.Clear(celestialID); err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResp(500, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(nil))
+}
+
+// marketplaceItemParam reads the itemType, itemID and itemRef query params of a marketplace request
+func marketplaceItemParam(value func(string) string) MarketplaceItem {
+	itemType, _ := strconv.ParseInt(value("itemType"), 10, 64)
+	itemID, _ := strconv.ParseInt(value("itemID"), 10, 64)
+	return MarketplaceItem{Type: MarketplaceItemType(itemType), ID: ID(itemID), Ref: value("itemRef")}
+}
+
+// GetMarketplaceOffersHandler ...
+func GetMarketplaceOffersHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	item := marketplaceItemParam(c.QueryParam)
+	filter := MarketplaceFilter{Tab: MarketplaceTab(c.QueryParam("tab")), ItemType: item.Type, ItemID: item.ID, ItemRef: item.Ref}
+	priceType, _ := strconv.ParseInt(c.QueryParam("priceType"), 10, 64)
+	filter.PriceType = MarketplaceResource(priceType)
+	var err error
+	if v := c.QueryParam("minPricePerUnit"); v != "" {
+		if filter.MinPricePerUnit, err = strconv.ParseFloat(v, 64); err != nil {
+			return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid minPricePerUnit"))
+		}
+	}
+	if v := c.QueryParam("maxPricePerUnit"); v != "" {
+		if filter.MaxPricePerUnit, err = strconv.ParseFloat(v, 64); err != nil {
+			return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid maxPricePerUnit"))
+		}
+	}
+	offers, err := bot.GetMarketplaceOffers(filter)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResp(500, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(offers))
+}
+
+// CreateMarketplaceOfferHandler body: {"type": "buy" or "sell", "itemType": 1, "itemID": 202, "itemRef": "", "quantity": 10,
+// "priceType": 1, "price": 1000, "priceRange": 0, "celestialID": 123}
+func CreateMarketplaceOfferHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	var body struct {
+		Type        string `json:"type"`
+		ItemType    int64  `json:"itemType"`
+		ItemID      int64  `json:"itemID"`
+		ItemRef     string `json:"itemRef"`
+		Quantity    int64  `json:"quantity"`
+		PriceType   int64  `json:"priceType"`
+		Price       int64  `json:"price"`
+		PriceRange  int64  `json:"priceRange"`
+		CelestialID int64  `json:"celestialID"`
+	}
+	if err := bindJSONBody(c, &body); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid body"))
+	}
+	offer := MarketplaceOfferRequest{
+		Item:        MarketplaceItem{Type: MarketplaceItemType(body.ItemType), ID: ID(body.ItemID), Ref: body.ItemRef},
+		Quantity:    body.Quantity,
+		PriceType:   MarketplaceResource(body.PriceType),
+		Price:       body.Price,
+		PriceRange:  body.PriceRange,
+		CelestialID: CelestialID(body.CelestialID),
+	}
+	switch body.Type {
+	case "buy":
+		offer.Type = MarketplaceBuyOffer
+	case "sell":
+		offer.Type = MarketplaceSellOffer
+	default:
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid type"))
+	}
+	if err := offer.Validate(); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, err.Error()))
+	}
+	if err := bot.CreateMarketplaceOffer(offer); err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResp(500, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(nil))
+}
+
+// BuyMarketplaceHandler ...
+func BuyMarketplaceHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	offerID, err := strconv.ParseInt(c.Param("offerID"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid offer id"))
+	}
+	var body struct {
+		CelestialID int64 `json:"celestialID"`
+	}
+	if err := bindJSONBody(c, &body); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid body"))
+	}
+	if err := bot.BuyMarketplace(offerID, CelestialID(body.CelestialID)); err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResp(500, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(nil))
+}
+
+// SellMarketplaceHandler ...
+func SellMarketplaceHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	offerID, err := strconv.ParseInt(c.Param("offerID"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid offer id"))
+	}
+	var body struct {
+		CelestialID int64 `json:"celestialID"`
+	}
+	if err := bindJSONBody(c, &body); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid body"))
+	}
+	if err := bot.SellMarketplace(offerID, CelestialID(body.CelestialID)); err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResp(500, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(nil))
+}
+
+// GetMyMarketplaceOffersHandler ...
+func GetMyMarketplaceOffersHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	offers, err := bot.GetMyMarketplaceOffers()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResp(500, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(offers))
+}
+
+// CancelMarketplaceOfferHandler ...
+func CancelMarketplaceOfferHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	offerID, err := strconv.ParseInt(c.Param("offerID"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid offer id"))
+	}
+	if err := bot.CancelMarketplaceOffer(offerID); err != nil {
+		if err == ErrMarketplaceOfferNotFound {
+			return c.JSON(http.StatusNotFound, ErrorResp(404, err.Error()))
+		}
+		return c.JSON(http.StatusInternalServerError, ErrorResp(500, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(nil))
+}
+
+// GetChatConversationsHandler ...
+func GetChatConversationsHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	conversations, err := bot.GetChatConversations()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResp(500, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(conversations))
+}
+
+// chatHistoryHandler returns the history of the conversation identified by the idParam route parameter
+func chatHistoryHandler(c echo.Context, idParam string, clb func(id, lastMessageID int64) ([]ChatMsg, error)) error {
+	id, err := strconv.ParseInt(c.Param(idParam), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid "+idParam))
+	}
+	var lastMessageID int64
+	if v := c.QueryParam("lastMessageID"); v != "" {
+		if lastMessageID, err = strconv.ParseInt(v, 10, 64); err != nil {
+			return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid lastMessageID"))
+		}
+	}
+	msgs, err := clb(id, lastMessageID)
+	if err != nil {
+		if err.Error() == "invalid parameters" {
+			return c.JSON(http.StatusBadRequest, ErrorResp(400, err.Error()))
+		}
+		return c.JSON(http.StatusInternalServerError, ErrorResp(500, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(msgs))
+}
+
+// GetChatHistoryHandler ...
+func GetChatHistoryHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	return chatHistoryHandler(c, "playerID", bot.GetChatHistory)
+}
+
+// GetAllianceChatHistoryHandler ...
+func GetAllianceChatHistoryHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	return chatHistoryHandler(c, "associationID", bot.GetAllianceChatHistory)
+}
+
+// MarkChatAsReadHandler ...
+func MarkChatAsReadHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	playerID, err := strconv.ParseInt(c.Param("playerID"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid playerID"))
+	}
+	if err := bot.MarkChatAsRead(playerID); err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResp(500, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(nil))
+}
+
+// MarkAllianceChatAsReadHandler ...
+func MarkAllianceChatAsReadHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	associationID, err := strconv.ParseInt(c.Param("associationID"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid associationID"))
+	}
+	if err := bot.MarkAllianceChatAsRead(associationID); err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResp(500, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(nil))
+}
+
+// GetBuddiesHandler ...
+func GetBuddiesHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	buddies, err := bot.GetBuddies()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResp(500, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(buddies))
+}
+
+// AddBuddyHandler ...
+func AddBuddyHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	var body struct {
+		PlayerID int64  `json:"playerID"`
+		Message  string `json:"message"`
+	}
+	if err := bindJSONBody(c, &body); err != nil || body.PlayerID <= 0 {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid playerID"))
+	}
+	if err := bot.AddBuddy(body.PlayerID, body.Message); err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResp(500, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(nil))
+}
+
+// RemoveBuddyHandler ...
+func RemoveBuddyHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	buddyID, err := strconv.ParseInt(c.Param("buddyID"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid buddyID"))
+	}
+	if err := bot.RemoveBuddy(buddyID); err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResp(500, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(nil))
+}
+
+// GetIgnoredPlayersHandler ...
+func GetIgnoredPlayersHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	players, err := bot.GetIgnoredPlayers()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResp(500, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(players))
+}
+
+// IgnorePlayerHandler ...
+func IgnorePlayerHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	var body struct {
+		PlayerID int64 `json:"playerID"`
+	}
+	if err := bindJSONBody(c, &body); err != nil || body.PlayerID <= 0 {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid playerID"))
+	}
+	if err := bot.IgnorePlayer(body.PlayerID); err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResp(500, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(nil))
+}
+
+// UnignorePlayerHandler ...
+func UnignorePlayerHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	playerID, err := strconv.ParseInt(c.Param("playerID"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid playerID"))
+	}
+	if err := bot.UnignorePlayer(playerID); err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResp(500, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(nil))
+}
+
+// GetMessagesHandler returns the messages of a tab, a single page if the page query parameter is set
+func GetMessagesHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	tabID, err := strconv.ParseInt(c.Param("tabID"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid tab id"))
+	}
+	if pageStr := c.QueryParam("page"); pageStr != "" {
+		page, err := strconv.ParseInt(pageStr, 10, 64)
+		if err != nil || page < 1 {
+			return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid page"))
+		}
+		msgs, nbPage, err := bot.GetMessages(MessagesTab(tabID), page)
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, ErrorResp(500, err.Error()))
+		}
+		return c.JSON(http.StatusOK, SuccessResp(struct {
+			Messages []Message
+			NbPage   int64
+		}{msgs, nbPage}))
+	}
+	msgs, err := bot.GetAllMessages(MessagesTab(tabID))
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResp(500, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(msgs))
+}
+
+// FavoriteMessageHandler ...
+func FavoriteMessageHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	messageID, err := strconv.ParseInt(c.Param("messageID"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid message id"))
+	}
+	if err := bot.FavoriteMessage(messageID); err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResp(500, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(nil))
+}
+
+// UnfavoriteMessageHandler ...
+func UnfavoriteMessageHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	messageID, err := strconv.ParseInt(c.Param("messageID"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid message id"))
+	}
+	if err := bot.UnfavoriteMessage(messageID); err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResp(500, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(nil))
+}
+
+// GetAllianceOverviewHandler ...
+func GetAllianceOverviewHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	overview, err := bot.GetAllianceOverview()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResp(500, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(overview))
+}
+
+// GetAllianceRanksHandler ...
+func GetAllianceRanksHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	ranks, err := bot.GetAllianceRanks()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResp(500, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(ranks))
+}
+
+// GetAllianceApplicationsHandler ...
+func GetAllianceApplicationsHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	applications, err := bot.GetAllianceApplications()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResp(500, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(applications))
+}
+
+// AcceptAllianceApplicationHandler ...
+func AcceptAllianceApplicationHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	applicationID, err := strconv.ParseInt(c.Param("applicationID"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid application id"))
+	}
+	if err := bot.AcceptAllianceApplication(applicationID); err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResp(500, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(nil))
+}
+
+// DenyAllianceApplicationHandler ...
+func DenyAllianceApplicationHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	applicationID, err := strconv.ParseInt(c.Param("applicationID"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid application id"))
+	}
+	var body struct {
+		Reason string `json:"reason"`
+	}
+	if err := bindJSONBody(c, &body); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid body"))
+	}
+	if err := bot.DenyAllianceApplication(applicationID, body.Reason); err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResp(500, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(nil))
+}
+
+// SendAllianceCircularHandler sends a circular message to the rankIDs ranks, to all members if no rankIDs are given
+// body: {"message": "hello", "rankIDs": [1, 2]}
+func SendAllianceCircularHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	var body struct {
+		Message string  `json:"message"`
+		RankIDs []int64 `json:"rankIDs"`
+	}
+	if err := bindJSONBody(c, &body); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid body"))
+	}
+	if body.Message == "" {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "empty message"))
+	}
+	if body.RankIDs == nil {
+		body.RankIDs = make([]int64, 0)
+	}
+	if err := bot.SendAllianceCircular(body.Message, body.RankIDs); err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResp(500, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(nil))
+}
+
+// SetAllianceClassHandler ...
+func SetAllianceClassHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	var body struct {
+		Class int64 `json:"class"`
+	}
+	if err := bindJSONBody(c, &body); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid class"))
+	}
+	if err := bot.SetAllianceClass(AllianceClass(body.Class)); err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResp(500, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(nil))
+}
+
+// GetAlliancePublicInfoHandler ...
+func GetAlliancePublicInfoHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	allianceID, err := strconv.ParseInt(c.Param("allianceID"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid alliance id"))
+	}
+	info, err := bot.GetAlliancePublicInfo(allianceID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResp(500, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(info))
+}
+
+// bindJSONBody decodes the json body of the request into v, an empty body is accepted
+func bindJSONBody(c echo.Context, v interface{}) error {
+	if err := json.NewDecoder(c.Request().Body).Decode(v); err != nil && err != io.EOF {
+		return err
+	}
+	return nil
+}
+
+// HighscoreHandler ...
+func HighscoreHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	category, err := strconv.ParseInt(c.Param("category"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid category"))
+	}
+	typ, err := strconv.ParseInt(c.Param("type"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid type"))
+	}
+	page, err := strconv.ParseInt(c.Param("page"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid page"))
+	}
+	highscore, err := bot.Highscore(category, typ, page)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(highscore))
+}
+
+// GetCelestialsHandler ...
+func GetCelestialsHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	celestials, err := bot.GetCelestials()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResp(500, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(celestials))
+}
+
+// GetCelestialHandler ...
+func GetCelestialHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	celestialID, err := strconv.ParseInt(c.Param("celestialID"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid celestial id"))
+	}
+	celestial, err := bot.GetCelestial(CelestialID(celestialID))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(celestial))
+}
+
+// GetAllResourcesHandler ...
+func GetAllResourcesHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	allResources, err := bot.GetAllResources()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResp(500, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(allResources))
+}
+
+// GetActiveItemsHandler ...
+func GetActiveItemsHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	celestialID, err := strconv.ParseInt(c.Param("celestialID"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid celestial id"))
+	}
+	items, err := bot.GetActiveItems(CelestialID(celestialID))
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResp(500, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(items))
+}
+
+// GetDMCostsHandler ...
+func GetDMCostsHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	celestialID, err := strconv.ParseInt(c.Param("celestialID"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid celestial id"))
+	}
+	costs, err := bot.GetDMCosts(CelestialID(celestialID))
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResp(500, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(costs))
+}
+
+// UseDMHandler body: {"type": "buildings|research|shipyard"}
+func UseDMHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	celestialID, err := strconv.ParseInt(c.Param("celestialID"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid celestial id"))
+	}
+	var body struct {
+		Type string `json:"type"`
+	}
+	if err := bindJSONBody(c, &body); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid body"))
+	}
+	if err := bot.UseDM(body.Type, CelestialID(celestialID)); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(nil))
+}
+
+// AbandonHandler ...
+func AbandonHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	planetID, err := strconv.ParseInt(c.Param("planetID"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid planet id"))
+	}
+	if err := bot.Abandon(PlanetID(planetID)); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(nil))
+}
+
+// GetResourcesProductionsHandler ...
+func GetResourcesProductionsHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	planetID, err := strconv.ParseInt(c.Param("planetID"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid planet id"))
+	}
+	productions, err := bot.GetResourcesProductions(PlanetID(planetID))
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResp(500, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(productions))
+}
+
+// DestroyRocketsHandler body: {"abm": 10, "ipm": 2}
+func DestroyRocketsHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	planetID, err := strconv.ParseInt(c.Param("planetID"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid planet id"))
+	}
+	var body struct {
+		ABM int64 `json:"abm"`
+		IPM int64 `json:"ipm"`
+	}
+	if err := bindJSONBody(c, &body); err != nil || body.ABM < 0 || body.IPM < 0 {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid body"))
+	}
+	if err := bot.DestroyRockets(PlanetID(planetID), body.ABM, body.IPM); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(nil))
+}
+
+// JumpGateDestinationsHandler ...
+func JumpGateDestinationsHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	moonID, err := strconv.ParseInt(c.Param("moonID"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid moon id"))
+	}
+	destinations, countdown, err := bot.JumpGateDestinations(MoonID(moonID))
+	if err != nil && countdown == 0 {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(map[string]interface{}{
+		"Destinations": destinations,
+		"Countdown":    countdown,
+	}))
+}
+
+// RecruitOfficerHandler body: {"type": 2, "days": 7}
+func RecruitOfficerHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	var body struct {
+		Type int64 `json:"type"`
+		Days int64 `json:"days"`
+	}
+	if err := bindJSONBody(c, &body); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid body"))
+	}
+	if err := bot.RecruitOfficer(body.Type, body.Days); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(nil))
+}
+
+// SendMessageAllianceHandler body: {"associationID": 123, "message": "..."}
+func SendMessageAllianceHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	var body struct {
+		AssociationID int64  `json:"associationID"`
+		Message       string `json:"message"`
+	}
+	if err := bindJSONBody(c, &body); err != nil || body.AssociationID == 0 || body.Message == "" {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid body"))
+	}
+	if err := bot.SendMessageAlliance(body.AssociationID, body.Message); err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResp(500, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(nil))
+}
+
+// GetExpeditionMessagesHandler ...
+func GetExpeditionMessagesHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	msgs, err := bot.GetExpeditionMessages()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResp(500, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(msgs))
+}
+
+// GetExpeditionMessageAtHandler ...
+func GetExpeditionMessageAtHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	timestamp, err := strconv.ParseInt(c.Param("timestamp"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid timestamp"))
+	}
+	msg, err := bot.GetExpeditionMessageAt(time.Unix(timestamp, 0))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(msg))
+}
+
+// GetCombatReportMessagesHandler ...
+func GetCombatReportMessagesHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	msgs, err := bot.GetCombatReportMessages()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResp(500, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(msgs))
+}
+
+// GetCombatReportSummaryForHandler ...
+func GetCombatReportSummaryForHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	galaxy, err := strconv.ParseInt(c.Param("galaxy"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid galaxy"))
+	}
+	system, err := strconv.ParseInt(c.Param("system"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid system"))
+	}
+	position, err := strconv.ParseInt(c.Param("position"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid position"))
+	}
+	summary, err := bot.GetCombatReportSummaryFor(Coordinate{Type: PlanetType, Galaxy: galaxy, System: system, Position: position})
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(summary))
+}
+
+// GetFleetsFromEventListHandler ...
+func GetFleetsFromEventListHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	return c.JSON(http.StatusOK, SuccessResp(bot.GetFleetsFromEventList()))
+}
+
+// fleetRequest json body of the fleet endpoints
+type fleetRequest struct {
+	Ships       []Quantifiable
+	Speed       Speed
+	Where       Coordinate
+	Mission     MissionID
+	Resources   Resources
+	HoldingTime int64
+	UnionID     int64
+}
+
+func bindFleetRequest(c echo.Context) (fleetRequest, error) {
+	req := fleetRequest{Speed: HundredPercent, Mission: Transport}
+	if err := bindJSONBody(c, &req); err != nil {
+		return req, errors.New("invalid body")
+	}
+	if req.Where.Type == 0 {
+		req.Where.Type = PlanetType
+	}
+	if req.Speed < FivePercent || req.Speed > HundredPercent {
+		return req, errors.New("invalid speed")
+	}
+	for _, ship := range req.Ships {
+		if !ship.ID.IsShip() || ship.Nbr < 0 {
+			return req, errors.New("invalid ship " + ship.ID.String())
+		}
+	}
+	return req, nil
+}
+
+// EnsureFleetHandler body: {"ships": [{"ID": 202, "Nbr": 10}], "speed": 10, "where": {"galaxy": 1, "system": 2, "position": 3, "type": 1},
+// "mission": 3, "resources": {"metal": 1000}, "holdingTime": 0, "unionID": 0}
+func EnsureFleetHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	celestialID, err := strconv.ParseInt(c.Param("celestialID"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid celestial id"))
+	}
+	req, err := bindFleetRequest(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, err.Error()))
+	}
+	fleet, err := bot.EnsureFleet(CelestialID(celestialID), req.Ships, req.Speed, req.Where, req.Mission, req.Resources, req.HoldingTime, req.UnionID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResp(500, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(fleet))
+}
+
+// FlightTimeHandler body: {"origin": {"galaxy": 1, "system": 2, "position": 3}, "where": {...}, "speed": 10, "ships": [...], "mission": 3}
+func FlightTimeHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	var body struct {
+		Origin Coordinate
+		fleetRequest
+	}
+	body.fleetRequest = fleetRequest{Speed: HundredPercent, Mission: Transport}
+	if err := bindJSONBody(c, &body); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid body"))
+	}
+	if body.Speed < FivePercent || body.Speed > HundredPercent {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid speed"))
+	}
+	secs, fuel := bot.FlightTime(body.Origin, body.Where, body.Speed, ShipsInfos{}.FromQuantifiables(body.Ships), body.Mission)
+	return c.JSON(http.StatusOK, SuccessResp(map[string]int64{"Secs": secs, "Fuel": fuel}))
+}
+
+// CreateUnionHandler body: {"users": ["player1", "player2"]}
+func CreateUnionHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	fleetID, err := strconv.ParseInt(c.Param("fleetID"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid fleet id"))
+	}
+	var body struct {
+		Users []string `json:"users"`
+	}
+	if err := bindJSONBody(c, &body); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid body"))
+	}
+	fleets, _ := bot.GetFleets()
+	for _, fleet := range fleets {
+		if fleet.ID == FleetID(fleetID) {
+			unionID, err := bot.CreateUnion(fleet, body.Users)
+			if err != nil {
+				return c.JSON(http.StatusInternalServerError, ErrorResp(500, err.Error()))
+			}
+			return c.JSON(http.StatusOK, SuccessResp(unionID))
+		}
+	}
+	return c.JSON(http.StatusBadRequest, ErrorResp(400, "fleet not found"))
+}
+
+// GetMarketplaceMessagesHandler ...
+func GetMarketplaceMessagesHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	msgs, err := bot.GetMarketplaceMessages()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResp(500, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(msgs))
+}
+
+// CollectAllMarketplaceMessagesHandler ...
+func CollectAllMarketplaceMessagesHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	if err := bot.CollectAllMarketplaceMessages(); err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResp(500, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(nil))
+}
+
+// CollectMarketplaceMessageHandler ...
+func CollectMarketplaceMessageHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	messageID, err := strconv.ParseInt(c.Param("messageID"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid message id"))
+	}
+	msgs, err := bot.GetMarketplaceMessages()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResp(500, err.Error()))
+	}
+	for _, msg := range msgs {
+		if msg.ID == messageID {
+			if err := bot.CollectMarketplaceMessage(msg); err != nil {
+				return c.JSON(http.StatusInternalServerError, ErrorResp(500, err.Error()))
+			}
+			return c.JSON(http.StatusOK, SuccessResp(nil))
+		}
+	}
+	return c.JSON(http.StatusBadRequest, ErrorResp(400, "message not found"))
+}
+
+// GetResourceMerchantHandler ...
+func GetResourceMerchantHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	celestialID, err := strconv.ParseInt(c.Param("celestialID"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid celestial id"))
+	}
+	merchant, err := bot.GetResourceMerchant(CelestialID(celestialID))
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResp(500, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(merchant))
+}
+
+// CallResourceMerchantHandler body: {"resource": "metal|crystal|deuterium"}
+func CallResourceMerchantHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	celestialID, err := strconv.ParseInt(c.Param("celestialID"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid celestial id"))
+	}
+	var body struct {
+		Resource MerchantResource `json:"resource"`
+	}
+	if err := bindJSONBody(c, &body); err != nil || !body.Resource.IsValid() {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid resource"))
+	}
+	merchant, err := bot.CallResourceMerchant(CelestialID(celestialID), body.Resource)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResp(500, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(merchant))
+}
+
+// TradeResourceMerchantHandler body: {"amount": 100000}
+func TradeResourceMerchantHandler(c echo.Context) error {
+	bot := c.Get("bot").(*OGame)
+	celestialID, err := strconv.ParseInt(c.Param("celestialID"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid celestial id"))
+	}
+	var body struct {
+		Amount int64 `json:"amount"`
+	}
+	if err := bindJSONBody(c, &body); err != nil || body.Amount <= 0 {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, "invalid amount"))
+	}
+	payment, err := bot.TradeResourceMerchant(CelestialID(celestialID), body.Amount)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResp(400, err.Error()))
+	}
+	return c.JSON(http.StatusOK, SuccessResp(payment))
+}
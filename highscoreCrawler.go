@@ -0,0 +1,304 @@
+package ogame
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Highscore categories
+const (
+	PlayerHighscore   int64 = 1
+	AllianceHighscore int64 = 2
+)
+
+// Highscore types
+const (
+	TotalHighscore int64 = iota
+	EconomyHighscore
+	ResearchHighscore
+	MilitaryHighscore
+	MilitaryBuiltHighscore
+	MilitaryDestroyedHighscore
+	MilitaryLostHighscore
+	HonorHighscore
+)
+
+// HighscoreSnapshot every pages of a highscore ranking at the time it was crawled
+type HighscoreSnapshot struct {
+	Category  int64
+	Type      int64
+	CrawledAt time.Time
+	Players   []HighscorePlayer
+}
+
+// Player returns the entry of playerID in the snapshot
+func (s HighscoreSnapshot) Player(playerID int64) *HighscorePlayer {
+	for i := range s.Players {
+		if s.Players[i].ID == playerID {
+			return &s.Players[i]
+		}
+	}
+	return nil
+}
+
+// HighscoreStore persists the snapshots taken by the HighscoreCrawler
+type HighscoreStore interface {
+	SaveSnapshot(HighscoreSnapshot) error
+	GetSnapshots(category, typ int64) ([]HighscoreSnapshot, error)
+}
+
+type highscoreKey struct {
+	category int64
+	typ      int64
+}
+
+// MemoryHighscoreStore keeps the snapshots history of every rankings in memory
+type MemoryHighscoreStore struct {
+	sync.RWMutex
+	snapshots map[highscoreKey][]HighscoreSnapshot
+	retention time.Duration
+}
+
+// NewMemoryHighscoreStore ...
+func NewMemoryHighscoreStore() *MemoryHighscoreStore {
+	return &MemoryHighscoreStore{snapshots: make(map[highscoreKey][]HighscoreSnapshot), retention: 7 * 24 * time.Hour}
+}
+
+// SetRetention sets for how long snapshots are kept, older ones are dropped when a new snapshot is saved
+func (s *MemoryHighscoreStore) SetRetention(retention time.Duration) *MemoryHighscoreStore {
+	s.retention = retention
+	return s
+}
+
+// SaveSnapshot ...
+func (s *MemoryHighscoreStore) SaveSnapshot(snapshot HighscoreSnapshot) error {
+	s.Lock()
+	defer s.Unlock()
+	key := highscoreKey{snapshot.Category, snapshot.Type}
+	snapshots := append(s.snapshots[key], snapshot)
+	sort.Slice(snapshots, func(i, j int) bool { return snapshots[i].CrawledAt.Before(snapshots[j].CrawledAt) })
+	limit := snapshots[len(snapshots)-1].CrawledAt.Add(-s.retention)
+	for len(snapshots) > 1 && snapshots[0].CrawledAt.Before(limit) {
+		snapshots = snapshots[1:]
+	}
+	s.snapshots[key] = snapshots
+	return nil
+}
+
+// GetSnapshots returns the snapshots of a ranking, oldest first
+func (s *MemoryHighscoreStore) GetSnapshots(category, typ int64) ([]HighscoreSnapshot, error) {
+	s.RLock()
+	defer s.RUnlock()
+	snapshots := s.snapshots[highscoreKey{category, typ}]
+	out := make([]HighscoreSnapshot, len(snapshots))
+	copy(out, snapshots)
+	return out, nil
+}
+
+func (s *MemoryHighscoreStore) allSnapshots() []HighscoreSnapshot {
+	s.RLock()
+	defer s.RUnlock()
+	out := make([]HighscoreSnapshot, 0)
+	for _, snapshots := range s.snapshots {
+		out = append(out, snapshots...)
+	}
+	return out
+}
+
+// FileHighscoreStore memory store that is written as json in a file every batchSize saves and on Flush
+type FileHighscoreStore struct {
+	*MemoryHighscoreStore
+	file *jsonFileStore
+}
+
+// NewFileHighscoreStore creates a store backed by filename, loading existing snapshots if the file exists
+func NewFileHighscoreStore(filename string) (*FileHighscoreStore, error) {
+	s := &FileHighscoreStore{MemoryHighscoreStore: NewMemoryHighscoreStore()}
+	s.file = newJSONFileStore(filename, 16, func() interface{} { return s.MemoryHighscoreStore.allSnapshots() })
+	var snapshots []HighscoreSnapshot
+	if err := s.file.load(&snapshots); err != nil {
+		return nil, err
+	}
+	for _, snapshot := range snapshots {
+		_ = s.MemoryHighscoreStore.SaveSnapshot(snapshot)
+	}
+	return s, nil
+}
+
+// SetBatchSize sets after how many saved snapshots the file is written, a full crawl saves 16 snapshots
+func (s *FileHighscoreStore) SetBatchSize(batchSize int64) *FileHighscoreStore {
+	s.file.batchSize = batchSize
+	return s
+}
+
+// SaveSnapshot ...
+func (s *FileHighscoreStore) SaveSnapshot(snapshot HighscoreSnapshot) error {
+	return s.file.save(func() { _ = s.MemoryHighscoreStore.SaveSnapshot(snapshot) })
+}
+
+// Flush writes the snapshots saved since the last write
+func (s *FileHighscoreStore) Flush() error {
+	return s.file.flush()
+}
+
+// HighscoreDelta score change of a player between two snapshots of a ranking
+type HighscoreDelta struct {
+	Category  int64
+	Type      int64
+	PlayerID  int64
+	Name      string
+	Homeworld Coordinate
+	From      time.Time
+	To        time.Time
+	Before    int64
+	After     int64
+}
+
+// Diff returns the score difference, positive when the score increased
+func (d HighscoreDelta) Diff() int64 {
+	return d.After - d.Before
+}
+
+// DiffHighscoreSnapshots returns the score changes of the players present in both snapshots of the same ranking
+func DiffHighscoreSnapshots(prev, curr HighscoreSnapshot) []HighscoreDelta {
+	before := make(map[int64]int64, len(prev.Players))
+	for _, p := range prev.Players {
+		before[p.ID] = p.Score
+	}
+	out := make([]HighscoreDelta, 0)
+	for _, p := range curr.Players {
+		score, found := before[p.ID]
+		if p.ID == 0 || !found || score == p.Score {
+			continue
+		}
+		out = append(out, HighscoreDelta{Category: curr.Category, Type: curr.Type, PlayerID: p.ID, Name: p.Name,
+			Homeworld: p.Homeworld, From: prev.CrawledAt, To: curr.CrawledAt, Before: score, After: p.Score})
+	}
+	return out
+}
+
+// HighscoreDeltas returns the score changes of a ranking between the last snapshot taken before since
+// (or the oldest one) and the most recent one
+func HighscoreDeltas(store HighscoreStore, category, typ int64, since time.Time) ([]HighscoreDelta, error) {
+	snapshots, err := store.GetSnapshots(category, typ)
+	if err != nil {
+		return nil, err
+	}
+	if len(snapshots) < 2 {
+		return []HighscoreDelta{}, nil
+	}
+	prev := snapshots[0]
+	for _, snapshot := range snapshots[:len(snapshots)-1] {
+		if snapshot.CrawledAt.After(since) {
+			break
+		}
+		prev = snapshot
+	}
+	return DiffHighscoreSnapshots(prev, snapshots[len(snapshots)-1]), nil
+}
+
+// HighscoreCrawler crawls every pages of the highscore rankings and keeps the history in a HighscoreStore
+type HighscoreCrawler struct {
+	b     Wrapper
+	store HighscoreStore
+	delay time.Duration
+}
+
+// NewHighscoreCrawler ...
+func NewHighscoreCrawler(b Wrapper, store HighscoreStore) *HighscoreCrawler {
+	return &HighscoreCrawler{b: b, store: store, delay: time.Second}
+}
+
+// SetDelay sets the minimum delay between two highscore pages
+func (c *HighscoreCrawler) SetDelay(delay time.Duration) *HighscoreCrawler {
+	c.delay = delay
+	return c
+}
+
+// Store returns the store used by the crawler
+func (c *HighscoreCrawler) Store() HighscoreStore {
+	return c.store
+}
+
+// flush writes the store if it buffers its saves
+func (c *HighscoreCrawler) flush() error {
+	if f, ok := c.store.(interface{ Flush() error }); ok {
+		return f.Flush()
+	}
+	return nil
+}
+
+// CrawlRanking fetches every pages of a ranking and saves the snapshot.
+// Closing stop aborts the crawl with ErrHighscoreCrawlStopped, nothing is saved
+func (c *HighscoreCrawler) CrawlRanking(category, typ int64, stop <-chan struct{}) (HighscoreSnapshot, error) {
+	snapshot, err := c.crawlRanking(category, typ, stop)
+	if err != nil {
+		return snapshot, err
+	}
+	return snapshot, c.flush()
+}
+
+func (c *HighscoreCrawler) crawlRanking(category, typ int64, stop <-chan struct{}) (HighscoreSnapshot, error) {
+	snapshot := HighscoreSnapshot{Category: category, Type: typ, CrawledAt: c.b.ServerTime()}
+	var page, nbPage int64 = 1, 1
+	for ; page <= nbPage; page++ {
+		if page > 1 && !waitOrStop(c.delay, stop) {
+			return snapshot, ErrHighscoreCrawlStopped
+		}
+		highscore, err := c.b.Highscore(category, typ, page)
+		if err != nil {
+			return snapshot, err
+		}
+		nbPage = highscore.NbPage
+		snapshot.Players = append(snapshot.Players, highscore.Players...)
+	}
+	if err := c.store.SaveSnapshot(snapshot); err != nil {
+		return snapshot, err
+	}
+	return snapshot, nil
+}
+
+// Crawl crawls the rankings of every types, for players and alliances.
+// Closing stop aborts the crawl with ErrHighscoreCrawlStopped, the rankings already crawled are kept
+func (c *HighscoreCrawler) Crawl(stop <-chan struct{}) ([]HighscoreSnapshot, error) {
+	out := make([]HighscoreSnapshot, 0)
+	for _, category := range []int64{PlayerHighscore, AllianceHighscore} {
+		for typ := TotalHighscore; typ <= HonorHighscore; typ++ {
+			if len(out) > 0 && !waitOrStop(c.delay, stop) {
+				_ = c.flush()
+				return out, ErrHighscoreCrawlStopped
+			}
+			snapshot, err := c.crawlRanking(category, typ, stop)
+			if err != nil {
+				_ = c.flush()
+				return out, err
+			}
+			out = append(out, snapshot)
+		}
+	}
+	return out, c.flush()
+}
+
+// FindFleetLosses returns the players who lost more than minLost military points during the last period,
+// having their homeworld within systems of origin, biggest losses first
+func (c *HighscoreCrawler) FindFleetLosses(minLost int64, period time.Duration, origin Coordinate, systems int64) ([]HighscoreDelta, error) {
+	if minLost < 0 || systems < 0 {
+		return nil, errors.New("invalid parameters")
+	}
+	deltas, err := HighscoreDeltas(c.store, PlayerHighscore, MilitaryLostHighscore, c.b.ServerTime().Add(-period))
+	if err != nil {
+		return nil, err
+	}
+	out := make([]HighscoreDelta, 0)
+	for _, delta := range deltas {
+		if delta.Diff() <= minLost || delta.Homeworld.Galaxy != origin.Galaxy ||
+			systemDistance(c.b.GetNbSystems(), delta.Homeworld.System, origin.System, c.b.IsDonutSystem()) > systems {
+			continue
+		}
+		out = append(out, delta)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Diff() > out[j].Diff() })
+	return out, nil
+}
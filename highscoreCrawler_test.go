@@ -0,0 +1,138 @@
+package ogame
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestHighscorePlayer(id, score int64, home Coordinate) HighscorePlayer {
+	return HighscorePlayer{ID: id, Name: "player" + strconv.FormatInt(id, 10), Score: score, Homeworld: home}
+}
+
+type fakeHighscoreWrapper struct {
+	*fakeBot
+	highscorePages []Highscore
+}
+
+func (w *fakeHighscoreWrapper) Highscore(category, typ, page int64) (Highscore, error) {
+	if page < 1 || page > int64(len(w.highscorePages)) {
+		return Highscore{}, errors.New("invalid page")
+	}
+	highscore := w.highscorePages[page-1]
+	highscore.Category, highscore.Type, highscore.CurrPage = category, typ, page
+	highscore.NbPage = int64(len(w.highscorePages))
+	return highscore, nil
+}
+
+func TestHighscoreCrawler_CrawlRanking(t *testing.T) {
+	w := &fakeHighscoreWrapper{fakeBot: newFakeBot()}
+	w.highscorePages = []Highscore{
+		{Players: []HighscorePlayer{newTestHighscorePlayer(1, 100, Coordinate{1, 2, 3, PlanetType})}},
+		{Players: []HighscorePlayer{newTestHighscorePlayer(2, 50, Coordinate{1, 5, 3, PlanetType})}},
+	}
+	store := NewMemoryHighscoreStore()
+	crawler := NewHighscoreCrawler(w, store).SetDelay(0)
+	snapshot, err := crawler.CrawlRanking(PlayerHighscore, MilitaryLostHighscore, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(snapshot.Players))
+	assert.Equal(t, w.now, snapshot.CrawledAt)
+	assert.Equal(t, int64(50), snapshot.Player(2).Score)
+	assert.Nil(t, snapshot.Player(3))
+	snapshots, _ := store.GetSnapshots(PlayerHighscore, MilitaryLostHighscore)
+	assert.Equal(t, 1, len(snapshots))
+
+	snapshots, err = crawler.Crawl(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 16, len(snapshots))
+	assert.Equal(t, AllianceHighscore, snapshots[15].Category)
+	assert.Equal(t, HonorHighscore, snapshots[15].Type)
+}
+
+func TestHighscoreCrawler_Stop(t *testing.T) {
+	w := &fakeHighscoreWrapper{fakeBot: newFakeBot()}
+	w.highscorePages = []Highscore{{}, {}}
+	store := NewMemoryHighscoreStore()
+	crawler := NewHighscoreCrawler(w, store).SetDelay(time.Hour)
+	stop := make(chan struct{})
+	close(stop)
+	_, err := crawler.CrawlRanking(PlayerHighscore, TotalHighscore, stop)
+	assert.Equal(t, ErrHighscoreCrawlStopped, err)
+	snapshots, _ := store.GetSnapshots(PlayerHighscore, TotalHighscore)
+	assert.Equal(t, 0, len(snapshots))
+
+	w.highscorePages = []Highscore{{}}
+	out, err := crawler.Crawl(stop)
+	assert.Equal(t, ErrHighscoreCrawlStopped, err)
+	assert.Equal(t, 1, len(out))
+}
+
+func TestMemoryHighscoreStore_Retention(t *testing.T) {
+	store := NewMemoryHighscoreStore().SetRetention(time.Hour)
+	now := time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC)
+	_ = store.SaveSnapshot(HighscoreSnapshot{Category: 1, Type: 0, CrawledAt: now})
+	_ = store.SaveSnapshot(HighscoreSnapshot{Category: 1, Type: 0, CrawledAt: now.Add(-30 * time.Minute)})
+	_ = store.SaveSnapshot(HighscoreSnapshot{Category: 1, Type: 0, CrawledAt: now.Add(-2 * time.Hour)})
+	snapshots, _ := store.GetSnapshots(1, 0)
+	assert.Equal(t, 2, len(snapshots))
+	assert.Equal(t, now.Add(-30*time.Minute), snapshots[0].CrawledAt)
+}
+
+func TestHighscoreCrawler_FindFleetLosses(t *testing.T) {
+	w := newFakeBot()
+	store := NewMemoryHighscoreStore()
+	crawler := NewHighscoreCrawler(w, store)
+	home := Coordinate{1, 100, 8, PlanetType}
+	snapshot := func(at time.Time, scores ...int64) HighscoreSnapshot {
+		s := HighscoreSnapshot{Category: PlayerHighscore, Type: MilitaryLostHighscore, CrawledAt: at}
+		s.Players = []HighscorePlayer{
+			newTestHighscorePlayer(1, scores[0], Coordinate{1, 105, 4, PlanetType}),
+			newTestHighscorePlayer(2, scores[1], Coordinate{1, 498, 4, PlanetType}),
+			newTestHighscorePlayer(3, scores[2], Coordinate{2, 100, 4, PlanetType}),
+			newTestHighscorePlayer(4, scores[3], Coordinate{1, 300, 4, PlanetType}),
+		}
+		return s
+	}
+	_ = store.SaveSnapshot(snapshot(w.now.Add(-3*time.Hour), 0, 0, 0, 0))
+	_ = store.SaveSnapshot(snapshot(w.now.Add(-90*time.Minute), 1000, 100, 0, 0))
+	_ = store.SaveSnapshot(snapshot(w.now, 51000, 200100, 90000, 90000))
+
+	losses, err := crawler.FindFleetLosses(10000, time.Hour, home, 110)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(losses))
+	assert.Equal(t, int64(2), losses[0].PlayerID)
+	assert.Equal(t, int64(200000), losses[0].Diff())
+	assert.Equal(t, int64(1), losses[1].PlayerID)
+	assert.Equal(t, int64(50000), losses[1].Diff())
+	assert.Equal(t, w.now.Add(-90*time.Minute), losses[1].From)
+
+	deltas, _ := HighscoreDeltas(store, PlayerHighscore, MilitaryLostHighscore, w.now.Add(-24*time.Hour))
+	assert.Equal(t, 4, len(deltas))
+	assert.Equal(t, int64(51000), deltas[0].Diff())
+}
+
+func TestFileHighscoreStore(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "highscore")
+	defer os.RemoveAll(dir)
+	filename := filepath.Join(dir, "highscore.json")
+	store, err := NewFileHighscoreStore(filename)
+	assert.NoError(t, err)
+	_ = store.SaveSnapshot(HighscoreSnapshot{Category: 1, Type: 3, Players: []HighscorePlayer{{ID: 5, Score: 10}}})
+	_ = store.SaveSnapshot(HighscoreSnapshot{Category: 2, Type: 0})
+	_, err = os.Stat(filename)
+	assert.True(t, os.IsNotExist(err))
+	assert.NoError(t, store.Flush())
+	store, err = NewFileHighscoreStore(filename)
+	assert.NoError(t, err)
+	snapshots, _ := store.GetSnapshots(1, 3)
+	assert.Equal(t, 1, len(snapshots))
+	assert.Equal(t, int64(10), snapshots[0].Player(5).Score)
+	snapshots, _ = store.GetSnapshots(2, 0)
+	assert.Equal(t, 1, len(snapshots))
+}
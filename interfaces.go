@@ -14,28 +14,44 @@ import (
 type Prioritizable interface {
 	RecruitOfficer(typ, days int64) error
 	Abandon(interface{}) error
+	AcceptAllianceApplication(applicationID int64) error
 	ActivateItem(string, CelestialID) error
+	AddBuddy(playerID int64, message string) error
 	Begin() Prioritizable
 	BeginNamed(name string) Prioritizable
 	BuyMarketplace(itemID int64, celestialID CelestialID) error
 	BuyOfferOfTheDay() error
+	CallResourceMerchant(celestialID CelestialID, resource MerchantResource) (ResourceMerchant, error)
 	CancelFleet(FleetID) error
+	CancelMarketplaceOffer(offerID int64) error
 	CollectAllMarketplaceMessages() error
 	CollectMarketplaceMessage(MarketplaceMessage) error
+	CreateMarketplaceOffer(MarketplaceOfferRequest) error
 	CreateUnion(fleet Fleet, unionUsers []string) (int64, error)
 	DoAuction(bid map[CelestialID]Resources) error
 	Done()
 	DeleteAllMessagesFromTab(tabID int64) error
+	DenyAllianceApplication(applicationID int64, reason string) error
 	DeleteMessage(msgID int64) error
+	FavoriteMessage(msgID int64) error
 	FlightTime(origin, destination Coordinate, speed Speed, ships ShipsInfos, mission MissionID) (secs, fuel int64)
 	GalaxyInfos(galaxy, system int64, opts ...Option) (SystemInfos, error)
+	GetAllianceApplications() ([]AllianceApplication, error)
+	GetAllianceOverview() (AllianceOverview, error)
 	GetAlliancePageContent(url.Values) ([]byte, error)
+	GetAlliancePublicInfo(allianceID int64) (AlliancePublicInfo, error)
+	GetAllianceRanks() ([]AllianceRank, error)
 	GetAllResources() (map[CelestialID]Resources, error)
+	GetAllianceChatHistory(associationID, lastMessageID int64) ([]ChatMsg, error)
 	GetAttacks(...Option) ([]AttackEvent, error)
 	GetAuction() (Auction, error)
+	GetBuddies() ([]Buddy, error)
 	GetCachedResearch() Researches
 	GetCelestial(interface{}) (Celestial, error)
 	GetCelestials() ([]Celestial, error)
+	GetChatConversations() ([]ChatConversation, error)
+	GetChatHistory(playerID, lastMessageID int64) ([]ChatMsg, error)
+	GetCombatReportMessages() ([]CombatReportSummary, error)
 	GetCombatReportSummaryFor(Coordinate) (CombatReportSummary, error)
 	GetDMCosts(CelestialID) (DMCosts, error)
 	GetEmpire(CelestialType) ([]EmpireCelestial, error)
@@ -47,31 +63,48 @@ type Prioritizable interface {
 	GetExpeditionMessages() ([]ExpeditionMessage, error)
 	GetFleets(...Option) ([]Fleet, Slots)
 	GetFleetsFromEventList() []Fleet
+	GetIgnoredPlayers() ([]IgnoredPlayer, error)
 	GetItems(CelestialID) ([]Item, error)
 	GetActiveItems(CelestialID) ([]ActiveItem, error)
+	GetAllMessages(MessagesTab) ([]Message, error)
+	GetMarketplaceMessages() ([]MarketplaceMessage, error)
+	GetMarketplaceOffers(MarketplaceFilter) ([]MarketplaceOffer, error)
+	GetMessages(tab MessagesTab, page int64) ([]Message, int64, error)
 	GetMoon(interface{}) (Moon, error)
 	GetMoons() []Moon
+	GetMyMarketplaceOffers() ([]MarketplaceOffer, error)
 	GetPageContent(url.Values) ([]byte, error)
 	GetPlanet(interface{}) (Planet, error)
 	GetPlanets() []Planet
 	GetResearch() Researches
+	GetResourceMerchant(celestialID CelestialID) (ResourceMerchant, error)
 	GetSlots() Slots
 	GetUserInfos() UserInfos
 	HeadersForPage(url string) (http.Header, error)
 	Highscore(category, typ, page int64) (Highscore, error)
+	IgnorePlayer(playerID int64) error
 	IsUnderAttack() (bool, error)
 	Login() error
 	LoginWithBearerToken(token string) (bool, error)
 	LoginWithExistingCookies() (bool, error)
 	Logout()
+	MarkAllianceChatAsRead(associationID int64) error
+	MarkChatAsRead(playerID int64) error
 	OfferBuyMarketplace(itemID interface{}, quantity, priceType, price, priceRange int64, celestialID CelestialID) error
 	OfferSellMarketplace(itemID interface{}, quantity, priceType, price, priceRange int64, celestialID CelestialID) error
 	PostPageContent(url.Values, url.Values) ([]byte, error)
+	RemoveBuddy(buddyID int64) error
+	SellMarketplace(itemID int64, celestialID CelestialID) error
 	SendMessage(playerID int64, message string) error
+	SendAllianceCircular(message string, rankIDs []int64) error
 	SendMessageAlliance(associationID int64, message string) error
 	ServerTime() time.Time
+	SetAllianceClass(class AllianceClass) error
 	SetInitiator(initiator string) Prioritizable
+	TradeResourceMerchant(celestialID CelestialID, amount int64) (Resources, error)
 	Tx(clb func(tx Prioritizable) error) error
+	UnfavoriteMessage(msgID int64) error
+	UnignorePlayer(playerID int64) error
 	UseDM(string, CelestialID) error
 
 	// Planet or Moon functions
@@ -278,9 +311,21 @@ type Extractor interface {
 	ExtractResourceSettings(pageHTML []byte) (ResourceSettings, error)
 	ExtractAttacks(pageHTML []byte, ownCoords []Coordinate) ([]AttackEvent, error)
 	ExtractOfferOfTheDay(pageHTML []byte) (int64, string, PlanetResources, Multiplier, error)
+	ExtractResourceMerchant(pageHTML []byte) (ResourceMerchant, error)
 	ExtractResourcesBuildings(pageHTML []byte) (ResourcesBuildings, error)
 	ExtractExpeditionMessages(pageHTML []byte, location *time.Location) ([]ExpeditionMessage, int64, error)
 	ExtractMarketplaceMessages(pageHTML []byte, location *time.Location) ([]MarketplaceMessage, int64, error)
+	ExtractMessages(pageHTML []byte, location *time.Location) ([]Message, int64, error)
+	ExtractMarketplaceOffers(pageHTML []byte) ([]MarketplaceOffer, int64, error)
+	ExtractChatConversations(pageHTML []byte, location *time.Location) ([]ChatConversation, error)
+	ExtractBuddies(pageHTML []byte) ([]Buddy, error)
+	ExtractIgnoredPlayers(pageHTML []byte) ([]IgnoredPlayer, error)
+	ExtractBuddiesToken(pageHTML []byte) (string, error)
+	ExtractAllianceOverview(pageHTML []byte, location *time.Location) (AllianceOverview, error)
+	ExtractAllianceRanks(pageHTML []byte) ([]AllianceRank, error)
+	ExtractAllianceApplications(pageHTML []byte, location *time.Location) ([]AllianceApplication, error)
+	ExtractAllianceToken(pageHTML []byte) (string, error)
+	ExtractAlliancePublicInfo(pageHTML []byte) (AlliancePublicInfo, error)
 	ExtractDefense(pageHTML []byte) (DefensesInfos, error)
 	ExtractShips(pageHTML []byte) (ShipsInfos, error)
 	ExtractFacilities(pageHTML []byte) (Facilities, error)
@@ -320,7 +365,9 @@ type Extractor interface {
 	ExtractResearchFromDoc(doc *goquery.Document) Researches
 	ExtractOGameSessionFromDoc(doc *goquery.Document) string
 	ExtractAttacksFromDoc(doc *goquery.Document, clock clockwork.Clock, ownCoords []Coordinate) ([]AttackEvent, error)
+	ExtractMessagesFromDoc(doc *goquery.Document, location *time.Location) ([]Message, int64, error)
 	ExtractOfferOfTheDayFromDoc(doc *goquery.Document) (price int64, importToken string, planetResources PlanetResources, multiplier Multiplier, err error)
+	ExtractResourceMerchantFromDoc(doc *goquery.Document) (ResourceMerchant, error)
 	ExtractProductionFromDoc(doc *goquery.Document) ([]Quantifiable, error)
 	ExtractOverviewProductionFromDoc(doc *goquery.Document) ([]Quantifiable, error)
 	ExtractFleet1ShipsFromDoc(doc *goquery.Document) (s ShipsInfos)
@@ -0,0 +1,212 @@
+package ogame
+
+import (
+	"errors"
+	"sort"
+	"time"
+)
+
+// IPMSalvo missiles sent from one planet at a single defense type
+type IPMSalvo struct {
+	Origin     Celestial
+	Priority   ID
+	Nbr        int64
+	FlightTime int64
+	ImpactAt   time.Time
+}
+
+// IPMStrikePlan missiles needed to destroy the chosen defenses of a target
+type IPMStrikePlan struct {
+	Target      Coordinate
+	Salvos      []IPMSalvo
+	Missiles    int64 // Missiles sent, including the ones intercepted
+	Intercepted int64 // Missiles destroyed by the anti-ballistic missiles
+	Missing     int64 // Missiles needed but not available in range
+	Destroyed   DefensesInfos
+	Remaining   DefensesInfos
+	ImpactAt    time.Time // Impact of the last salvo
+}
+
+// DefaultIPMTargets defenses targeted when none are given, most valuable first
+var DefaultIPMTargets = []ID{PlasmaTurretID, GaussCannonID, IonCannonID, HeavyLaserID, LightLaserID, RocketLauncherID}
+
+// IPMRange returns the range, in systems, of the interplanetary missiles
+func IPMRange(impulseDrive int64) int64 {
+	return MaxInt(5*impulseDrive-1, 0)
+}
+
+// IPMFlightTime returns the flight time in seconds of the interplanetary missiles
+func IPMFlightTime(systemsDistance int64) int64 {
+	return 30 + 60*systemsDistance
+}
+
+// IPMHull returns the damage needed to destroy one unit of a defense
+func IPMHull(id ID, armourTechnology int64) int64 {
+	defense, ok := Objs.ByID(id).(Defense)
+	if !ok {
+		return 0
+	}
+	return defense.GetStructuralIntegrity(Researches{ArmourTechnology: armourTechnology}) / 10
+}
+
+// IPMDamage returns the damage of one interplanetary missile
+func IPMDamage(weaponsTechnology int64) int64 {
+	return InterplanetaryMissiles.GetWeaponPower(Researches{WeaponsTechnology: weaponsTechnology})
+}
+
+// IPMPlanner computes and sends the interplanetary missiles needed to destroy defenses
+type IPMPlanner struct {
+	b       Wrapper
+	origins []Celestial
+}
+
+// NewIPMPlanner ...
+func NewIPMPlanner(b Wrapper) *IPMPlanner {
+	return &IPMPlanner{b: b}
+}
+
+// SetOrigins restricts the planets missiles are launched from (all planets by default)
+func (p *IPMPlanner) SetOrigins(origins ...interface{}) *IPMPlanner {
+	p.origins = make([]Celestial, 0, len(origins))
+	for _, origin := range origins {
+		if c := p.b.GetCachedCelestial(origin); c != nil {
+			p.origins = append(p.origins, c)
+		}
+	}
+	return p
+}
+
+type ipmLauncher struct {
+	origin     Celestial
+	missiles   int64
+	flightTime int64
+}
+
+// launchers returns the planets having the target in range and missiles, closest first
+func (p *IPMPlanner) launchers(target Coordinate) ([]ipmLauncher, error) {
+	origins := p.origins
+	if origins == nil {
+		origins = p.b.GetCachedCelestials()
+	}
+	ipmRange := IPMRange(p.b.GetCachedResearch().ImpulseDrive)
+	out := make([]ipmLauncher, 0)
+	for _, origin := range origins {
+		coord := origin.GetCoordinate()
+		if coord.Type != PlanetType || coord.Galaxy != target.Galaxy {
+			continue
+		}
+		distance := systemDistance(p.b.GetNbSystems(), coord.System, target.System, p.b.IsDonutSystem())
+		if distance > ipmRange {
+			continue
+		}
+		defenses, err := p.b.GetDefense(origin.GetID())
+		if err != nil {
+			return nil, err
+		}
+		if defenses.InterplanetaryMissiles > 0 {
+			out = append(out, ipmLauncher{origin: origin, missiles: defenses.InterplanetaryMissiles, flightTime: IPMFlightTime(distance)})
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].flightTime < out[j].flightTime })
+	return out, nil
+}
+
+// Plan computes the missiles needed to destroy the targeted defenses of the report, in priority order.
+// Missiles intercepted by the anti-ballistic missiles are added to the first salvos.
+func (p *IPMPlanner) Plan(report EspionageReport, targets ...ID) (IPMStrikePlan, error) {
+	plan := IPMStrikePlan{Target: report.Coordinate}
+	defenses := report.DefensesInfos()
+	if defenses == nil {
+		return plan, errors.New("no defenses information in espionage report")
+	}
+	plan.Remaining = *defenses
+	if len(targets) == 0 {
+		targets = DefaultIPMTargets
+	}
+	launchers, err := p.launchers(report.Coordinate)
+	if err != nil {
+		return plan, err
+	}
+	armour := i64(report.ArmourTechnology)
+	damage := IPMDamage(p.b.GetCachedResearch().WeaponsTechnology)
+	now := p.b.ServerTime()
+	abm := defenses.AntiBallisticMissiles
+	for _, id := range targets {
+		if !id.IsDefense() || id == AntiBallisticMissilesID || id == InterplanetaryMissilesID {
+			return plan, errors.New("invalid defense target id " + id.String())
+		}
+		nbr := defenses.ByID(id)
+		if nbr <= 0 {
+			continue
+		}
+		hull := IPMHull(id, armour)
+		needed := (nbr*hull + damage - 1) / damage
+		needed += abm // Anti-ballistic missiles left intercept the first missiles
+		var hits int64
+		for i := range launchers {
+			if needed <= 0 {
+				break
+			}
+			l := &launchers[i]
+			missiles := MinInt(needed, l.missiles)
+			if missiles <= 0 {
+				continue
+			}
+			l.missiles -= missiles
+			needed -= missiles
+			hits += missiles
+			salvo := IPMSalvo{Origin: l.origin, Priority: id, Nbr: missiles, FlightTime: l.flightTime}
+			salvo.ImpactAt = now.Add(time.Duration(l.flightTime) * time.Second)
+			if salvo.ImpactAt.After(plan.ImpactAt) {
+				plan.ImpactAt = salvo.ImpactAt
+			}
+			plan.Salvos = append(plan.Salvos, salvo)
+		}
+		plan.Missing += needed
+		plan.Missiles += hits
+		stopped := MinInt(hits, abm)
+		abm -= stopped
+		plan.Intercepted += stopped
+		destroyed := MinInt(nbr, (hits-stopped)*damage/hull)
+		plan.Destroyed.Set(id, destroyed)
+		plan.Remaining.Set(id, nbr-destroyed)
+	}
+	return plan, nil
+}
+
+// Send launches every salvo of the plan, returns the number of missiles sent
+func (p *IPMPlanner) Send(plan IPMStrikePlan) (int64, error) {
+	var sent int64
+	for _, salvo := range plan.Salvos {
+		nbr, err := p.b.SendIPM(PlanetID(salvo.Origin.GetID()), plan.Target, salvo.Nbr, salvo.Priority)
+		sent += nbr
+		if err != nil {
+			return sent, err
+		}
+	}
+	return sent, nil
+}
+
+// SimulateFollowUp simulates an attack on the target once the missiles destroyed the defenses
+func (p *IPMPlanner) SimulateFollowUp(plan IPMStrikePlan, report EspionageReport, ships ShipsInfos, simulations int) SimulatorResult {
+	researches := p.b.GetCachedResearch()
+	attacker := Attacker{ShipsInfos: ships,
+		Weapon: int(researches.WeaponsTechnology), Shield: int(researches.ShieldingTechnology), Armour: int(researches.ArmourTechnology)}
+	defender := Defender{DefensesInfos: plan.Remaining,
+		Metal: int(report.Metal), Crystal: int(report.Crystal), Deuterium: int(report.Deuterium),
+		Weapon: int(i64(report.WeaponsTechnology)), Shield: int(i64(report.ShieldingTechnology)), Armour: int(i64(report.ArmourTechnology))}
+	if reportShips := report.ShipsInfos(); reportShips != nil {
+		defender.ShipsInfos = *reportShips
+	}
+	return Simulate(attacker, defender, SimulatorParams{Simulations: simulations})
+}
+
+// FollowUpLaunchAt returns when to launch an attack from origin so that it arrives delay after the last missile impact
+func (p *IPMPlanner) FollowUpLaunchAt(plan IPMStrikePlan, origin interface{}, ships ShipsInfos, speed Speed, delay time.Duration) (time.Time, error) {
+	c := p.b.GetCachedCelestial(origin)
+	if c == nil {
+		return time.Time{}, errors.New("invalid origin")
+	}
+	secs, _ := p.b.FlightTime(c.GetCoordinate(), plan.Target, speed, ships, Attack)
+	return plan.ImpactAt.Add(delay).Add(-time.Duration(secs) * time.Second), nil
+}
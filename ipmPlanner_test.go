@@ -0,0 +1,83 @@
+package ogame
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIPMRange(t *testing.T) {
+	assert.Equal(t, int64(0), IPMRange(0))
+	assert.Equal(t, int64(4), IPMRange(1))
+	assert.Equal(t, int64(49), IPMRange(10))
+}
+
+type fakeIPMWrapper struct {
+	*fakeBot
+	defensesByID map[CelestialID]DefensesInfos
+	ipms         []Quantifiable
+}
+
+func (w *fakeIPMWrapper) GetDefense(celestialID CelestialID, opts ...Option) (DefensesInfos, error) {
+	return w.defensesByID[celestialID], nil
+}
+
+func (w *fakeIPMWrapper) SendIPM(planetID PlanetID, coord Coordinate, nbr int64, priority ID) (int64, error) {
+	w.ipms = append(w.ipms, Quantifiable{ID: priority, Nbr: nbr})
+	return nbr, nil
+}
+
+func TestIPMPlanner(t *testing.T) {
+	w := &fakeIPMWrapper{fakeBot: newFakeBot(), defensesByID: make(map[CelestialID]DefensesInfos)}
+	w.researches = Researches{ImpulseDrive: 4, WeaponsTechnology: 10}
+	far := Planet{ID: 1, Coordinate: Coordinate{1, 100, 8, PlanetType}}
+	near := Planet{ID: 2, Coordinate: Coordinate{1, 105, 8, PlanetType}}
+	outOfRange := Planet{ID: 3, Coordinate: Coordinate{1, 150, 8, PlanetType}}
+	w.celestials = []Celestial{far, near, outOfRange}
+	w.defensesByID[1] = DefensesInfos{InterplanetaryMissiles: 6}
+	w.defensesByID[2] = DefensesInfos{InterplanetaryMissiles: 20}
+	w.defensesByID[3] = DefensesInfos{InterplanetaryMissiles: 100}
+	report := EspionageReport{Coordinate: Coordinate{1, 110, 5, PlanetType}, HasDefensesInformation: true,
+		RocketLauncher: I64Ptr(100), PlasmaTurret: I64Ptr(10), AntiBallisticMissiles: I64Ptr(5)}
+	p := NewIPMPlanner(w)
+
+	plan, err := p.Plan(report)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(plan.Salvos))
+	assert.Equal(t, IPMSalvo{Origin: near, Priority: PlasmaTurretID, Nbr: 10, FlightTime: 330, ImpactAt: w.now.Add(330 * time.Second)}, plan.Salvos[0])
+	assert.Equal(t, RocketLauncherID, plan.Salvos[1].Priority)
+	assert.Equal(t, int64(1), plan.Salvos[1].Nbr)
+	assert.Equal(t, int64(11), plan.Missiles)
+	assert.Equal(t, int64(5), plan.Intercepted)
+	assert.Equal(t, int64(0), plan.Missing)
+	assert.Equal(t, DefensesInfos{PlasmaTurret: 10, RocketLauncher: 100}, plan.Destroyed)
+	assert.Equal(t, DefensesInfos{AntiBallisticMissiles: 5}, plan.Remaining)
+
+	sent, err := p.Send(plan)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(11), sent)
+	assert.Equal(t, []Quantifiable{{ID: PlasmaTurretID, Nbr: 10}, {ID: RocketLauncherID, Nbr: 1}}, w.ipms)
+
+	ships := ShipsInfos{LightFighter: 10}
+	launchAt, err := p.FollowUpLaunchAt(plan, near, ships, HundredPercent, 10*time.Second)
+	assert.NoError(t, err)
+	secs, _ := w.FlightTime(near.Coordinate, report.Coordinate, HundredPercent, ships, Attack)
+	assert.Equal(t, plan.ImpactAt.Add(10*time.Second-time.Duration(secs)*time.Second), launchAt)
+	result := p.SimulateFollowUp(plan, report, ships, 1)
+	assert.Equal(t, 100, result.AttackerWin)
+
+	// Not enough missiles in range, split across planets
+	report.PlasmaTurret = I64Ptr(100)
+	plan, err = NewIPMPlanner(w).Plan(report, PlasmaTurretID)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(plan.Salvos))
+	assert.Equal(t, near.GetID(), plan.Salvos[0].Origin.GetID())
+	assert.Equal(t, far.GetID(), plan.Salvos[1].Origin.GetID())
+	assert.Equal(t, int64(26), plan.Missiles)
+	assert.Equal(t, int64(21), plan.Missing)
+	assert.Equal(t, int64(50), plan.Destroyed.PlasmaTurret)
+
+	_, err = p.Plan(report, AntiBallisticMissilesID)
+	assert.Error(t, err)
+}
@@ -0,0 +1,67 @@
+package ogame
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"sync"
+)
+
+// jsonFileStore persists the content of a memory store as json in a file, every batchSize saves and on flush
+type jsonFileStore struct {
+	sync.Mutex
+	filename  string
+	batchSize int64
+	unsaved   int64
+	content   func() interface{} // what is written to the file
+}
+
+func newJSONFileStore(filename string, batchSize int64, content func() interface{}) *jsonFileStore {
+	return &jsonFileStore{filename: filename, batchSize: batchSize, content: content}
+}
+
+// load unmarshals the file into v, a missing file is not an error
+func (f *jsonFileStore) load(v interface{}) error {
+	by, err := ioutil.ReadFile(f.filename)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	return json.Unmarshal(by, v)
+}
+
+// save calls fn, then writes the file if batchSize saves are pending
+func (f *jsonFileStore) save(fn func()) error {
+	f.Lock()
+	defer f.Unlock()
+	fn()
+	f.unsaved++
+	if f.unsaved < f.batchSize {
+		return nil
+	}
+	return f.write()
+}
+
+// flush writes the file if some saves are pending
+func (f *jsonFileStore) flush() error {
+	f.Lock()
+	defer f.Unlock()
+	if f.unsaved == 0 {
+		return nil
+	}
+	return f.write()
+}
+
+func (f *jsonFileStore) write() error {
+	by, err := json.Marshal(f.content())
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(f.filename, by, 0644); err != nil {
+		return err
+	}
+	f.unsaved = 0
+	return nil
+}
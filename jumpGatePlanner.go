@@ -0,0 +1,261 @@
+package ogame
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+// JumpStepType how a step of a jump plan moves the ships
+type JumpStepType string
+
+// Jump step types
+const (
+	JumpStep   JumpStepType = "jump"
+	FlightStep JumpStepType = "flight"
+)
+
+// JumpPlanStep a single jump or flight of a jump plan
+type JumpPlanStep struct {
+	Type     JumpStepType
+	Origin   Celestial
+	Dest     Celestial
+	StartAt  time.Time // Earliest time the step can start (gate recharged, ships arrived)
+	ArriveAt time.Time
+	Fuel     int64
+}
+
+// JumpPlan steps to move ships from a celestial to another
+type JumpPlan struct {
+	Ships       ShipsInfos
+	Steps       []JumpPlanStep
+	ArrivalTime time.Time
+	Fuel        int64
+}
+
+// jumpGateNode state of a moon gate in the network
+type jumpGateNode struct {
+	moon     Celestial
+	dests    []MoonID
+	readyAt  time.Time
+	cooldown time.Duration
+}
+
+// JumpGateCooldown returns the recharge time of a jump gate after a jump, one hour at level 1, 30% less per level
+func JumpGateCooldown(level int64) time.Duration {
+	if level < 1 {
+		level = 1
+	}
+	return time.Duration(math.Round(3600*math.Pow(0.7, float64(level-1)))) * time.Second
+}
+
+// JumpGatePlanner moves ships across our network of moons with jump gates
+type JumpGatePlanner struct {
+	b        Wrapper
+	cooldown time.Duration
+	speed    Speed
+	sleep    func(time.Duration)
+}
+
+// NewJumpGatePlanner ...
+func NewJumpGatePlanner(b Wrapper) *JumpGatePlanner {
+	p := new(JumpGatePlanner)
+	p.b = b
+	p.speed = HundredPercent
+	p.sleep = time.Sleep
+	return p
+}
+
+// SetCooldown overrides the recharge time of the gates after a jump, used to plan successive jumps through the same moon.
+// By default it is computed from the jump gate level of every moon.
+func (p *JumpGatePlanner) SetCooldown(cooldown time.Duration) *JumpGatePlanner {
+	p.cooldown = cooldown
+	return p
+}
+
+// SetSpeed sets the speed of the flights
+func (p *JumpGatePlanner) SetSpeed(speed Speed) *JumpGatePlanner {
+	p.speed = speed
+	return p
+}
+
+// network returns the moons having a jump gate, with their destinations and recharge time
+func (p *JumpGatePlanner) network() (map[CelestialID]*jumpGateNode, error) {
+	now := p.b.ServerTime()
+	nodes := make(map[CelestialID]*jumpGateNode)
+	for _, moon := range p.b.GetCachedMoons() {
+		facilities, err := p.b.GetFacilities(moon.ID.Celestial())
+		if err != nil {
+			return nil, err
+		}
+		if facilities.JumpGate == 0 {
+			continue // No jump gate
+		}
+		dests, countdown, err := p.b.JumpGateDestinations(moon.ID)
+		if err != nil && countdown == 0 {
+			return nil, err
+		}
+		cooldown := p.cooldown
+		if cooldown == 0 {
+			cooldown = JumpGateCooldown(facilities.JumpGate)
+		}
+		nodes[moon.GetID()] = &jumpGateNode{moon: moon, dests: dests, readyAt: now.Add(time.Duration(countdown) * time.Second), cooldown: cooldown}
+	}
+	// The destinations are not displayed while a gate recharges, it can jump to any other moon having a gate
+	for id, node := range nodes {
+		if len(node.dests) > 0 {
+			continue
+		}
+		for destID := range nodes {
+			if destID != id {
+				node.dests = append(node.dests, MoonID(destID))
+			}
+		}
+	}
+	return nodes, nil
+}
+
+// Plan computes the fastest way to move the ships from origin to destination, using jump gates and flights
+func (p *JumpGatePlanner) Plan(origin, destination interface{}, ships ShipsInfos) (JumpPlan, error) {
+	plan := JumpPlan{Ships: ships}
+	from := p.b.GetCachedCelestial(origin)
+	to := p.b.GetCachedCelestial(destination)
+	if from == nil || to == nil {
+		return plan, errors.New("invalid origin or destination")
+	}
+	gates, err := p.network()
+	if err != nil {
+		return plan, err
+	}
+
+	// Dijkstra on arrival time. Reaching a moon by a jump puts its gate in recharge.
+	type state struct {
+		id     CelestialID
+		jumped bool
+	}
+	type label struct {
+		at   time.Time
+		prev *state
+		step JumpPlanStep
+	}
+	celestials := map[CelestialID]Celestial{from.GetID(): from, to.GetID(): to}
+	for id, gate := range gates {
+		celestials[id] = gate.moon
+	}
+	now := p.b.ServerTime()
+	start := state{id: from.GetID()}
+	labels := map[state]*label{start: {at: now}}
+	done := make(map[state]bool)
+	for {
+		var curr *state
+		for s, l := range labels {
+			s := s
+			if !done[s] && (curr == nil || l.at.Before(labels[*curr].at)) {
+				curr = &s
+			}
+		}
+		if curr == nil || curr.id == to.GetID() {
+			break
+		}
+		done[*curr] = true
+		currAt := labels[*curr].at
+		relax := func(next state, step JumpPlanStep) {
+			if l, ok := labels[next]; !ok || step.ArriveAt.Before(l.at) {
+				prev := *curr
+				labels[next] = &label{at: step.ArriveAt, prev: &prev, step: step}
+			}
+		}
+		if gate, ok := gates[curr.id]; ok {
+			startAt := currAt
+			if gate.readyAt.After(startAt) {
+				startAt = gate.readyAt
+			}
+			if curr.jumped && currAt.Add(gate.cooldown).After(startAt) {
+				startAt = currAt.Add(gate.cooldown)
+			}
+			for _, destID := range gate.dests {
+				dest, ok := gates[destID.Celestial()]
+				if !ok {
+					continue
+				}
+				arriveAt := startAt
+				if dest.readyAt.After(arriveAt) {
+					arriveAt = dest.readyAt
+				}
+				relax(state{id: destID.Celestial(), jumped: true},
+					JumpPlanStep{Type: JumpStep, Origin: gate.moon, Dest: dest.moon, StartAt: arriveAt, ArriveAt: arriveAt})
+			}
+		}
+		for id, c := range celestials {
+			if id == curr.id {
+				continue
+			}
+			secs, fuel := p.b.FlightTime(celestials[curr.id].GetCoordinate(), c.GetCoordinate(), p.speed, ships, Park)
+			relax(state{id: id}, JumpPlanStep{Type: FlightStep, Origin: celestials[curr.id], Dest: c,
+				StartAt: currAt, ArriveAt: currAt.Add(time.Duration(secs) * time.Second), Fuel: fuel})
+		}
+	}
+
+	var end *state
+	for _, s := range []state{{id: to.GetID(), jumped: true}, {id: to.GetID()}} {
+		s := s
+		if l, ok := labels[s]; ok && (end == nil || l.at.Before(labels[*end].at)) {
+			end = &s
+		}
+	}
+	if end == nil {
+		return plan, errors.New("destination unreachable")
+	}
+	plan.ArrivalTime = labels[*end].at
+	for s := end; labels[*s].prev != nil; s = labels[*s].prev {
+		step := labels[*s].step
+		plan.Steps = append([]JumpPlanStep{step}, plan.Steps...)
+		plan.Fuel += step.Fuel
+	}
+	return plan, nil
+}
+
+// Execute runs the plan step by step, waiting for the ships to arrive and the gates to recharge
+func (p *JumpGatePlanner) Execute(plan JumpPlan) error {
+	for _, step := range plan.Steps {
+		if wait := step.StartAt.Sub(p.b.ServerTime()); wait > 0 {
+			p.sleep(wait)
+		}
+		switch step.Type {
+		case JumpStep:
+			originID, destID := MoonID(step.Origin.GetID()), MoonID(step.Dest.GetID())
+			for {
+				success, countdown, err := p.b.JumpGate(originID, destID, plan.Ships)
+				if success {
+					break
+				}
+				if countdown <= 0 {
+					if err == nil {
+						err = errors.New("jump failed")
+					}
+					return err
+				}
+				p.sleep(time.Duration(countdown) * time.Second)
+			}
+		case FlightStep:
+			fleet, err := NewFleetBuilder(p.b).
+				SetOrigin(step.Origin).
+				SetDestination(step.Dest).
+				SetMission(Park).
+				SetSpeed(p.speed).
+				SetShips(plan.Ships).
+				SendNow()
+			if err != nil {
+				return err
+			}
+			arriveAt := step.ArriveAt
+			if !fleet.ArrivalTime.IsZero() {
+				arriveAt = fleet.ArrivalTime
+			}
+			if wait := arriveAt.Sub(p.b.ServerTime()); wait > 0 {
+				p.sleep(wait)
+			}
+		}
+	}
+	return nil
+}
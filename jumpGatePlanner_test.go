@@ -0,0 +1,146 @@
+package ogame
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeJumpGateWrapper struct {
+	*fakeFleets
+	facilities Facilities
+	gateDests  map[MoonID][]MoonID
+	gateReady  map[MoonID]time.Time
+	gateErr    error
+	jumps      [][2]MoonID
+}
+
+func newFakeJumpGateWrapper() *fakeJumpGateWrapper {
+	return &fakeJumpGateWrapper{fakeFleets: newFakeFleets(), gateDests: make(map[MoonID][]MoonID), gateReady: make(map[MoonID]time.Time)}
+}
+
+func (w *fakeJumpGateWrapper) GetFacilities(CelestialID, ...Option) (Facilities, error) {
+	return w.facilities, nil
+}
+
+func (w *fakeJumpGateWrapper) gateCountdown(moonID MoonID) int64 {
+	return MaxInt(int64(w.gateReady[moonID].Sub(w.now).Seconds()), 0)
+}
+
+func (w *fakeJumpGateWrapper) JumpGateDestinations(origin MoonID) ([]MoonID, int64, error) {
+	if w.gateErr != nil {
+		return nil, 0, w.gateErr
+	}
+	if countdown := w.gateCountdown(origin); countdown > 0 {
+		return w.gateDests[origin], countdown, errors.New("jump gate is in recharge mode")
+	}
+	return w.gateDests[origin], 0, nil
+}
+
+func (w *fakeJumpGateWrapper) JumpGate(origin, dest MoonID, ships ShipsInfos) (bool, int64, error) {
+	if countdown := MaxInt(w.gateCountdown(origin), w.gateCountdown(dest)); countdown > 0 {
+		return false, countdown, errors.New("jump gate is in recharge mode")
+	}
+	w.gateReady[origin] = w.now.Add(time.Hour)
+	w.gateReady[dest] = w.now.Add(time.Hour)
+	w.jumps = append(w.jumps, [2]MoonID{origin, dest})
+	return true, 0, nil
+}
+
+func TestJumpGatePlanner(t *testing.T) {
+	w := newFakeJumpGateWrapper()
+	m1 := Moon{ID: 11, Coordinate: Coordinate{1, 100, 8, MoonType}}
+	m2 := Moon{ID: 12, Coordinate: Coordinate{3, 100, 8, MoonType}}
+	m3 := Moon{ID: 13, Coordinate: Coordinate{5, 200, 8, MoonType}}
+	planet := Planet{ID: 3, Coordinate: Coordinate{5, 200, 9, PlanetType}}
+	far := Planet{ID: 4, Coordinate: Coordinate{1, 101, 8, PlanetType}}
+	w.celestials = []Celestial{m1, m2, m3, planet, far}
+	w.gateDests[11] = []MoonID{12, 13}
+	w.gateDests[12] = []MoonID{11, 13}
+	w.gateDests[13] = []MoonID{11, 12}
+	w.gateReady[13] = w.now.Add(30 * time.Minute)
+	w.facilities.JumpGate = 1
+	ships := ShipsInfos{LargeCargo: 100}
+	p := NewJumpGatePlanner(w)
+
+	// Single jump, gates are ready
+	plan, err := p.Plan(m1, m2, ships)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(plan.Steps))
+	assert.Equal(t, JumpStep, plan.Steps[0].Type)
+	assert.Equal(t, w.now, plan.ArrivalTime)
+
+	// Waits for the destination gate to recharge, jumping through m2 would wait for m2 cooldown
+	plan, err = p.Plan(m1, m3, ships)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(plan.Steps))
+	assert.Equal(t, w.now.Add(30*time.Minute), plan.Steps[0].StartAt)
+
+	// Jump, then fly to the planet next to the moon
+	plan, err = p.Plan(m1, planet, ships)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(plan.Steps))
+	assert.Equal(t, JumpStep, plan.Steps[0].Type)
+	assert.Equal(t, m3.GetID(), plan.Steps[0].Dest.GetID())
+	assert.Equal(t, FlightStep, plan.Steps[1].Type)
+	assert.Equal(t, planet.GetID(), plan.Steps[1].Dest.GetID())
+	secs, fuel := w.FlightTime(m3.Coordinate, planet.Coordinate, HundredPercent, ships, Park)
+	assert.Equal(t, w.now.Add(30*time.Minute+time.Duration(secs)*time.Second), plan.ArrivalTime)
+	assert.Equal(t, fuel, plan.Fuel)
+
+	// Flying is faster than using the gates
+	plan, err = p.Plan(m1, far, ships)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(plan.Steps))
+	assert.Equal(t, FlightStep, plan.Steps[0].Type)
+
+	// Execution waits for the gate to recharge
+	plan, _ = p.Plan(m1, planet, ships)
+	p.sleep = func(d time.Duration) { w.now = w.now.Add(d) }
+	start := w.now
+	assert.NoError(t, p.Execute(plan))
+	assert.Equal(t, [][2]MoonID{{11, 13}}, w.jumps)
+	assert.Equal(t, start.Add(30*time.Minute), w.sentFleets[0].StartTime)
+	assert.Equal(t, Park, w.sentFleets[0].Mission)
+	assert.Equal(t, planet.Coordinate, w.sentFleets[0].Destination)
+}
+
+func TestJumpGatePlanner_Network(t *testing.T) {
+	w := newFakeJumpGateWrapper()
+	m1 := Moon{ID: 11, Coordinate: Coordinate{1, 100, 8, MoonType}}
+	m2 := Moon{ID: 12, Coordinate: Coordinate{3, 100, 8, MoonType}}
+	w.celestials = []Celestial{m1, m2}
+	w.gateReady[12] = w.now.Add(10 * time.Minute)
+	w.gateDests[11] = []MoonID{12}
+	p := NewJumpGatePlanner(w)
+
+	// Moons without jump gate are not part of the network
+	nodes, err := p.network()
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(nodes))
+
+	// Recharging gate does not display its destinations
+	w.facilities.JumpGate = 3
+	nodes, err = p.network()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(nodes))
+	assert.Equal(t, []MoonID{11}, nodes[12].dests)
+	assert.Equal(t, w.now.Add(10*time.Minute), nodes[12].readyAt)
+	assert.Equal(t, 29*time.Minute+24*time.Second, nodes[11].cooldown)
+	nodes, _ = p.SetCooldown(time.Hour).network()
+	assert.Equal(t, time.Hour, nodes[11].cooldown)
+
+	w.gateErr = errors.New("failed to fetch page")
+	_, err = p.network()
+	assert.Error(t, err)
+	_, err = p.Plan(m1, m2, ShipsInfos{LargeCargo: 1})
+	assert.Error(t, err)
+}
+
+func TestJumpGateCooldown(t *testing.T) {
+	assert.Equal(t, time.Hour, JumpGateCooldown(0))
+	assert.Equal(t, time.Hour, JumpGateCooldown(1))
+	assert.Equal(t, 42*time.Minute, JumpGateCooldown(2))
+}
@@ -0,0 +1,206 @@
+package ogame
+
+import (
+	"errors"
+	"strconv"
+)
+
+// MarketplaceItemType kind of goods traded on the marketplace
+type MarketplaceItemType int64
+
+// Marketplace item types
+const (
+	ShipsMarketplaceItemType     MarketplaceItemType = 1
+	ResourcesMarketplaceItemType MarketplaceItemType = 2
+	ItemsMarketplaceItemType     MarketplaceItemType = 3
+)
+
+// MarketplaceResource resource used to pay on the marketplace
+type MarketplaceResource int64
+
+// Marketplace resources
+const (
+	MarketplaceMetal     MarketplaceResource = 1
+	MarketplaceCrystal   MarketplaceResource = 2
+	MarketplaceDeuterium MarketplaceResource = 3
+)
+
+// MarketplaceTab tab of the marketplace listing
+type MarketplaceTab string
+
+// Marketplace tabs
+const (
+	MarketplaceBuyingTab  MarketplaceTab = "buying"  // Offers of other players we can buy
+	MarketplaceSellingTab MarketplaceTab = "selling" // Requests of other players we can sell to
+)
+
+// MarketplaceOfferType whether we create an offer to buy or to sell
+type MarketplaceOfferType int64
+
+// Marketplace offer types
+const (
+	MarketplaceBuyOffer  MarketplaceOfferType = 3
+	MarketplaceSellOffer MarketplaceOfferType = 4
+)
+
+// MarketplaceItem goods traded on the marketplace
+type MarketplaceItem struct {
+	Type MarketplaceItemType
+	ID   ID     // Ship id, or resource id (1: metal, 2: crystal, 3: deuterium)
+	Ref  string // Item hash
+}
+
+// MarketplaceShip returns a ship marketplace item
+func MarketplaceShip(id ID) MarketplaceItem {
+	return MarketplaceItem{Type: ShipsMarketplaceItemType, ID: id}
+}
+
+// MarketplaceResources returns a resource marketplace item
+func MarketplaceResources(resource MarketplaceResource) MarketplaceItem {
+	return MarketplaceItem{Type: ResourcesMarketplaceItemType, ID: ID(resource)}
+}
+
+// MarketplaceItemRef returns an item marketplace item
+func MarketplaceItemRef(ref string) MarketplaceItem {
+	return MarketplaceItem{Type: ItemsMarketplaceItemType, Ref: ref}
+}
+
+// Validate checks the item can be traded on the marketplace
+func (i MarketplaceItem) Validate() error {
+	switch i.Type {
+	case ShipsMarketplaceItemType:
+		if !i.ID.IsShip() {
+			return errors.New("invalid ship id " + strconv.FormatInt(int64(i.ID), 10))
+		}
+	case ResourcesMarketplaceItemType:
+		if i.ID < 1 || i.ID > 3 {
+			return errors.New("invalid resource id " + strconv.FormatInt(int64(i.ID), 10))
+		}
+	case ItemsMarketplaceItemType:
+		if len(i.Ref) != 40 {
+			return errors.New("invalid item ref " + i.Ref)
+		}
+	default:
+		return errors.New("invalid item type " + strconv.FormatInt(int64(i.Type), 10))
+	}
+	return nil
+}
+
+// itemID returns the value of the itemId field of the marketplace forms
+func (i MarketplaceItem) itemID() string {
+	if i.Type == ItemsMarketplaceItemType {
+		return i.Ref
+	}
+	return strconv.FormatInt(int64(i.ID), 10)
+}
+
+// toMarketplaceItem converts the untyped item id of OfferBuyMarketplace/OfferSellMarketplace.
+// A 40 characters string is an item hash, 1-3 are resources, ship ids are ships.
+func toMarketplaceItem(itemID interface{}) (MarketplaceItem, error) {
+	switch v := itemID.(type) {
+	case string:
+		if len(v) == 40 {
+			return MarketplaceItemRef(v), nil
+		}
+		return MarketplaceItem{}, errors.New("invalid itemID string")
+	case int64:
+		if v >= 1 && v <= 3 {
+			return MarketplaceResources(MarketplaceResource(v)), nil
+		} else if ID(v).IsShip() {
+			return MarketplaceShip(ID(v)), nil
+		}
+		return MarketplaceItem{}, errors.New("invalid itemID int64")
+	case int:
+		if v >= 1 && v <= 3 {
+			return MarketplaceResources(MarketplaceResource(v)), nil
+		} else if ID(v).IsShip() {
+			return MarketplaceShip(ID(v)), nil
+		}
+		return MarketplaceItem{}, errors.New("invalid itemID int")
+	case ID:
+		if v.IsShip() {
+			return MarketplaceShip(v), nil
+		}
+		return MarketplaceItem{}, errors.New("invalid itemID ID")
+	}
+	return MarketplaceItem{}, errors.New("invalid itemID type")
+}
+
+// MarketplaceOfferRequest offer to create on the marketplace
+type MarketplaceOfferRequest struct {
+	Type        MarketplaceOfferType
+	Item        MarketplaceItem
+	Quantity    int64
+	PriceType   MarketplaceResource // Resource asked or paid in exchange
+	Price       int64               // Total price of the offer
+	PriceRange  int64               // Accepted price deviation, in percent
+	CelestialID CelestialID         // Celestial the goods are taken from or delivered to
+}
+
+// Validate checks the request before it is sent
+func (r MarketplaceOfferRequest) Validate() error {
+	if r.Type != MarketplaceBuyOffer && r.Type != MarketplaceSellOffer {
+		return errors.New("invalid offer type")
+	}
+	if err := r.Item.Validate(); err != nil {
+		return err
+	}
+	if r.Quantity <= 0 {
+		return errors.New("invalid quantity")
+	}
+	if r.PriceType < MarketplaceMetal || r.PriceType > MarketplaceDeuterium {
+		return errors.New("invalid price type")
+	}
+	if r.Price <= 0 {
+		return errors.New("invalid price")
+	}
+	return nil
+}
+
+// MarketplaceOffer offer listed on the marketplace
+type MarketplaceOffer struct {
+	ID           int64
+	Item         MarketplaceItem
+	Quantity     int64
+	PriceType    MarketplaceResource
+	Price        int64
+	PricePerUnit float64
+	Player       string // Seller of the buying tab offers, buyer of the selling tab requests
+	Own          bool   // Offer created by us
+	Cancelable   bool
+	Token        string // Token needed to cancel our own offer
+}
+
+// MarketplaceFilter filters applied to the marketplace listing
+type MarketplaceFilter struct {
+	Tab             MarketplaceTab      // Buying tab by default
+	ItemType        MarketplaceItemType // Any type if not set
+	ItemID          ID                  // Any ship/resource if not set
+	ItemRef         string              // Any item if not set
+	PriceType       MarketplaceResource // Any resource if not set
+	MinPricePerUnit float64             // No limit if not set
+	MaxPricePerUnit float64             // No limit if not set
+}
+
+// Match returns either or not the offer passes the filter
+func (f MarketplaceFilter) Match(offer MarketplaceOffer) bool {
+	if f.ItemType != 0 && offer.Item.Type != f.ItemType {
+		return false
+	}
+	if f.ItemID != 0 && offer.Item.ID != f.ItemID {
+		return false
+	}
+	if f.ItemRef != "" && offer.Item.Ref != f.ItemRef {
+		return false
+	}
+	if f.PriceType != 0 && offer.PriceType != f.PriceType {
+		return false
+	}
+	if f.MinPricePerUnit > 0 && offer.PricePerUnit < f.MinPricePerUnit {
+		return false
+	}
+	if f.MaxPricePerUnit > 0 && offer.PricePerUnit > f.MaxPricePerUnit {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,544 @@
+package ogame
+
+import (
+	"errors"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MarketplaceTradeType ...
+type MarketplaceTradeType string
+
+// Marketplace trade types
+const (
+	MarketplacePurchase MarketplaceTradeType = "purchase"
+	MarketplaceSale     MarketplaceTradeType = "sale"
+)
+
+// MarketplaceTrade completed trade, from the marketplace purchases and sales messages
+type MarketplaceTrade struct {
+	MessageID int64
+	Type      MarketplaceTradeType
+	Item      MarketplaceItem
+	ItemName  string
+	Quantity  int64
+	PriceType MarketplaceResource
+	Price     int64 // Resources received for a sale (market fee deducted), paid for a purchase
+	Fee       int64
+	CreatedAt time.Time
+}
+
+var (
+	marketplaceGoodsRgx = regexp.MustCompile(`You (?:sold|bought): ([\d.,]+) ([^\n]+)`)
+	marketplacePriceRgx = regexp.MustCompile(`You (?:received|paid):? ([\d.,]+) (Metal|Crystal|Deuterium)`)
+	marketplaceFeeRgx   = regexp.MustCompile(`Market Fee: ([\d.,]+) (Metal|Crystal|Deuterium)`)
+)
+
+// marketplaceResourceByName ...
+func marketplaceResourceByName(name string) MarketplaceResource {
+	switch name {
+	case "Metal":
+		return MarketplaceMetal
+	case "Crystal":
+		return MarketplaceCrystal
+	case "Deuterium":
+		return MarketplaceDeuterium
+	}
+	return 0
+}
+
+// ParseMarketplaceTrade default parser, based on the english marketplace messages
+func ParseMarketplaceTrade(msg MarketplaceMessage) (MarketplaceTrade, bool) {
+	trade := MarketplaceTrade{MessageID: msg.ID, CreatedAt: msg.CreatedAt}
+	switch msg.Type {
+	case 26:
+		trade.Type = MarketplacePurchase
+	case 27:
+		trade.Type = MarketplaceSale
+	default:
+		return trade, false
+	}
+	m := marketplaceGoodsRgx.FindStringSubmatch(msg.Content)
+	if len(m) != 3 {
+		return trade, false
+	}
+	trade.Quantity = ParseInt(m[1])
+	trade.ItemName = strings.TrimSpace(m[2])
+	if resource := marketplaceResourceByName(trade.ItemName); resource != 0 {
+		trade.Item = MarketplaceResources(resource)
+	} else {
+		trade.Item = MarketplaceItem{Type: ItemsMarketplaceItemType}
+		for _, ship := range Ships {
+			if strings.EqualFold(ship.GetName(), trade.ItemName) {
+				trade.Item = MarketplaceShip(ship.GetID())
+				break
+			}
+		}
+	}
+	if m := marketplacePriceRgx.FindStringSubmatch(msg.Content); len(m) == 3 {
+		trade.Price = ParseInt(m[1])
+		trade.PriceType = marketplaceResourceByName(m[2])
+	}
+	if m := marketplaceFeeRgx.FindStringSubmatch(msg.Content); len(m) == 3 {
+		trade.Fee = ParseInt(m[1])
+	}
+	return trade, true
+}
+
+// MarketplaceLedger profit and loss of the completed trades
+type MarketplaceLedger struct {
+	Trades    []MarketplaceTrade
+	Resources Resources  // Resources gained (or lost if negative), including the resources traded as goods
+	Ships     ShipsInfos // Ships bought minus ships sold
+	Items     int64      // Items bought minus items sold
+	Fees      Resources
+}
+
+func (l *MarketplaceLedger) add(trade MarketplaceTrade) {
+	sign := int64(1)
+	if trade.Type == MarketplaceSale {
+		sign = -1
+	}
+	switch trade.Item.Type {
+	case ResourcesMarketplaceItemType:
+		l.Resources = l.Resources.Add(marketplaceResources(MarketplaceResource(trade.Item.ID), sign*trade.Quantity))
+	case ShipsMarketplaceItemType:
+		l.Ships.Set(trade.Item.ID, l.Ships.ByID(trade.Item.ID)+sign*trade.Quantity)
+	default:
+		l.Items += sign * trade.Quantity
+	}
+	l.Resources = l.Resources.Add(marketplaceResources(trade.PriceType, -sign*trade.Price))
+	l.Fees = l.Fees.Add(marketplaceResources(trade.PriceType, trade.Fee))
+	l.Trades = append(l.Trades, trade)
+}
+
+// marketplaceResources returns amount of the resource
+func marketplaceResources(resource MarketplaceResource, amount int64) Resources {
+	switch resource {
+	case MarketplaceMetal:
+		return Resources{Metal: amount}
+	case MarketplaceCrystal:
+		return Resources{Crystal: amount}
+	case MarketplaceDeuterium:
+		return Resources{Deuterium: amount}
+	}
+	return Resources{}
+}
+
+// MarketplacePricePoint unit prices, in metal, of the offers of an item at a sample time
+type MarketplacePricePoint struct {
+	At     time.Time
+	Min    float64
+	Median float64
+	Max    float64
+	Offers int
+}
+
+// MarketplacePriceBand fair unit price range, in metal, of an item
+type MarketplacePriceBand struct {
+	Low  float64 // First quartile of the sampled medians
+	Fair float64 // Median of the sampled medians
+	High float64 // Third quartile of the sampled medians
+}
+
+// MarketplaceTrader samples the marketplace prices, buys underpriced offers, relists our surplus above market
+// and keeps a ledger of the completed trades
+type MarketplaceTrader struct {
+	sync.Mutex
+	b            Wrapper
+	celestial    Celestial
+	ratios       TradeRatios
+	historySize  int
+	minSamples   int
+	watched      map[MarketplaceItem]bool
+	buyThreshold float64
+	budget       Resources
+	surplus      map[MarketplaceItem]int64
+	markup       float64
+	priceRange   int64
+	autoCollect  bool
+	parser       func(MarketplaceMessage) (MarketplaceTrade, bool)
+	history      map[MarketplaceItem][]MarketplacePricePoint
+	bought       map[int64]bool
+	seenMessages map[int64]bool
+	ledger       MarketplaceLedger
+}
+
+// NewMarketplaceTrader ...
+func NewMarketplaceTrader(b Wrapper, celestial interface{}) *MarketplaceTrader {
+	t := new(MarketplaceTrader)
+	t.b = b
+	t.celestial = b.GetCachedCelestial(celestial)
+	t.ratios = DefaultTradeRatios
+	t.historySize = 48
+	t.minSamples = 3
+	t.buyThreshold = 0.8
+	t.markup = 1.1
+	t.autoCollect = true
+	t.parser = ParseMarketplaceTrade
+	t.surplus = make(map[MarketplaceItem]int64)
+	t.history = make(map[MarketplaceItem][]MarketplacePricePoint)
+	t.bought = make(map[int64]bool)
+	t.seenMessages = make(map[int64]bool)
+	return t
+}
+
+// SetRates sets the exchange ratios used to compare prices (3:2:1 by default)
+func (t *MarketplaceTrader) SetRates(ratios TradeRatios) *MarketplaceTrader {
+	t.ratios = ratios
+	return t
+}
+
+// rate returns the value in metal of one unit of a marketplace resource
+func (t *MarketplaceTrader) rate(resource MarketplaceResource) float64 {
+	switch resource {
+	case MarketplaceCrystal:
+		return t.ratios.MSU(Resources{Crystal: 1})
+	case MarketplaceDeuterium:
+		return t.ratios.MSU(Resources{Deuterium: 1})
+	}
+	return t.ratios.MSU(Resources{Metal: 1})
+}
+
+// SetHistorySize sets the number of samples kept per item
+func (t *MarketplaceTrader) SetHistorySize(historySize int) *MarketplaceTrader {
+	t.historySize = historySize
+	return t
+}
+
+// SetMinSamples sets the number of samples needed before trading an item
+func (t *MarketplaceTrader) SetMinSamples(minSamples int) *MarketplaceTrader {
+	t.minSamples = minSamples
+	return t
+}
+
+// Watch restricts the items bought (every item by default)
+func (t *MarketplaceTrader) Watch(items ...MarketplaceItem) *MarketplaceTrader {
+	t.watched = make(map[MarketplaceItem]bool)
+	for _, item := range items {
+		t.watched[item] = true
+	}
+	return t
+}
+
+// SetBuyThreshold buys the offers priced below threshold times the fair price (0.8 by default)
+func (t *MarketplaceTrader) SetBuyThreshold(buyThreshold float64) *MarketplaceTrader {
+	t.buyThreshold = buyThreshold
+	return t
+}
+
+// SetBudget sets the resources the trader can spend on purchases, nothing is bought without budget
+func (t *MarketplaceTrader) SetBudget(budget Resources) *MarketplaceTrader {
+	t.budget = budget
+	return t
+}
+
+// SetSurplus relists the quantity of a ship or resource above keep
+func (t *MarketplaceTrader) SetSurplus(item MarketplaceItem, keep int64) *MarketplaceTrader {
+	t.surplus[item] = keep
+	return t
+}
+
+// SetMarkup sets the ratio of the fair price our surplus is listed at (1.1 by default)
+func (t *MarketplaceTrader) SetMarkup(markup float64) *MarketplaceTrader {
+	t.markup = markup
+	return t
+}
+
+// SetPriceRange sets the price range of our sell offers
+func (t *MarketplaceTrader) SetPriceRange(priceRange int64) *MarketplaceTrader {
+	t.priceRange = priceRange
+	return t
+}
+
+// SetAutoCollect collects the goods and resources of the completed trades (true by default)
+func (t *MarketplaceTrader) SetAutoCollect(autoCollect bool) *MarketplaceTrader {
+	t.autoCollect = autoCollect
+	return t
+}
+
+// SetParser sets the function used to read the marketplace messages (english by default)
+func (t *MarketplaceTrader) SetParser(parser func(MarketplaceMessage) (MarketplaceTrade, bool)) *MarketplaceTrader {
+	t.parser = parser
+	return t
+}
+
+// UnitPrice returns the unit price of an offer, in metal
+func (t *MarketplaceTrader) UnitPrice(offer MarketplaceOffer) float64 {
+	if offer.Quantity <= 0 || offer.PriceType < MarketplaceMetal || offer.PriceType > MarketplaceDeuterium {
+		return 0
+	}
+	return float64(offer.Price) * t.rate(offer.PriceType) / float64(offer.Quantity)
+}
+
+// History returns the sampled prices of an item, oldest first
+func (t *MarketplaceTrader) History(item MarketplaceItem) []MarketplacePricePoint {
+	t.Lock()
+	defer t.Unlock()
+	return append([]MarketplacePricePoint{}, t.history[item]...)
+}
+
+// Band returns the fair price band of an item, false if there are not enough samples
+func (t *MarketplaceTrader) Band(item MarketplaceItem) (MarketplacePriceBand, bool) {
+	t.Lock()
+	defer t.Unlock()
+	return t.band(item)
+}
+
+func (t *MarketplaceTrader) band(item MarketplaceItem) (MarketplacePriceBand, bool) {
+	history := t.history[item]
+	if len(history) == 0 || len(history) < t.minSamples {
+		return MarketplacePriceBand{}, false
+	}
+	medians := make([]float64, len(history))
+	for i, point := range history {
+		medians[i] = point.Median
+	}
+	sort.Float64s(medians)
+	return MarketplacePriceBand{Low: quantile(medians, 0.25), Fair: quantile(medians, 0.5), High: quantile(medians, 0.75)}, true
+}
+
+// quantile returns the q quantile of sorted values, interpolating between the closest ranks
+func quantile(sorted []float64, q float64) float64 {
+	pos := q * float64(len(sorted)-1)
+	i := int(pos)
+	if i+1 >= len(sorted) {
+		return sorted[len(sorted)-1]
+	}
+	return sorted[i] + (sorted[i+1]-sorted[i])*(pos-float64(i))
+}
+
+// Ledger returns the completed trades and their profit and loss
+func (t *MarketplaceTrader) Ledger() MarketplaceLedger {
+	t.Lock()
+	defer t.Unlock()
+	ledger := t.ledger
+	ledger.Trades = append([]MarketplaceTrade{}, t.ledger.Trades...)
+	return ledger
+}
+
+// Sample records the current prices of the buying tab offers, returns the offers
+func (t *MarketplaceTrader) Sample() ([]MarketplaceOffer, error) {
+	t.Lock()
+	defer t.Unlock()
+	return t.sample()
+}
+
+func (t *MarketplaceTrader) sample() ([]MarketplaceOffer, error) {
+	offers, err := t.b.GetMarketplaceOffers(MarketplaceFilter{Tab: MarketplaceBuyingTab})
+	if err != nil {
+		return nil, err
+	}
+	prices := make(map[MarketplaceItem][]float64)
+	for _, offer := range offers {
+		if offer.Own {
+			continue
+		}
+		if price := t.UnitPrice(offer); price > 0 {
+			prices[offer.Item] = append(prices[offer.Item], price)
+		}
+	}
+	now := t.b.ServerTime()
+	for item, itemPrices := range prices {
+		sort.Float64s(itemPrices)
+		point := MarketplacePricePoint{At: now, Min: itemPrices[0], Median: quantile(itemPrices, 0.5),
+			Max: itemPrices[len(itemPrices)-1], Offers: len(itemPrices)}
+		history := append(t.history[item], point)
+		if len(history) > t.historySize {
+			history = history[len(history)-t.historySize:]
+		}
+		t.history[item] = history
+	}
+	return offers, nil
+}
+
+// Reconcile adds the completed trades of the purchases and sales messages to the ledger
+func (t *MarketplaceTrader) Reconcile() error {
+	t.Lock()
+	defer t.Unlock()
+	return t.reconcile()
+}
+
+func (t *MarketplaceTrader) reconcile() error {
+	msgs, err := t.b.GetMarketplaceMessages()
+	if err != nil {
+		return err
+	}
+	sort.SliceStable(msgs, func(i, j int) bool { return msgs[i].CreatedAt.Before(msgs[j].CreatedAt) })
+	for _, msg := range msgs {
+		if t.seenMessages[msg.ID] {
+			continue
+		}
+		t.seenMessages[msg.ID] = true
+		if trade, ok := t.parser(msg); ok {
+			t.ledger.add(trade)
+		}
+	}
+	if t.autoCollect {
+		return t.b.CollectAllMarketplaceMessages()
+	}
+	return nil
+}
+
+// buy buys the offers priced below the threshold, cheapest first, within the budget
+func (t *MarketplaceTrader) buy(offers []MarketplaceOffer) ([]MarketplaceOffer, error) {
+	type candidate struct {
+		offer MarketplaceOffer
+		ratio float64
+	}
+	candidates := make([]candidate, 0)
+	for _, offer := range offers {
+		if offer.Own || t.bought[offer.ID] || (t.watched != nil && !t.watched[offer.Item]) {
+			continue
+		}
+		band, ok := t.band(offer.Item)
+		if !ok || band.Fair <= 0 {
+			continue
+		}
+		ratio := t.UnitPrice(offer) / band.Fair
+		if ratio > 0 && ratio <= t.buyThreshold {
+			candidates = append(candidates, candidate{offer: offer, ratio: ratio})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].ratio < candidates[j].ratio })
+	bought := make([]MarketplaceOffer, 0)
+	for _, c := range candidates {
+		cost := marketplaceResources(c.offer.PriceType, c.offer.Price)
+		if !t.budget.CanAfford(cost) {
+			continue
+		}
+		if err := t.b.BuyMarketplace(c.offer.ID, t.celestial.GetID()); err != nil {
+			return bought, err
+		}
+		t.bought[c.offer.ID] = true
+		t.budget = t.budget.Sub(cost)
+		bought = append(bought, c.offer)
+	}
+	return bought, nil
+}
+
+// relist lists the surplus ships and resources not already offered, above the fair price
+func (t *MarketplaceTrader) relist() ([]MarketplaceOfferRequest, error) {
+	listed := make([]MarketplaceOfferRequest, 0)
+	if len(t.surplus) == 0 {
+		return listed, nil
+	}
+	myOffers, err := t.b.GetMyMarketplaceOffers()
+	if err != nil {
+		return listed, err
+	}
+	offered := make(map[MarketplaceItem]bool)
+	for _, offer := range myOffers {
+		offered[offer.Item] = true
+	}
+	ships, err := t.b.GetShips(t.celestial.GetID())
+	if err != nil {
+		return listed, err
+	}
+	resources, err := t.b.GetResources(t.celestial.GetID())
+	if err != nil {
+		return listed, err
+	}
+	items := make([]MarketplaceItem, 0, len(t.surplus))
+	for item := range t.surplus {
+		items = append(items, item)
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Type < items[j].Type || (items[i].Type == items[j].Type && items[i].ID < items[j].ID)
+	})
+	for _, item := range items {
+		if offered[item] {
+			continue
+		}
+		var owned int64
+		priceType := MarketplaceMetal
+		switch item.Type {
+		case ShipsMarketplaceItemType:
+			owned = ships.ByID(item.ID)
+		case ResourcesMarketplaceItemType:
+			owned = marketplaceOwned(resources, MarketplaceResource(item.ID))
+			if MarketplaceResource(item.ID) == MarketplaceMetal {
+				priceType = MarketplaceCrystal
+			}
+		default:
+			continue
+		}
+		quantity := owned - t.surplus[item]
+		band, ok := t.band(item)
+		if quantity <= 0 || !ok {
+			continue
+		}
+		unitPrice := band.Fair * t.markup
+		if unitPrice < band.High {
+			unitPrice = band.High
+		}
+		price := int64(unitPrice * float64(quantity) / t.rate(priceType))
+		if price <= 0 {
+			continue
+		}
+		req := MarketplaceOfferRequest{Type: MarketplaceSellOffer, Item: item, Quantity: quantity, PriceType: priceType,
+			Price: price, PriceRange: t.priceRange, CelestialID: t.celestial.GetID()}
+		if err := t.b.CreateMarketplaceOffer(req); err != nil {
+			return listed, err
+		}
+		listed = append(listed, req)
+	}
+	return listed, nil
+}
+
+func marketplaceOwned(resources Resources, resource MarketplaceResource) int64 {
+	switch resource {
+	case MarketplaceMetal:
+		return resources.Metal
+	case MarketplaceCrystal:
+		return resources.Crystal
+	case MarketplaceDeuterium:
+		return resources.Deuterium
+	}
+	return 0
+}
+
+// MarketplaceTick what the trader did during a tick
+type MarketplaceTick struct {
+	Bought []MarketplaceOffer
+	Listed []MarketplaceOfferRequest
+}
+
+// Tick samples the prices, reconciles the completed trades, buys the underpriced offers and relists the surplus
+func (t *MarketplaceTrader) Tick() (MarketplaceTick, error) {
+	t.Lock()
+	defer t.Unlock()
+	var tick MarketplaceTick
+	if t.celestial == nil {
+		return tick, errors.New("invalid celestial")
+	}
+	offers, err := t.sample()
+	if err != nil {
+		return tick, err
+	}
+	if err := t.reconcile(); err != nil {
+		return tick, err
+	}
+	if tick.Bought, err = t.buy(offers); err != nil {
+		return tick, err
+	}
+	tick.Listed, err = t.relist()
+	return tick, err
+}
+
+// Run calls Tick every interval until stop is closed
+func (t *MarketplaceTrader) Run(interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		_, _ = t.Tick()
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
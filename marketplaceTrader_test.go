@@ -0,0 +1,144 @@
+package ogame
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMarketplaceTrade(t *testing.T) {
+	trade, ok := ParseMarketplaceTrade(MarketplaceMessage{ID: 1, Type: 27,
+		Content: "Your trade is complete.\nYou sold: 100 Metal\nYou received 54 Crystal on Homeworld [4:116:12].\nMarket Fee: 6 Crystal"})
+	assert.True(t, ok)
+	assert.Equal(t, MarketplaceSale, trade.Type)
+	assert.Equal(t, MarketplaceResources(MarketplaceMetal), trade.Item)
+	assert.Equal(t, int64(100), trade.Quantity)
+	assert.Equal(t, MarketplaceCrystal, trade.PriceType)
+	assert.Equal(t, int64(54), trade.Price)
+	assert.Equal(t, int64(6), trade.Fee)
+
+	trade, ok = ParseMarketplaceTrade(MarketplaceMessage{ID: 2, Type: 26,
+		Content: "Your trade is complete.\nYou bought: 1.000 Small Cargo\nYou paid 2.000.000 Metal"})
+	assert.True(t, ok)
+	assert.Equal(t, MarketplacePurchase, trade.Type)
+	assert.Equal(t, MarketplaceShip(SmallCargoID), trade.Item)
+	assert.Equal(t, int64(1000), trade.Quantity)
+	assert.Equal(t, int64(2000000), trade.Price)
+
+	_, ok = ParseMarketplaceTrade(MarketplaceMessage{ID: 3, Type: 27, Content: "Your offer has expired."})
+	assert.False(t, ok)
+}
+
+type fakeMarketplaceWrapper struct {
+	*fakeFleets
+	mpOffers    []MarketplaceOffer
+	mpMyOffers  []MarketplaceOffer
+	mpMsgs      []MarketplaceMessage
+	mpBought    []int64
+	mpCollected int
+}
+
+func (w *fakeMarketplaceWrapper) GetMarketplaceOffers(filter MarketplaceFilter) ([]MarketplaceOffer, error) {
+	offers := make([]MarketplaceOffer, 0)
+	for _, offer := range w.mpOffers {
+		if filter.Match(offer) {
+			offers = append(offers, offer)
+		}
+	}
+	return offers, nil
+}
+
+func (w *fakeMarketplaceWrapper) GetMyMarketplaceOffers() ([]MarketplaceOffer, error) {
+	return w.mpMyOffers, nil
+}
+func (w *fakeMarketplaceWrapper) GetMarketplaceMessages() ([]MarketplaceMessage, error) {
+	return w.mpMsgs, nil
+}
+
+func (w *fakeMarketplaceWrapper) CollectAllMarketplaceMessages() error {
+	w.mpCollected++
+	return nil
+}
+
+func (w *fakeMarketplaceWrapper) BuyMarketplace(itemID int64, celestialID CelestialID) error {
+	w.mpBought = append(w.mpBought, itemID)
+	return nil
+}
+
+func (w *fakeMarketplaceWrapper) CreateMarketplaceOffer(offer MarketplaceOfferRequest) error {
+	w.mpMyOffers = append(w.mpMyOffers, MarketplaceOffer{Item: offer.Item, Quantity: offer.Quantity, PriceType: offer.PriceType, Price: offer.Price, Own: true})
+	return nil
+}
+
+func TestMarketplaceTrader_SetRates(t *testing.T) {
+	offer := MarketplaceOffer{Quantity: 10, PriceType: MarketplaceCrystal, Price: 40000}
+	tr := NewMarketplaceTrader(newFakeBot(), nil)
+	assert.Equal(t, float64(6000), tr.UnitPrice(offer))
+	assert.Equal(t, tr.UnitPrice(offer), tr.SetRates(TradeRatios{Metal: 3, Crystal: 2, Deuterium: 1}).UnitPrice(offer))
+	offer.PriceType = MarketplaceDeuterium
+	assert.Equal(t, float64(12000), tr.UnitPrice(offer))
+	assert.Equal(t, float64(8000), tr.SetRates(TradeRatios{Metal: 2, Crystal: 1, Deuterium: 1}).UnitPrice(offer))
+}
+
+func TestMarketplaceTrader(t *testing.T) {
+	w := &fakeMarketplaceWrapper{fakeFleets: newFakeFleets()}
+	planet := Planet{ID: 1, Coordinate: Coordinate{1, 100, 8, PlanetType}}
+	w.celestials = []Celestial{planet}
+	w.ships[planet.GetID()] = ShipsInfos{LargeCargo: 150}
+	w.resources = Resources{Deuterium: 1000000}
+	tr := NewMarketplaceTrader(w, planet).
+		SetMinSamples(3).
+		SetBudget(Resources{Metal: 1000000}).
+		SetSurplus(MarketplaceShip(LargeCargoID), 100).
+		SetSurplus(MarketplaceResources(MarketplaceDeuterium), 2000000)
+
+	// Large cargo around 6000 metal per unit, deuterium around 3 crystal per unit
+	w.mpOffers = []MarketplaceOffer{
+		{ID: 1, Item: MarketplaceShip(LargeCargoID), Quantity: 10, PriceType: MarketplaceMetal, Price: 60000},
+		{ID: 2, Item: MarketplaceShip(LargeCargoID), Quantity: 10, PriceType: MarketplaceCrystal, Price: 40000},
+		{ID: 3, Item: MarketplaceResources(MarketplaceDeuterium), Quantity: 100, PriceType: MarketplaceCrystal, Price: 200},
+		{ID: 4, Item: MarketplaceShip(LargeCargoID), Quantity: 1, PriceType: MarketplaceMetal, Price: 1000, Own: true},
+	}
+	for i := 0; i < 2; i++ {
+		tick, err := tr.Tick()
+		assert.NoError(t, err)
+		assert.Equal(t, 0, len(tick.Bought))
+		assert.Equal(t, 0, len(tick.Listed))
+		w.now = w.now.Add(time.Hour)
+	}
+	assert.Equal(t, 2, len(tr.History(MarketplaceShip(LargeCargoID))))
+	_, ok := tr.Band(MarketplaceShip(LargeCargoID))
+	assert.False(t, ok)
+
+	// Underpriced offers are bought within the budget, surplus is relisted above market
+	w.mpOffers = append(w.mpOffers,
+		MarketplaceOffer{ID: 5, Item: MarketplaceShip(LargeCargoID), Quantity: 100, PriceType: MarketplaceMetal, Price: 400000},
+		MarketplaceOffer{ID: 6, Item: MarketplaceShip(LargeCargoID), Quantity: 200, PriceType: MarketplaceMetal, Price: 800000})
+	w.mpMsgs = []MarketplaceMessage{
+		{ID: 10, Type: 27, CreatedAt: w.now, Content: "You sold: 100 Metal\nYou received 54 Crystal on Homeworld [1:100:8].\nMarket Fee: 6 Crystal"},
+		{ID: 11, Type: 26, CreatedAt: w.now, Content: "You bought: 10 Large Cargo\nYou paid 50.000 Metal"},
+	}
+	tick, err := tr.Tick()
+	assert.NoError(t, err)
+	band, ok := tr.Band(MarketplaceShip(LargeCargoID))
+	assert.True(t, ok)
+	assert.Equal(t, 6000.0, band.Fair)
+	assert.Equal(t, []int64{5}, w.mpBought)
+	assert.Equal(t, 1, len(tick.Bought))
+	assert.Equal(t, []MarketplaceOfferRequest{{Type: MarketplaceSellOffer, Item: MarketplaceShip(LargeCargoID), Quantity: 50,
+		PriceType: MarketplaceMetal, Price: 330000, CelestialID: planet.GetID()}}, tick.Listed)
+	assert.Equal(t, 3, w.mpCollected)
+
+	ledger := tr.Ledger()
+	assert.Equal(t, 2, len(ledger.Trades))
+	assert.Equal(t, Resources{Metal: -100 - 50000, Crystal: 54}, ledger.Resources)
+	assert.Equal(t, int64(10), ledger.Ships.LargeCargo)
+	assert.Equal(t, Resources{Crystal: 6}, ledger.Fees)
+
+	// Messages are reconciled once, the surplus already listed is not relisted
+	tick, err = tr.Tick()
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(tick.Listed))
+	assert.Equal(t, 2, len(tr.Ledger().Trades))
+}
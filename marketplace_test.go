@@ -0,0 +1,44 @@
+package ogame
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToMarketplaceItem(t *testing.T) {
+	item, err := toMarketplaceItem(int64(2))
+	assert.NoError(t, err)
+	assert.Equal(t, MarketplaceResources(MarketplaceCrystal), item)
+	item, err = toMarketplaceItem(LightFighterID)
+	assert.NoError(t, err)
+	assert.Equal(t, MarketplaceShip(LightFighterID), item)
+	item, err = toMarketplaceItem("de922af379061263a56d7204d1c395cefcfb7d75")
+	assert.NoError(t, err)
+	assert.Equal(t, "de922af379061263a56d7204d1c395cefcfb7d75", item.itemID())
+	_, err = toMarketplaceItem(int64(RocketLauncherID))
+	assert.Error(t, err)
+	_, err = toMarketplaceItem(1.5)
+	assert.Error(t, err)
+}
+
+func TestMarketplaceOfferRequestValidate(t *testing.T) {
+	req := MarketplaceOfferRequest{Type: MarketplaceSellOffer, Item: MarketplaceShip(LargeCargoID), Quantity: 10,
+		PriceType: MarketplaceMetal, Price: 100000}
+	assert.NoError(t, req.Validate())
+	req.Item = MarketplaceShip(RocketLauncherID)
+	assert.Error(t, req.Validate())
+	req.Item = MarketplaceResources(MarketplaceDeuterium)
+	req.PriceType = 4
+	assert.Error(t, req.Validate())
+}
+
+func TestMarketplaceFilterMatch(t *testing.T) {
+	offer := MarketplaceOffer{Item: MarketplaceShip(SmallCargoID), PriceType: MarketplaceMetal, Quantity: 10, Price: 30000, PricePerUnit: 3000}
+	assert.True(t, MarketplaceFilter{}.Match(offer))
+	assert.True(t, MarketplaceFilter{ItemType: ShipsMarketplaceItemType, ItemID: SmallCargoID, MaxPricePerUnit: 3000}.Match(offer))
+	assert.False(t, MarketplaceFilter{ItemType: ResourcesMarketplaceItemType}.Match(offer))
+	assert.False(t, MarketplaceFilter{PriceType: MarketplaceCrystal}.Match(offer))
+	assert.False(t, MarketplaceFilter{MaxPricePerUnit: 2999}.Match(offer))
+	assert.False(t, MarketplaceFilter{MinPricePerUnit: 3001}.Match(offer))
+}
@@ -0,0 +1,279 @@
+package ogame
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// MessagesTab tab or subtab of the messages page
+type MessagesTab int64
+
+// Messages tabs
+const (
+	CommunicationMessagesTab        MessagesTab = 1
+	FleetsMessagesTab               MessagesTab = 2
+	EconomyMessagesTab              MessagesTab = 3
+	UniverseMessagesTab             MessagesTab = 4
+	SystemMessagesTab               MessagesTab = 5 // OGame news and system messages
+	FavoritesMessagesTab            MessagesTab = 6
+	PlayerMessagesTab               MessagesTab = 10 // Player and alliance messages
+	InformationMessagesTab          MessagesTab = 11
+	SharedCombatReportsMessagesTab  MessagesTab = 12
+	SharedEspionageMessagesTab      MessagesTab = 13
+	EspionageMessagesTab            MessagesTab = 20
+	CombatReportsMessagesTab        MessagesTab = 21
+	ExpeditionsMessagesTab          MessagesTab = 22
+	TransportMessagesTab            MessagesTab = 23 // Unions/Transport
+	OtherMessagesTab                MessagesTab = 24 // Colonization, harvesting, missile attacks...
+	MarketplacePurchasesMessagesTab MessagesTab = 26
+	MarketplaceSalesMessagesTab     MessagesTab = 27
+)
+
+// MessageType kind of message, detected from the tab and the english title
+type MessageType string
+
+// Message types
+const (
+	UnknownMessage          MessageType = ""
+	PlayerMessage           MessageType = "player"
+	AllianceMessage         MessageType = "alliance"
+	EspionageReportMessage  MessageType = "espionageReport"
+	ForeignEspionageMessage MessageType = "foreignEspionage"
+	CombatReportMessage     MessageType = "combatReport"
+	ExpeditionMessageType   MessageType = "expedition"
+	TransportMessage        MessageType = "transport"
+	ColonizationMessage     MessageType = "colonization"
+	HarvestMessage          MessageType = "harvest"
+	MissileAttackMessage    MessageType = "missileAttack"
+	MarketplaceMessageType  MessageType = "marketplace"
+	SystemMessage           MessageType = "system"
+)
+
+// Message message of any tab of the messages page
+type Message struct {
+	ID         int64
+	Tab        MessagesTab
+	Type       MessageType
+	Title      string
+	Sender     string
+	SenderID   int64 // Player id of the player messages
+	Content    string
+	Coordinate Coordinate // Coordinate of the title link, if any
+	CreatedAt  time.Time
+	New        bool
+	Favorite   bool
+}
+
+var messageTitleTypes = []struct {
+	rgx *regexp.Regexp
+	typ MessageType
+}{
+	{regexp.MustCompile(`^Harvesting report`), HarvestMessage},
+	{regexp.MustCompile(`^Settlement Report`), ColonizationMessage},
+	{regexp.MustCompile(`^Missile attack`), MissileAttackMessage},
+	{regexp.MustCompile(`^Reaching a planet`), TransportMessage},
+	{regexp.MustCompile(`^Espionage action`), ForeignEspionageMessage},
+	{regexp.MustCompile(`^(Circular message|Alliance)`), AllianceMessage},
+}
+
+var messageTabTypes = map[MessagesTab]MessageType{
+	PlayerMessagesTab:               PlayerMessage,
+	SharedCombatReportsMessagesTab:  CombatReportMessage,
+	SharedEspionageMessagesTab:      EspionageReportMessage,
+	EspionageMessagesTab:            EspionageReportMessage,
+	CombatReportsMessagesTab:        CombatReportMessage,
+	ExpeditionsMessagesTab:          ExpeditionMessageType,
+	TransportMessagesTab:            TransportMessage,
+	MarketplacePurchasesMessagesTab: MarketplaceMessageType,
+	MarketplaceSalesMessagesTab:     MarketplaceMessageType,
+	SystemMessagesTab:               SystemMessage,
+}
+
+// detectMessageType returns the type of a message from its english title, or from its tab
+func detectMessageType(tab MessagesTab, title string) MessageType {
+	for _, t := range messageTitleTypes {
+		if t.rgx.MatchString(title) {
+			return t.typ
+		}
+	}
+	return messageTabTypes[tab]
+}
+
+// setMessagesTab sets the tab the messages were fetched from, the pages do not always tell it
+func setMessagesTab(msgs []Message, tab MessagesTab) {
+	for i := range msgs {
+		msgs[i].Tab = tab
+		if msgs[i].Type == UnknownMessage {
+			msgs[i].Type = detectMessageType(tab, msgs[i].Title)
+		}
+	}
+}
+
+var (
+	msgCoordRgx     = regexp.MustCompile(`(planet|moon) [^\[]*(\[\d+:\d+:\d+])`)
+	msgResourcesRgx = regexp.MustCompile(`Metal: ([\d.,]+)\s*Crystal: ([\d.,]+)\s*Deuterium: ([\d.,]+)`)
+)
+
+// messageCoords returns the planet and moon coordinates found in a message content, in order
+func messageCoords(content string) []Coordinate {
+	coords := make([]Coordinate, 0)
+	for _, m := range msgCoordRgx.FindAllStringSubmatch(content, -1) {
+		coord := extractCoordV6(m[2])
+		coord.Type = PlanetType
+		if m[1] == "moon" {
+			coord.Type = MoonType
+		}
+		coords = append(coords, coord)
+	}
+	return coords
+}
+
+// TransportReport resources delivered by one of our fleets
+type TransportReport struct {
+	Origin      Coordinate
+	Destination Coordinate
+	Resources   Resources
+}
+
+// ParseTransportReport parses an english transport message
+func ParseTransportReport(msg Message) (TransportReport, error) {
+	if msg.Type != TransportMessage {
+		return TransportReport{}, errors.New("not a transport message")
+	}
+	coords := messageCoords(msg.Content)
+	m := msgResourcesRgx.FindStringSubmatch(msg.Content)
+	if len(coords) != 2 || len(m) != 4 {
+		return TransportReport{}, errors.New("failed to parse transport message")
+	}
+	return TransportReport{
+		Origin:      coords[0],
+		Destination: coords[1],
+		Resources:   Resources{Metal: ParseInt(m[1]), Crystal: ParseInt(m[2]), Deuterium: ParseInt(m[3])},
+	}, nil
+}
+
+// HarvestReport resources harvested from a debris field
+type HarvestReport struct {
+	Coordinate  Coordinate
+	Ships       int64 // Recyclers or pathfinders
+	Capacity    int64
+	DebrisField Resources // Resources in the debris field before harvesting
+	Harvested   Resources
+}
+
+var (
+	harvestShipsRgx     = regexp.MustCompile(`Your ([\d.,]+) \S+ have a total cargo capacity of ([\d.,]+)`)
+	harvestSplitRgx     = regexp.MustCompile(`You have harvested`)
+	harvestMetalRgx     = regexp.MustCompile(`([\d.,]+) Metal`)
+	harvestCrystalRgx   = regexp.MustCompile(`([\d.,]+) Crystal`)
+	harvestDeuteriumRgx = regexp.MustCompile(`([\d.,]+) Deuterium`)
+)
+
+// harvestResources parses "1.000 Metal, 2.000 Crystal and 0 Deuterium"
+func harvestResources(str string) Resources {
+	amount := func(rgx *regexp.Regexp) int64 {
+		m := rgx.FindStringSubmatch(str)
+		if len(m) != 2 {
+			return 0
+		}
+		return ParseInt(m[1])
+	}
+	return Resources{Metal: amount(harvestMetalRgx), Crystal: amount(harvestCrystalRgx), Deuterium: amount(harvestDeuteriumRgx)}
+}
+
+// ParseHarvestReport parses an english harvesting report
+func ParseHarvestReport(msg Message) (HarvestReport, error) {
+	if msg.Type != HarvestMessage {
+		return HarvestReport{}, errors.New("not a harvest message")
+	}
+	m := harvestShipsRgx.FindStringSubmatch(msg.Content)
+	parts := harvestSplitRgx.Split(msg.Content, 2)
+	if len(m) != 3 || len(parts) != 2 {
+		return HarvestReport{}, errors.New("failed to parse harvest message")
+	}
+	coord := extractCoordV6(msg.Title)
+	coord.Type = DebrisType
+	return HarvestReport{
+		Coordinate:  coord,
+		Ships:       ParseInt(m[1]),
+		Capacity:    ParseInt(m[2]),
+		DebrisField: harvestResources(parts[0]),
+		Harvested:   harvestResources(parts[1]),
+	}, nil
+}
+
+// ColonizationReport result of a colonization
+type ColonizationReport struct {
+	Coordinate Coordinate
+	Success    bool
+}
+
+// ParseColonizationReport parses an english settlement report
+func ParseColonizationReport(msg Message) (ColonizationReport, error) {
+	if msg.Type != ColonizationMessage {
+		return ColonizationReport{}, errors.New("not a colonization message")
+	}
+	coord := extractCoordV6(msg.Content)
+	if coord.Galaxy == 0 {
+		return ColonizationReport{}, errors.New("failed to parse colonization message")
+	}
+	coord.Type = PlanetType
+	return ColonizationReport{Coordinate: coord, Success: strings.Contains(msg.Content, "found a new planet")}, nil
+}
+
+// MissileAttackReport interplanetary missiles attack, sent by us or received
+type MissileAttackReport struct {
+	Origin      Coordinate
+	Destination Coordinate
+	Missiles    int64
+	Intercepted int64
+}
+
+var (
+	missilesRgx    = regexp.MustCompile(`([\d.,]+) missile\(s\) from`)
+	interceptedRgx = regexp.MustCompile(`([\d.,]+) missile\(s\) were destroyed`)
+)
+
+// ParseMissileAttackReport parses an english missile attack message
+func ParseMissileAttackReport(msg Message) (MissileAttackReport, error) {
+	if msg.Type != MissileAttackMessage {
+		return MissileAttackReport{}, errors.New("not a missile attack message")
+	}
+	coords := messageCoords(msg.Content)
+	m := missilesRgx.FindStringSubmatch(msg.Content)
+	if len(coords) != 2 || len(m) != 2 {
+		return MissileAttackReport{}, errors.New("failed to parse missile attack message")
+	}
+	report := MissileAttackReport{Origin: coords[0], Destination: coords[1], Missiles: ParseInt(m[1])}
+	if m := interceptedRgx.FindStringSubmatch(msg.Content); len(m) == 2 {
+		report.Intercepted = ParseInt(m[1])
+	}
+	return report, nil
+}
+
+// ForeignEspionageReport foreign fleet spying on one of our celestials
+type ForeignEspionageReport struct {
+	Origin                 Coordinate
+	Destination            Coordinate
+	CounterEspionageChance int64 // In percent
+}
+
+var counterEspionageRgx = regexp.MustCompile(`Chance of counter-espionage: (\d+)\s?%`)
+
+// ParseForeignEspionageReport parses an english espionage action message
+func ParseForeignEspionageReport(msg Message) (ForeignEspionageReport, error) {
+	if msg.Type != ForeignEspionageMessage {
+		return ForeignEspionageReport{}, errors.New("not a foreign espionage message")
+	}
+	coords := messageCoords(msg.Content)
+	if len(coords) != 2 {
+		return ForeignEspionageReport{}, errors.New("failed to parse foreign espionage message")
+	}
+	report := ForeignEspionageReport{Origin: coords[0], Destination: coords[1]}
+	if m := counterEspionageRgx.FindStringSubmatch(msg.Content); len(m) == 2 {
+		report.CounterEspionageChance = ParseInt(m[1])
+	}
+	return report, nil
+}
@@ -0,0 +1,83 @@
+package ogame
+
+import (
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTransportReport(t *testing.T) {
+	pageHTMLBytes, _ := ioutil.ReadFile("samples/v7.2/en/messages_transport.html")
+	msgs, _, _ := NewExtractorV7().ExtractMessages(pageHTMLBytes, time.UTC)
+	report, err := ParseTransportReport(msgs[0])
+	assert.NoError(t, err)
+	assert.Equal(t, TransportReport{
+		Origin:      Coordinate{1, 42, 8, PlanetType},
+		Destination: Coordinate{1, 44, 6, PlanetType},
+		Resources:   Resources{Metal: 125000, Crystal: 60500, Deuterium: 12345},
+	}, report)
+	report, _ = ParseTransportReport(msgs[1])
+	assert.Equal(t, Coordinate{1, 44, 6, MoonType}, report.Origin)
+	assert.Equal(t, Resources{Crystal: 1000000}, report.Resources)
+
+	_, err = ParseHarvestReport(msgs[0])
+	assert.Error(t, err)
+}
+
+func TestParseOtherReports(t *testing.T) {
+	pageHTMLBytes, _ := ioutil.ReadFile("samples/v7.2/en/messages_other.html")
+	msgs, _, _ := NewExtractorV7().ExtractMessages(pageHTMLBytes, time.UTC)
+
+	harvest, err := ParseHarvestReport(msgs[0])
+	assert.NoError(t, err)
+	assert.Equal(t, HarvestReport{
+		Coordinate:  Coordinate{2, 120, 16, DebrisType},
+		Ships:       25,
+		Capacity:    500000,
+		DebrisField: Resources{Metal: 350000, Crystal: 120000},
+		Harvested:   Resources{Metal: 350000, Crystal: 120000},
+	}, harvest)
+
+	colonization, err := ParseColonizationReport(msgs[1])
+	assert.NoError(t, err)
+	assert.Equal(t, ColonizationReport{Coordinate: Coordinate{2, 121, 8, PlanetType}, Success: true}, colonization)
+	colonization, _ = ParseColonizationReport(msgs[2])
+	assert.False(t, colonization.Success)
+
+	missiles, err := ParseMissileAttackReport(msgs[3])
+	assert.NoError(t, err)
+	assert.Equal(t, MissileAttackReport{
+		Origin:      Coordinate{2, 120, 8, PlanetType},
+		Destination: Coordinate{2, 118, 4, PlanetType},
+		Missiles:    12,
+		Intercepted: 3,
+	}, missiles)
+}
+
+func TestParseForeignEspionageReport(t *testing.T) {
+	msg := Message{Type: ForeignEspionageMessage, Content: "A foreign fleet from planet Homerworld [4:184:10] was sighted near your planet Homeworld [4:212:8]. Chance of counter-espionage: 7 %"}
+	report, err := ParseForeignEspionageReport(msg)
+	assert.NoError(t, err)
+	assert.Equal(t, ForeignEspionageReport{
+		Origin:                 Coordinate{4, 184, 10, PlanetType},
+		Destination:            Coordinate{4, 212, 8, PlanetType},
+		CounterEspionageChance: 7,
+	}, report)
+}
+
+func TestDetectMessageType(t *testing.T) {
+	assert.Equal(t, HarvestMessage, detectMessageType(FavoritesMessagesTab, "Harvesting report from DF on [1:2:16]"))
+	assert.Equal(t, PlayerMessage, detectMessageType(PlayerMessagesTab, "Hello"))
+	assert.Equal(t, UnknownMessage, detectMessageType(OtherMessagesTab, "Something new"))
+}
+
+func TestSetMessagesTab(t *testing.T) {
+	msgs := []Message{{Title: "Hello"}, {Title: "Espionage action", Type: ForeignEspionageMessage, Tab: 20}}
+	setMessagesTab(msgs, PlayerMessagesTab)
+	assert.Equal(t, PlayerMessagesTab, msgs[0].Tab)
+	assert.Equal(t, PlayerMessage, msgs[0].Type)
+	assert.Equal(t, PlayerMessagesTab, msgs[1].Tab)
+	assert.Equal(t, ForeignEspionageMessage, msgs[1].Type)
+}
@@ -1787,67 +1787,9 @@ LOOP:
 				b.error("unknown message received:", buf)
 				continue
 			}
-			if name, ok := out[0].(string); ok {
-				arg := out[1]
-				if name == "new bid" {
-					if firstArg, ok := arg.(map[string]interface{}); ok {
-						auctionID, _ := strconv.ParseInt(doCastStr(firstArg["auctionId"]), 10, 64)
-						pck1 := AuctioneerNewBid{
-							Sum:       int64(doCastF64(firstArg["sum"])),
-							Price:     int64(doCastF64(firstArg["price"])),
-							Bids:      int64(doCastF64(firstArg["bids"])),
-							AuctionID: auctionID,
-						}
-						if player, ok := firstArg["player"].(map[string]interface{}); ok {
-							pck1.Player.ID = int64(doCastF64(player["id"]))
-							pck1.Player.Name = doCastStr(player["name"])
-							pck1.Player.Link = doCastStr(player["link"])
-						}
-						pck = pck1
-					}
-				} else if name == "timeLeft" {
-					if timeLeftMsg, ok := arg.(string); ok {
-						if strings.Contains(timeLeftMsg, "color:") {
-							doc, _ := goquery.NewDocumentFromReader(strings.NewReader(timeLeftMsg))
-							rgx := regexp.MustCompile(`\d+`)
-							txt := rgx.FindString(doc.Find("b").Text())
-							approx, _ := strconv.ParseInt(txt, 10, 64)
-							pck = AuctioneerTimeRemaining{Approx: approx * 60}
-						} else if strings.Contains(timeLeftMsg, "nextAuction") {
-							doc, _ := goquery.NewDocumentFromReader(strings.NewReader(timeLeftMsg))
-							rgx := regexp.MustCompile(`\d+`)
-							txt := rgx.FindString(doc.Find("span").Text())
-							secs, _ := strconv.ParseInt(txt, 10, 64)
-							pck = AuctioneerNextAuction{Secs: secs}
-						}
-					}
-				} else if name == "new auction" {
-					if firstArg, ok := arg.(map[string]interface{}); ok {
-						pck1 := AuctioneerNewAuction{
-							AuctionID: int64(doCastF64(firstArg["auctionId"])),
-						}
-						if infoMsg, ok := firstArg["info"].(string); ok {
-							doc, _ := goquery.NewDocumentFromReader(strings.NewReader(infoMsg))
-							rgx := regexp.MustCompile(`\d+`)
-							txt := rgx.FindString(doc.Find("b").Text())
-							approx, _ := strconv.ParseInt(txt, 10, 64)
-							pck1.Approx = approx * 60
-						}
-						pck = pck1
-					}
-				} else if name == "auction finished" {
-					if firstArg, ok := arg.(map[string]interface{}); ok {
-						pck1 := AuctioneerAuctionFinished{
-							Sum:  int64(doCastF64(firstArg["sum"])),
-							Bids: int64(doCastF64(firstArg["bids"])),
-						}
-						if player, ok := firstArg["player"].(map[string]interface{}); ok {
-							pck1.Player.ID = int64(doCastF64(player["id"]))
-							pck1.Player.Name = doCastStr(player["name"])
-							pck1.Player.Link = doCastStr(player["link"])
-						}
-						pck = pck1
-					}
+			if name, ok := out[0].(string); ok && len(out) > 1 {
+				if evt, ok := parseAuctioneerEvent(name, out[1]); ok {
+					pck = evt
 				}
 			}
 			for _, clb := range b.auctioneerCallbacks {
@@ -1940,67 +1882,10 @@ LOOP:
 			var pck interface{} = string(msg)
 			var out map[string]interface{}
 			_ = json.Unmarshal(msg, &out)
-			if args, ok := out["args"].([]interface{}); ok {
-				if len(args) > 0 {
-					if name, ok := out["name"].(string); ok && name == "new bid" {
-						if firstArg, ok := args[0].(map[string]interface{}); ok {
-							auctionID, _ := strconv.ParseInt(doCastStr(firstArg["auctionId"]), 10, 64)
-							pck1 := AuctioneerNewBid{
-								Sum:       int64(doCastF64(firstArg["sum"])),
-								Price:     int64(doCastF64(firstArg["price"])),
-								Bids:      int64(doCastF64(firstArg["bids"])),
-								AuctionID: auctionID,
-							}
-							if player, ok := firstArg["player"].(map[string]interface{}); ok {
-								pck1.Player.ID = int64(doCastF64(player["id"]))
-								pck1.Player.Name = doCastStr(player["name"])
-								pck1.Player.Link = doCastStr(player["link"])
-							}
-							pck = pck1
-						}
-					} else if name, ok := out["name"].(string); ok && name == "timeLeft" {
-						if timeLeftMsg, ok := args[0].(string); ok {
-							if strings.Contains(timeLeftMsg, "color:") {
-								doc, _ := goquery.NewDocumentFromReader(strings.NewReader(timeLeftMsg))
-								rgx := regexp.MustCompile(`\d+`)
-								txt := rgx.FindString(doc.Find("b").Text())
-								approx, _ := strconv.ParseInt(txt, 10, 64)
-								pck = AuctioneerTimeRemaining{Approx: approx * 60}
-							} else if strings.Contains(timeLeftMsg, "nextAuction") {
-								doc, _ := goquery.NewDocumentFromReader(strings.NewReader(timeLeftMsg))
-								rgx := regexp.MustCompile(`\d+`)
-								txt := rgx.FindString(doc.Find("span").Text())
-								secs, _ := strconv.ParseInt(txt, 10, 64)
-								pck = AuctioneerNextAuction{Secs: secs}
-							}
-						}
-					} else if name, ok := out["name"].(string); ok && name == "new auction" {
-						if firstArg, ok := args[0].(map[string]interface{}); ok {
-							pck1 := AuctioneerNewAuction{
-								AuctionID: int64(doCastF64(firstArg["auctionId"])),
-							}
-							if infoMsg, ok := firstArg["info"].(string); ok {
-								doc, _ := goquery.NewDocumentFromReader(strings.NewReader(infoMsg))
-								rgx := regexp.MustCompile(`\d+`)
-								txt := rgx.FindString(doc.Find("b").Text())
-								approx, _ := strconv.ParseInt(txt, 10, 64)
-								pck1.Approx = approx * 60
-							}
-							pck = pck1
-						}
-					} else if name, ok := out["name"].(string); ok && name == "auction finished" {
-						if firstArg, ok := args[0].(map[string]interface{}); ok {
-							pck1 := AuctioneerAuctionFinished{
-								Sum:  int64(doCastF64(firstArg["sum"])),
-								Bids: int64(doCastF64(firstArg["bids"])),
-							}
-							if player, ok := firstArg["player"].(map[string]interface{}); ok {
-								pck1.Player.ID = int64(doCastF64(player["id"]))
-								pck1.Player.Name = doCastStr(player["name"])
-								pck1.Player.Link = doCastStr(player["link"])
-							}
-							pck = pck1
-						}
+			if args, ok := out["args"].([]interface{}); ok && len(args) > 0 {
+				if name, ok := out["name"].(string); ok {
+					if evt, ok := parseAuctioneerEvent(name, args[0]); ok {
+						pck = evt
 					}
 				}
 			}
@@ -2030,6 +1915,67 @@ LOOP:
 	}
 }
 
+var auctioneerNumberRgx = regexp.MustCompile(`\d+`)
+
+// parseAuctioneerEvent returns the typed event of an auctioneer packet
+func parseAuctioneerEvent(name string, arg interface{}) (interface{}, bool) {
+	parsePlayer := func(v interface{}) (player AuctioneerPlayer) {
+		if m, ok := v.(map[string]interface{}); ok {
+			player.ID = int64(doCastF64(m["id"]))
+			player.Name = doCastStr(m["name"])
+			player.Link = doCastStr(m["link"])
+		}
+		return
+	}
+	// "<b>approx. 30m</b>" -> 1800, "<span id=\"nextAuction\">117</span>" -> 117
+	parseInfo := func(info, selector string) int64 {
+		doc, _ := goquery.NewDocumentFromReader(strings.NewReader(info))
+		nbr, _ := strconv.ParseInt(auctioneerNumberRgx.FindString(doc.Find(selector).Text()), 10, 64)
+		return nbr
+	}
+	switch name {
+	case "new bid":
+		if firstArg, ok := arg.(map[string]interface{}); ok {
+			auctionID, _ := strconv.ParseInt(doCastStr(firstArg["auctionId"]), 10, 64)
+			return AuctioneerNewBid{
+				Sum:       int64(doCastF64(firstArg["sum"])),
+				Price:     int64(doCastF64(firstArg["price"])),
+				Bids:      int64(doCastF64(firstArg["bids"])),
+				AuctionID: auctionID,
+				Player:    parsePlayer(firstArg["player"]),
+			}, true
+		}
+	case "timeLeft":
+		if timeLeftMsg, ok := arg.(string); ok {
+			if strings.Contains(timeLeftMsg, "color:") {
+				return AuctioneerTimeRemaining{Approx: parseInfo(timeLeftMsg, "b") * 60}, true
+			} else if strings.Contains(timeLeftMsg, "nextAuction") {
+				return AuctioneerNextAuction{Secs: parseInfo(timeLeftMsg, "span")}, true
+			}
+		}
+	case "new auction":
+		if firstArg, ok := arg.(map[string]interface{}); ok {
+			evt := AuctioneerNewAuction{AuctionID: int64(doCastF64(firstArg["auctionId"]))}
+			evt.Approx = parseInfo(doCastStr(firstArg["info"]), "b") * 60
+			if item, ok := firstArg["item"].(map[string]interface{}); ok {
+				evt.Item = AuctioneerItem{UUID: doCastStr(item["uuid"]), Image: doCastStr(item["image"]), Rarity: doCastStr(item["rarity"])}
+			}
+			return evt, true
+		}
+	case "auction finished":
+		if firstArg, ok := arg.(map[string]interface{}); ok {
+			return AuctioneerAuctionFinished{
+				Sum:         int64(doCastF64(firstArg["sum"])),
+				Bids:        int64(doCastF64(firstArg["bids"])),
+				NextAuction: parseInfo(doCastStr(firstArg["info"]), "span"),
+				Time:        doCastStr(firstArg["time"]),
+				Player:      parsePlayer(firstArg["player"]),
+			}, true
+		}
+	}
+	return nil, false
+}
+
 func doCastF64(v interface{}) float64 {
 	if f, ok := v.(float64); ok {
 		return f
@@ -2044,24 +1990,35 @@ func doCastStr(v interface{}) string {
 	return ""
 }
 
+// AuctioneerPlayer player of the auctioneer events
+type AuctioneerPlayer struct {
+	ID   int64
+	Name string
+	Link string
+}
+
+// AuctioneerItem item of an auction
+type AuctioneerItem struct {
+	UUID   string
+	Image  string
+	Rarity string // common, rare, precious
+}
+
 // AuctioneerNewBid ...
 type AuctioneerNewBid struct {
-	Sum       int64
-	Price     int64
+	Sum       int64 // Total of the bids
+	Price     int64 // Minimum total bid to become the highest bidder
 	Bids      int64
 	AuctionID int64
-	Player    struct {
-		ID   int64
-		Name string
-		Link string
-	}
+	Player    AuctioneerPlayer
 }
 
 // AuctioneerNewAuction ...
 // 5::/auctioneer:{"name":"new auction","args":[{"info":"<span style=\"color:#99CC00;\"><b>approx. 45m</b></span> remaining until the auction ends","item":{"uuid":"118d34e685b5d1472267696d1010a393a59aed03","image":"bdb4508609de1df58bf4a6108fff73078c89f777","rarity":"rare"},"oldAuction":{"item":{"uuid":"8a4f9e8309e1078f7f5ced47d558d30ae15b4a1b","imageSmall":"014827f6d1d5b78b1edd0d4476db05639e7d9367","rarity":"rare"},"time":"06.01.2021 17:35:05","bids":1,"sum":1000,"player":{"id":111106,"name":"Governor Skat","link":"http://s152-en.ogame.gameforge.com/game/index.php?page=ingame&component=galaxy&galaxy=1&system=218"}},"auctionId":18550}]}
 type AuctioneerNewAuction struct {
 	AuctionID int64
-	Approx    int64
+	Approx    int64 // Approximate seconds left
+	Item      AuctioneerItem
 }
 
 // AuctioneerAuctionFinished ...
@@ -2069,13 +2026,9 @@ type AuctioneerNewAuction struct {
 type AuctioneerAuctionFinished struct {
 	Sum         int64
 	Bids        int64
-	NextAuction int64
+	NextAuction int64 // Seconds until the next auction
 	Time        string
-	Player      struct {
-		ID   int64
-		Name string
-		Link string
-	}
+	Player      AuctioneerPlayer
 }
 
 // AuctioneerTimeRemaining ...
@@ -2200,6 +2153,9 @@ func IsAjaxPage(vals url.Values) bool {
 		page == PlanetRenameAjaxPage ||
 		page == RightmenuAjaxPage ||
 		page == AllianceOverviewAjaxPage ||
+		page == AllianceManagementAjaxPage ||
+		page == AllianceApplicationsAjaxPage ||
+		page == AllianceBroadcastAjaxPage ||
 		page == SupportAjaxPage ||
 		page == BuffActivationAjaxPage ||
 		page == AuctioneerAjaxPage ||
@@ -2449,6 +2405,98 @@ func (b *OGame) getAlliancePageContent(vals url.Values) ([]byte, error) {
 	return b.execRequest("GET", finalURL, nil, vals)
 }
 
+func (b *OGame) getAllianceAjaxPage(page string) ([]byte, error) {
+	return b.getPageContent(url.Values{"page": {"ingame"}, "component": {page}, "ajax": {"1"}})
+}
+
+func (b *OGame) getAllianceOverview() (AllianceOverview, error) {
+	pageHTML, err := b.getAllianceAjaxPage(AllianceOverviewAjaxPage)
+	if err != nil {
+		return AllianceOverview{}, err
+	}
+	return b.extractor.ExtractAllianceOverview(pageHTML, b.location)
+}
+
+func (b *OGame) getAllianceRanks() ([]AllianceRank, error) {
+	pageHTML, err := b.getAllianceAjaxPage(AllianceManagementAjaxPage)
+	if err != nil {
+		return nil, err
+	}
+	return b.extractor.ExtractAllianceRanks(pageHTML)
+}
+
+func (b *OGame) getAllianceApplications() ([]AllianceApplication, error) {
+	pageHTML, err := b.getAllianceAjaxPage(AllianceApplicationsAjaxPage)
+	if err != nil {
+		return nil, err
+	}
+	return b.extractor.ExtractAllianceApplications(pageHTML, b.location)
+}
+
+func (b *OGame) getAlliancePublicInfo(allianceID int64) (AlliancePublicInfo, error) {
+	pageHTML, err := b.getAlliancePageContent(url.Values{"allianceId": {strconv.FormatInt(allianceID, 10)}})
+	if err != nil {
+		return AlliancePublicInfo{}, err
+	}
+	return b.extractor.ExtractAlliancePublicInfo(pageHTML)
+}
+
+// postAlliance posts an action to an alliance ajax page and checks the json response
+func (b *OGame) postAlliance(page, action string, payload url.Values) error {
+	pageHTML, err := b.getAllianceAjaxPage(page)
+	if err != nil {
+		return err
+	}
+	token, err := b.extractor.ExtractAllianceToken(pageHTML)
+	if err != nil {
+		return err
+	}
+	payload.Set("action", action)
+	payload.Set("token", token)
+	payload.Set("ajax", "1")
+	pageJSON, err := b.postPageContent(url.Values{"page": {"ingame"}, "component": {page}, "action": {action}, "ajax": {"1"}, "asJson": {"1"}}, payload)
+	if err != nil {
+		return err
+	}
+	var res struct {
+		Message string
+		Error   bool
+	}
+	if err := json.Unmarshal(pageJSON, &res); err != nil {
+		return err
+	}
+	if res.Error {
+		return errors.New(res.Message)
+	}
+	return nil
+}
+
+func (b *OGame) acceptAllianceApplication(applicationID int64) error {
+	return b.postAlliance(AllianceApplicationsAjaxPage, "acceptApplication", url.Values{"applicationId": {strconv.FormatInt(applicationID, 10)}})
+}
+
+func (b *OGame) denyAllianceApplication(applicationID int64, reason string) error {
+	return b.postAlliance(AllianceApplicationsAjaxPage, "denyApplication", url.Values{"applicationId": {strconv.FormatInt(applicationID, 10)}, "text": {reason}})
+}
+
+func (b *OGame) sendAllianceCircular(message string, rankIDs []int64) error {
+	payload := url.Values{"text": {message}}
+	if len(rankIDs) == 0 {
+		payload.Set("recipients", "all")
+	}
+	for _, rankID := range rankIDs {
+		payload.Add("ranks[]", strconv.FormatInt(rankID, 10))
+	}
+	return b.postAlliance(AllianceBroadcastAjaxPage, "sendBroadcast", payload)
+}
+
+func (b *OGame) setAllianceClass(class AllianceClass) error {
+	if class < Warrior || class > Researcher {
+		return errors.New("invalid alliance class")
+	}
+	return b.postAlliance(AllianceManagementAjaxPage, "selectClass", url.Values{"allianceClass": {strconv.FormatInt(int64(class), 10)}})
+}
+
 type eventboxResp struct {
 	Hostile  int
 	Neutral  int
@@ -2814,6 +2862,117 @@ func (b *OGame) sendMessage(id int64, message string, isPlayer bool) error {
 	return nil
 }
 
+func (b *OGame) getChatConversations() ([]ChatConversation, error) {
+	pageHTML, err := b.getPage(ChatPage, CelestialID(0))
+	if err != nil {
+		return nil, err
+	}
+	return b.extractor.ExtractChatConversations(pageHTML, b.location)
+}
+
+func (b *OGame) getChatHistory(id, lastMessageID int64, isPlayer, updateUnread bool) ([]ChatMsg, error) {
+	payload := url.Values{
+		"updateUnread": {"0"},
+		"ajax":         {"1"},
+		"token":        {b.ajaxChatToken},
+	}
+	if isPlayer {
+		payload.Set("playerId", strconv.FormatInt(id, 10))
+		payload.Set("mode", "2")
+	} else {
+		payload.Set("associationId", strconv.FormatInt(id, 10))
+		payload.Set("mode", "4")
+	}
+	if updateUnread {
+		payload.Set("updateUnread", "1")
+	}
+	if lastMessageID > 0 {
+		payload.Set("lastMessageId", strconv.FormatInt(lastMessageID, 10))
+	}
+	pageJSON, err := b.postPageContent(url.Values{"page": {"ajaxChat"}}, payload)
+	if err != nil {
+		return nil, err
+	}
+	if strings.Contains(string(pageJSON), "INVALID_PARAMETERS") {
+		return nil, errors.New("invalid parameters")
+	}
+	msgs, newToken, err := parseChatHistory(pageJSON)
+	if err != nil {
+		return nil, err
+	}
+	if newToken != "" {
+		b.ajaxChatToken = newToken
+	}
+	return msgs, nil
+}
+
+func (b *OGame) markChatAsRead(id int64, isPlayer bool) error {
+	_, err := b.getChatHistory(id, 0, isPlayer, true)
+	return err
+}
+
+func (b *OGame) getBuddies() ([]Buddy, error) {
+	pageHTML, err := b.getPage(BuddiesPage, CelestialID(0))
+	if err != nil {
+		return nil, err
+	}
+	return b.extractor.ExtractBuddies(pageHTML)
+}
+
+func (b *OGame) getIgnoredPlayers() ([]IgnoredPlayer, error) {
+	pageHTML, err := b.getPage(BuddiesPage, CelestialID(0))
+	if err != nil {
+		return nil, err
+	}
+	return b.extractor.ExtractIgnoredPlayers(pageHTML)
+}
+
+// postBuddies posts an action to the buddies page and checks the json response
+func (b *OGame) postBuddies(action string, payload url.Values) error {
+	pageHTML, err := b.getPage(BuddiesPage, CelestialID(0))
+	if err != nil {
+		return err
+	}
+	token, err := b.extractor.ExtractBuddiesToken(pageHTML)
+	if err != nil {
+		return err
+	}
+	payload.Set("action", action)
+	payload.Set("token", token)
+	payload.Set("ajax", "1")
+	pageJSON, err := b.postPageContent(url.Values{"page": {"ingame"}, "component": {BuddiesPage}, "action": {action}, "ajax": {"1"}, "asJson": {"1"}}, payload)
+	if err != nil {
+		return err
+	}
+	var res struct {
+		Message string
+		Error   bool
+	}
+	if err := json.Unmarshal(pageJSON, &res); err != nil {
+		return err
+	}
+	if res.Error {
+		return errors.New(res.Message)
+	}
+	return nil
+}
+
+func (b *OGame) addBuddy(playerID int64, message string) error {
+	return b.postBuddies("sendBuddyRequest", url.Values{"id": {strconv.FormatInt(playerID, 10)}, "text": {message}})
+}
+
+func (b *OGame) removeBuddy(buddyID int64) error {
+	return b.postBuddies("deleteBuddy", url.Values{"id": {strconv.FormatInt(buddyID, 10)}})
+}
+
+func (b *OGame) ignorePlayer(playerID int64) error {
+	return b.postBuddies("ignorePlayer", url.Values{"id": {strconv.FormatInt(playerID, 10)}})
+}
+
+func (b *OGame) unignorePlayer(playerID int64) error {
+	return b.postBuddies("removeIgnore", url.Values{"id": {strconv.FormatInt(playerID, 10)}})
+}
+
 func (b *OGame) getFleetsFromEventList() []Fleet {
 	pageHTML, _ := b.getPageContent(url.Values{"eventList": {"movement"}, "ajax": {"1"}})
 	return b.extractor.ExtractFleetsFromEventList(pageHTML)
@@ -3242,6 +3401,31 @@ func (b *OGame) useDM(typ string, celestialID CelestialID) error {
 	return nil
 }
 
+// marketplaceResponse json response of the marketplace actions
+type marketplaceResponse struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+	Errors  []struct {
+		Message string `json:"message"`
+		Error   int64  `json:"error"`
+	} `json:"errors"`
+}
+
+func (b *OGame) postMarketplace(params, payload url.Values) error {
+	by, err := b.postPageContent(params, payload)
+	if err != nil {
+		return err
+	}
+	var res marketplaceResponse
+	if err := json.Unmarshal(by, &res); err != nil {
+		return err
+	}
+	if len(res.Errors) > 0 {
+		return errors.New(strconv.FormatInt(res.Errors[0].Error, 10) + " : " + res.Errors[0].Message)
+	}
+	return nil
+}
+
 // marketItemType 3 -> offer buy
 // marketItemType 4 -> offer sell
 // itemID 1 -> metal
@@ -3250,53 +3434,28 @@ func (b *OGame) useDM(typ string, celestialID CelestialID) error {
 // itemID 204 -> light fighter
 // itemID <HASH> -> item
 func (b *OGame) offerMarketplace(marketItemType int64, itemID interface{}, quantity, priceType, price, priceRange int64, celestialID CelestialID) error {
-	params := url.Values{"page": {"ingame"}, "component": {"marketplace"}, "tab": {"create_offer"}, "action": {"submitOffer"}, "asJson": {"1"}}
-	if celestialID != 0 {
-		params.Set("cp", strconv.FormatInt(int64(celestialID), 10))
+	item, err := toMarketplaceItem(itemID)
+	if err != nil {
+		return err
 	}
-	const (
-		shipsItemType = iota + 1
-		resourcesItemType
-		itemItemType
-	)
-	var itemIDPayload string
-	var itemType int64
-	if itemIDStr, ok := itemID.(string); ok {
-		if len(itemIDStr) == 40 {
-			itemType = itemItemType
-			itemIDPayload = itemIDStr
-		} else {
-			return errors.New("invalid itemID string")
-		}
-	} else if itemIDInt64, ok := itemID.(int64); ok {
-		if itemIDInt64 >= 1 && itemIDInt64 <= 3 {
-			itemType = resourcesItemType
-			itemIDPayload = strconv.FormatInt(itemIDInt64, 10)
-		} else if ID(itemIDInt64).IsShip() {
-			itemType = shipsItemType
-			itemIDPayload = strconv.FormatInt(itemIDInt64, 10)
-		} else {
-			return errors.New("invalid itemID int64")
-		}
-	} else if itemIDInt, ok := itemID.(int); ok {
-		if itemIDInt >= 1 && itemIDInt <= 3 {
-			itemType = resourcesItemType
-			itemIDPayload = strconv.Itoa(itemIDInt)
-		} else if ID(itemIDInt).IsShip() {
-			itemType = shipsItemType
-			itemIDPayload = strconv.Itoa(itemIDInt)
-		} else {
-			return errors.New("invalid itemID int")
-		}
-	} else if itemIDID, ok := itemID.(ID); ok {
-		if itemIDID.IsShip() {
-			itemType = shipsItemType
-			itemIDPayload = strconv.FormatInt(int64(itemIDID), 10)
-		} else {
-			return errors.New("invalid itemID ID")
-		}
-	} else {
-		return errors.New("invalid itemID type")
+	return b.createMarketplaceOffer(MarketplaceOfferRequest{
+		Type:        MarketplaceOfferType(marketItemType),
+		Item:        item,
+		Quantity:    quantity,
+		PriceType:   MarketplaceResource(priceType),
+		Price:       price,
+		PriceRange:  priceRange,
+		CelestialID: celestialID,
+	})
+}
+
+func (b *OGame) createMarketplaceOffer(offer MarketplaceOfferRequest) error {
+	if err := offer.Validate(); err != nil {
+		return err
+	}
+	params := url.Values{"page": {"ingame"}, "component": {"marketplace"}, "tab": {"create_offer"}, "action": {"submitOffer"}, "asJson": {"1"}}
+	if offer.CelestialID != 0 {
+		params.Set("cp", strconv.FormatInt(int64(offer.CelestialID), 10))
 	}
 
 	vals := url.Values{
@@ -3318,63 +3477,141 @@ func (b *OGame) offerMarketplace(marketItemType int64, itemID interface{}, quant
 	token, _ := getToken(pageHTML)
 
 	payload := url.Values{
-		"marketItemType": {strconv.FormatInt(marketItemType, 10)},
-		"itemType":       {strconv.FormatInt(itemType, 10)},
-		"itemId":         {itemIDPayload},
-		"quantity":       {strconv.FormatInt(quantity, 10)},
-		"priceType":      {strconv.FormatInt(priceType, 10)},
-		"price":          {strconv.FormatInt(price, 10)},
-		"priceRange":     {strconv.FormatInt(priceRange, 10)},
+		"marketItemType": {strconv.FormatInt(int64(offer.Type), 10)},
+		"itemType":       {strconv.FormatInt(int64(offer.Item.Type), 10)},
+		"itemId":         {offer.Item.itemID()},
+		"quantity":       {strconv.FormatInt(offer.Quantity, 10)},
+		"priceType":      {strconv.FormatInt(int64(offer.PriceType), 10)},
+		"price":          {strconv.FormatInt(offer.Price, 10)},
+		"priceRange":     {strconv.FormatInt(offer.PriceRange, 10)},
 		"token":          {token},
 	}
-	var res struct {
-		Status  string `json:"status"`
-		Message string `json:"message"`
-		Errors  []struct {
-			Message string `json:"message"`
-			Error   int64  `json:"error"`
-		} `json:"errors"`
+	return b.postMarketplace(params, payload)
+}
+
+// acceptMarketplaceOffer buys an offer of the buying tab, or sells to a request of the selling tab
+func (b *OGame) acceptMarketplaceOffer(tab MarketplaceTab, itemID int64, celestialID CelestialID) error {
+	params := url.Values{"page": {"ingame"}, "component": {"marketplace"}, "tab": {string(tab)}, "action": {"acceptRequest"}, "asJson": {"1"}}
+	if celestialID != 0 {
+		params.Set("cp", strconv.FormatInt(int64(celestialID), 10))
 	}
-	by, err := b.postPageContent(params, payload)
+	payload := url.Values{
+		"marketItemId": {strconv.FormatInt(itemID, 10)},
+	}
+	return b.postMarketplace(params, payload)
+}
+
+func (b *OGame) buyMarketplace(itemID int64, celestialID CelestialID) error {
+	return b.acceptMarketplaceOffer(MarketplaceBuyingTab, itemID, celestialID)
+}
+
+func (b *OGame) sellMarketplace(itemID int64, celestialID CelestialID) error {
+	return b.acceptMarketplaceOffer(MarketplaceSellingTab, itemID, celestialID)
+}
+
+// getMarketplacePage returns the offers of a page of a marketplace listing, and the number of pages
+func (b *OGame) getMarketplacePage(tab, action string, page int64) ([]MarketplaceOffer, int64, error) {
+	params := url.Values{"page": {"ingame"}, "component": {"marketplace"}, "tab": {tab}, "action": {action},
+		"ajax": {"1"}, "pagination[page]": {strconv.FormatInt(page, 10)}}
+	by, err := b.getPageContent(params)
 	if err != nil {
-		return err
+		return nil, 0, err
+	}
+	var res struct {
+		Status  string            `json:"status"`
+		Content map[string]string `json:"content"`
 	}
 	if err := json.Unmarshal(by, &res); err != nil {
-		return err
+		return nil, 0, errors.New("failed to unmarshal json response: " + err.Error())
 	}
-	if len(res.Errors) > 0 {
-		return errors.New(strconv.FormatInt(res.Errors[0].Error, 10) + " : " + res.Errors[0].Message)
+	// The listing and the pagination come in separate content keys, join them in a stable order
+	keys := make([]string, 0, len(res.Content))
+	for key := range res.Content {
+		keys = append(keys, key)
 	}
-	return err
+	sort.Strings(keys)
+	var pageHTML string
+	for _, key := range keys {
+		pageHTML += res.Content[key]
+	}
+	return b.extractor.ExtractMarketplaceOffers([]byte(pageHTML))
 }
 
-func (b *OGame) buyMarketplace(itemID int64, celestialID CelestialID) (err error) {
-	params := url.Values{"page": {"ingame"}, "component": {"marketplace"}, "tab": {"buying"}, "action": {"acceptRequest"}, "asJson": {"1"}}
-	if celestialID != 0 {
-		params.Set("cp", strconv.FormatInt(int64(celestialID), 10))
+func (b *OGame) getMarketplaceListing(tab, action string) ([]MarketplaceOffer, error) {
+	var page int64 = 1
+	var nbPage int64 = 1
+	offers := make([]MarketplaceOffer, 0)
+	for page <= nbPage {
+		newOffers, newNbPage, err := b.getMarketplacePage(tab, action, page)
+		if err != nil {
+			return offers, err
+		}
+		offers = append(offers, newOffers...)
+		nbPage = newNbPage
+		page++
 	}
-	payload := url.Values{
-		"marketItemId": {strconv.FormatInt(itemID, 10)},
+	return offers, nil
+}
+
+func (b *OGame) getMarketplaceOffers(filter MarketplaceFilter) ([]MarketplaceOffer, error) {
+	var listing []MarketplaceOffer
+	var err error
+	switch filter.Tab {
+	case "", MarketplaceBuyingTab:
+		listing, err = b.getMarketplaceListing("buying", "fetchBuyingItems")
+	case MarketplaceSellingTab:
+		listing, err = b.getMarketplaceListing("selling", "fetchSellingItems")
+	default:
+		return nil, errors.New("invalid marketplace tab " + string(filter.Tab))
 	}
-	var res struct {
-		Status  string `json:"status"`
-		Message string `json:"message"`
-		Errors  []struct {
-			Message string `json:"message"`
-			Error   int64  `json:"error"`
-		} `json:"errors"`
+	offers := make([]MarketplaceOffer, 0)
+	for _, offer := range listing {
+		if filter.Match(offer) {
+			offers = append(offers, offer)
+		}
 	}
-	by, err := b.postPageContent(params, payload)
-	if err != nil {
-		return err
+	return offers, err
+}
+
+// getMyMarketplaceOffers returns our active offers, from our sales and purchases history
+func (b *OGame) getMyMarketplaceOffers() ([]MarketplaceOffer, error) {
+	offers := make([]MarketplaceOffer, 0)
+	for _, tab := range []string{"history_selling", "history_buying"} {
+		action := "fetchHistorySellingItems"
+		if tab == "history_buying" {
+			action = "fetchHistoryBuyingItems"
+		}
+		history, err := b.getMarketplaceListing(tab, action)
+		if err != nil {
+			return offers, err
+		}
+		for _, offer := range history {
+			if offer.Cancelable {
+				offer.Own = true
+				offers = append(offers, offer)
+			}
+		}
 	}
-	if err := json.Unmarshal(by, &res); err != nil {
+	return offers, nil
+}
+
+func (b *OGame) cancelMarketplaceOffer(offerID int64) error {
+	offers, err := b.getMyMarketplaceOffers()
+	if err != nil {
 		return err
 	}
-	if len(res.Errors) > 0 {
-		return errors.New(strconv.FormatInt(res.Errors[0].Error, 10) + " : " + res.Errors[0].Message)
+	for _, offer := range offers {
+		if offer.ID != offerID {
+			continue
+		}
+		params := url.Values{"page": {"componentOnly"}, "component": {"marketplace"}, "action": {"cancelItem"}, "asJson": {"1"}}
+		payload := url.Values{
+			"marketItemId": {strconv.FormatInt(offer.ID, 10)},
+			"token":        {offer.Token},
+		}
+		return b.postMarketplace(params, payload)
 	}
-	return err
+	return ErrMarketplaceOfferNotFound
 }
 
 func (b *OGame) getItems(celestialID CelestialID) (items []Item, err error) {
@@ -3677,6 +3914,77 @@ func (b *OGame) buyOfferOfTheDay() error {
 	return nil
 }
 
+func (b *OGame) getResourceMerchant(celestialID CelestialID) (ResourceMerchant, error) {
+	payload := url.Values{"show": {"resources"}, "ajax": {"1"}}
+	if celestialID != 0 {
+		payload.Set("cp", strconv.FormatInt(int64(celestialID), 10))
+	}
+	pageHTML, err := b.postPageContent(url.Values{"page": {"ajax"}, "component": {"traderresources"}}, payload)
+	if err != nil {
+		return ResourceMerchant{}, err
+	}
+	return b.extractor.ExtractResourceMerchant(pageHTML)
+}
+
+// postResourceMerchant posts an action to the resource market and checks the json response
+func (b *OGame) postResourceMerchant(action string, payload url.Values) error {
+	payload.Set("action", action)
+	payload.Set("ajax", "1")
+	pageHTML, err := b.postPageContent(url.Values{"page": {"ajax"}, "component": {"traderresources"}, "ajax": {"1"}, "action": {action}, "asJson": {"1"}}, payload)
+	if err != nil {
+		return err
+	}
+	var res struct {
+		Message string
+		Error   bool
+	}
+	if err := json.Unmarshal(pageHTML, &res); err != nil {
+		return err
+	}
+	if res.Error {
+		return errors.New(res.Message)
+	}
+	return nil
+}
+
+func (b *OGame) callResourceMerchant(celestialID CelestialID, resource MerchantResource) (ResourceMerchant, error) {
+	if !resource.IsValid() {
+		return ResourceMerchant{}, errors.New("invalid merchant resource " + string(resource))
+	}
+	merchant, err := b.getResourceMerchant(celestialID)
+	if err != nil {
+		return ResourceMerchant{}, err
+	}
+	if err := b.postResourceMerchant("callMerchant", url.Values{"type": {string(resource)}, "token": {merchant.Token}}); err != nil {
+		return ResourceMerchant{}, err
+	}
+	return b.getResourceMerchant(celestialID)
+}
+
+// tradeResourceMerchant pays the called merchant with the resources of celestialID.
+// The bid keys mirror the auctioneer bid payload; they are not verified against a merchant capture.
+func (b *OGame) tradeResourceMerchant(celestialID CelestialID, amount int64) (Resources, error) {
+	merchant, err := b.getResourceMerchant(celestialID)
+	if err != nil {
+		return Resources{}, err
+	}
+	payment, err := merchant.Payment(celestialID, amount)
+	if err != nil {
+		return Resources{}, err
+	}
+	celestialIDStr := strconv.FormatInt(int64(celestialID), 10)
+	payload := url.Values{
+		"bid[planets][" + celestialIDStr + "][metal]":     {strconv.FormatInt(payment.Metal, 10)},
+		"bid[planets][" + celestialIDStr + "][crystal]":   {strconv.FormatInt(payment.Crystal, 10)},
+		"bid[planets][" + celestialIDStr + "][deuterium]": {strconv.FormatInt(payment.Deuterium, 10)},
+		"token": {merchant.Token},
+	}
+	if err := b.postResourceMerchant("trade", payload); err != nil {
+		return Resources{}, err
+	}
+	return payment, nil
+}
+
 // Hack fix: When moon name is >12, the moon image disappear from the EventsBox
 // and attacks are detected on planet instead.
 func fixAttackEvents(attacks []AttackEvent, planets []Planet) {
@@ -4554,6 +4862,7 @@ type MarketplaceMessage struct {
 	CreatedAt           time.Time
 	Token               string
 	MarketTransactionID int64
+	Content             string
 }
 
 func (b *OGame) getPageMessages(page, tabid int64) ([]byte, error) {
@@ -4567,6 +4876,35 @@ func (b *OGame) getPageMessages(page, tabid int64) ([]byte, error) {
 	return b.postPageContent(url.Values{"page": {"messages"}}, payload)
 }
 
+func (b *OGame) getMessages(tab MessagesTab, page int64) ([]Message, int64, error) {
+	pageHTML, err := b.getPageMessages(page, int64(tab))
+	if err != nil {
+		return nil, 0, err
+	}
+	msgs, nbPage, err := b.extractor.ExtractMessages(pageHTML, b.location)
+	if err != nil {
+		return nil, 0, err
+	}
+	setMessagesTab(msgs, tab)
+	return msgs, nbPage, nil
+}
+
+func (b *OGame) getAllMessages(tab MessagesTab) ([]Message, error) {
+	var page int64 = 1
+	var nbPage int64 = 1
+	msgs := make([]Message, 0)
+	for page <= nbPage {
+		newMessages, newNbPage, err := b.getMessages(tab, page)
+		if err != nil {
+			return nil, err
+		}
+		msgs = append(msgs, newMessages...)
+		nbPage = newNbPage
+		page++
+	}
+	return msgs, nil
+}
+
 func (b *OGame) getEspionageReportMessages() ([]EspionageReportSummary, error) {
 	var tabid int64 = 20
 	var page int64 = 1
@@ -4664,6 +5002,19 @@ func (b *OGame) collectMarketplaceMessage(msg MarketplaceMessage, newToken strin
 	return res.NewToken, err
 }
 
+// getMarketplaceMessagesAll returns the purchases and sales messages
+func (b *OGame) getMarketplaceMessagesAll() ([]MarketplaceMessage, error) {
+	purchases, err := b.getMarketplacePurchasesMessages()
+	if err != nil {
+		return nil, err
+	}
+	sales, err := b.getMarketplaceSalesMessages()
+	if err != nil {
+		return nil, err
+	}
+	return append(purchases, sales...), nil
+}
+
 func (b *OGame) getMarketplacePurchasesMessages() ([]MarketplaceMessage, error) {
 	return b.getMarketplaceMessages(26)
 }
@@ -4767,13 +5118,26 @@ func (b *OGame) getDeleteMessagesToken() (string, error) {
 }
 
 func (b *OGame) deleteMessage(msgID int64) error {
+	return b.messageAction(msgID, "103")
+}
+
+func (b *OGame) favoriteMessage(msgID int64) error {
+	return b.messageAction(msgID, "101")
+}
+
+func (b *OGame) unfavoriteMessage(msgID int64) error {
+	return b.messageAction(msgID, "102")
+}
+
+// messageAction applies an action to a message (101: favorite, 102: unfavorite, 103: delete)
+func (b *OGame) messageAction(msgID int64, action string) error {
 	token, err := b.getDeleteMessagesToken()
 	if err != nil {
 		return err
 	}
 	payload := url.Values{
 		"messageId": {strconv.FormatInt(msgID, 10)},
-		"action":    {"103"},
+		"action":    {action},
 		"ajax":      {"1"},
 		"token":     {token},
 	}
@@ -5352,6 +5716,46 @@ func (b *OGame) GetAlliancePageContent(vals url.Values) ([]byte, error) {
 	return b.WithPriority(Normal).GetPageContent(vals)
 }
 
+// GetAllianceOverview gets our alliance and its members
+func (b *OGame) GetAllianceOverview() (AllianceOverview, error) {
+	return b.WithPriority(Normal).GetAllianceOverview()
+}
+
+// GetAllianceRanks gets the ranks of our alliance and their permissions
+func (b *OGame) GetAllianceRanks() ([]AllianceRank, error) {
+	return b.WithPriority(Normal).GetAllianceRanks()
+}
+
+// GetAllianceApplications gets the pending applications to our alliance
+func (b *OGame) GetAllianceApplications() ([]AllianceApplication, error) {
+	return b.WithPriority(Normal).GetAllianceApplications()
+}
+
+// AcceptAllianceApplication accepts an application to our alliance
+func (b *OGame) AcceptAllianceApplication(applicationID int64) error {
+	return b.WithPriority(Normal).AcceptAllianceApplication(applicationID)
+}
+
+// DenyAllianceApplication denies an application to our alliance
+func (b *OGame) DenyAllianceApplication(applicationID int64, reason string) error {
+	return b.WithPriority(Normal).DenyAllianceApplication(applicationID, reason)
+}
+
+// SendAllianceCircular sends a circular message to the members having one of rankIDs, to all members if empty
+func (b *OGame) SendAllianceCircular(message string, rankIDs []int64) error {
+	return b.WithPriority(Normal).SendAllianceCircular(message, rankIDs)
+}
+
+// SetAllianceClass selects the class of our alliance
+func (b *OGame) SetAllianceClass(class AllianceClass) error {
+	return b.WithPriority(Normal).SetAllianceClass(class)
+}
+
+// GetAlliancePublicInfo gets the public information of any alliance
+func (b *OGame) GetAlliancePublicInfo(allianceID int64) (AlliancePublicInfo, error) {
+	return b.WithPriority(Normal).GetAlliancePublicInfo(allianceID)
+}
+
 // GetPageContent gets the html for a specific ogame page
 func (b *OGame) GetPageContent(vals url.Values) ([]byte, error) {
 	return b.WithPriority(Normal).GetPageContent(vals)
@@ -5479,6 +5883,63 @@ func (b *OGame) SendMessageAlliance(associationID int64, message string) error {
 	return b.WithPriority(Normal).SendMessageAlliance(associationID, message)
 }
 
+// GetChatConversations gets the conversations listed on the chat page
+func (b *OGame) GetChatConversations() ([]ChatConversation, error) {
+	return b.WithPriority(Normal).GetChatConversations()
+}
+
+// GetChatHistory gets the messages of the conversation with playerID older than lastMessageID,
+// the most recent messages if lastMessageID is 0. Returns an empty slice when there are no more messages.
+func (b *OGame) GetChatHistory(playerID, lastMessageID int64) ([]ChatMsg, error) {
+	return b.WithPriority(Normal).GetChatHistory(playerID, lastMessageID)
+}
+
+// GetAllianceChatHistory gets the messages of the alliance chat older than lastMessageID,
+// the most recent messages if lastMessageID is 0
+func (b *OGame) GetAllianceChatHistory(associationID, lastMessageID int64) ([]ChatMsg, error) {
+	return b.WithPriority(Normal).GetAllianceChatHistory(associationID, lastMessageID)
+}
+
+// MarkChatAsRead marks the conversation with playerID as read
+func (b *OGame) MarkChatAsRead(playerID int64) error {
+	return b.WithPriority(Normal).MarkChatAsRead(playerID)
+}
+
+// MarkAllianceChatAsRead marks the alliance chat as read
+func (b *OGame) MarkAllianceChatAsRead(associationID int64) error {
+	return b.WithPriority(Normal).MarkAllianceChatAsRead(associationID)
+}
+
+// GetBuddies gets our buddy list
+func (b *OGame) GetBuddies() ([]Buddy, error) {
+	return b.WithPriority(Normal).GetBuddies()
+}
+
+// AddBuddy sends a buddy request to playerID
+func (b *OGame) AddBuddy(playerID int64, message string) error {
+	return b.WithPriority(Normal).AddBuddy(playerID, message)
+}
+
+// RemoveBuddy removes a buddy from our buddy list, buddyID is Buddy.ID
+func (b *OGame) RemoveBuddy(buddyID int64) error {
+	return b.WithPriority(Normal).RemoveBuddy(buddyID)
+}
+
+// GetIgnoredPlayers gets our ignore list
+func (b *OGame) GetIgnoredPlayers() ([]IgnoredPlayer, error) {
+	return b.WithPriority(Normal).GetIgnoredPlayers()
+}
+
+// IgnorePlayer adds playerID to our ignore list
+func (b *OGame) IgnorePlayer(playerID int64) error {
+	return b.WithPriority(Normal).IgnorePlayer(playerID)
+}
+
+// UnignorePlayer removes playerID from our ignore list
+func (b *OGame) UnignorePlayer(playerID int64) error {
+	return b.WithPriority(Normal).UnignorePlayer(playerID)
+}
+
 // GetFleets get the player's own fleets activities
 func (b *OGame) GetFleets(opts ...Option) ([]Fleet, Slots) {
 	return b.WithPriority(Normal).GetFleets(opts...)
@@ -5648,6 +6109,11 @@ func (b *OGame) SendIPM(planetID PlanetID, coord Coordinate, nbr int64, priority
 	return b.WithPriority(Normal).SendIPM(planetID, coord, nbr, priority)
 }
 
+// GetCombatReportMessages gets the summary of each combat reports
+func (b *OGame) GetCombatReportMessages() ([]CombatReportSummary, error) {
+	return b.WithPriority(Normal).GetCombatReportMessages()
+}
+
 // GetCombatReportSummaryFor gets the latest combat report for a given coordinate
 func (b *OGame) GetCombatReportSummaryFor(coord Coordinate) (CombatReportSummary, error) {
 	return b.WithPriority(Normal).GetCombatReportSummaryFor(coord)
@@ -5688,6 +6154,26 @@ func (b *OGame) GetEspionageReport(msgID int64) (EspionageReport, error) {
 	return b.WithPriority(Normal).GetEspionageReport(msgID)
 }
 
+// GetMessages gets a page of messages of a tab, and the number of pages
+func (b *OGame) GetMessages(tab MessagesTab, page int64) ([]Message, int64, error) {
+	return b.WithPriority(Normal).GetMessages(tab, page)
+}
+
+// GetAllMessages gets the messages of every page of a tab
+func (b *OGame) GetAllMessages(tab MessagesTab) ([]Message, error) {
+	return b.WithPriority(Normal).GetAllMessages(tab)
+}
+
+// FavoriteMessage marks a message as favorite
+func (b *OGame) FavoriteMessage(msgID int64) error {
+	return b.WithPriority(Normal).FavoriteMessage(msgID)
+}
+
+// UnfavoriteMessage removes a message from the favorites
+func (b *OGame) UnfavoriteMessage(msgID int64) error {
+	return b.WithPriority(Normal).UnfavoriteMessage(msgID)
+}
+
 // DeleteMessage deletes a message from the mail box
 func (b *OGame) DeleteMessage(msgID int64) error {
 	return b.WithPriority(Normal).DeleteMessage(msgID)
@@ -5811,6 +6297,22 @@ func (b *OGame) DoAuction(bid map[CelestialID]Resources) error {
 	return b.WithPriority(Normal).DoAuction(bid)
 }
 
+// GetResourceMerchant gets the resource market of a celestial
+func (b *OGame) GetResourceMerchant(celestialID CelestialID) (ResourceMerchant, error) {
+	return b.WithPriority(Normal).GetResourceMerchant(celestialID)
+}
+
+// CallResourceMerchant calls a merchant selling resource, costs dark matter
+func (b *OGame) CallResourceMerchant(celestialID CelestialID, resource MerchantResource) (ResourceMerchant, error) {
+	return b.WithPriority(Normal).CallResourceMerchant(celestialID, resource)
+}
+
+// TradeResourceMerchant buys amount of the merchant resource with the resources of celestialID.
+// Returns the resources paid.
+func (b *OGame) TradeResourceMerchant(celestialID CelestialID, amount int64) (Resources, error) {
+	return b.WithPriority(Normal).TradeResourceMerchant(celestialID, amount)
+}
+
 // Highscore ...
 func (b *OGame) Highscore(category, typ, page int64) (Highscore, error) {
 	return b.WithPriority(Normal).Highscore(category, typ, page)
@@ -5857,11 +6359,45 @@ func (b *OGame) BuyMarketplace(itemID int64, celestialID CelestialID) error {
 }
 
 // OfferSellMarketplace sell offer on marketplace
+//
+// Deprecated: use CreateMarketplaceOffer, it takes a typed item
 func (b *OGame) OfferSellMarketplace(itemID interface{}, quantity, priceType, price, priceRange int64, celestialID CelestialID) error {
 	return b.WithPriority(Normal).OfferSellMarketplace(itemID, quantity, priceType, price, priceRange, celestialID)
 }
 
 // OfferBuyMarketplace buy offer on marketplace
+//
+// Deprecated: use CreateMarketplaceOffer, it takes a typed item
 func (b *OGame) OfferBuyMarketplace(itemID interface{}, quantity, priceType, price, priceRange int64, celestialID CelestialID) error {
 	return b.WithPriority(Normal).OfferBuyMarketplace(itemID, quantity, priceType, price, priceRange, celestialID)
 }
+
+// CreateMarketplaceOffer creates a buy or sell offer on marketplace
+func (b *OGame) CreateMarketplaceOffer(offer MarketplaceOfferRequest) error {
+	return b.WithPriority(Normal).CreateMarketplaceOffer(offer)
+}
+
+// SellMarketplace sell to a request of the marketplace selling tab
+func (b *OGame) SellMarketplace(itemID int64, celestialID CelestialID) error {
+	return b.WithPriority(Normal).SellMarketplace(itemID, celestialID)
+}
+
+// GetMarketplaceMessages returns the marketplace purchases and sales messages
+func (b *OGame) GetMarketplaceMessages() ([]MarketplaceMessage, error) {
+	return b.WithPriority(Normal).GetMarketplaceMessages()
+}
+
+// GetMarketplaceOffers returns the marketplace offers matching the filter
+func (b *OGame) GetMarketplaceOffers(filter MarketplaceFilter) ([]MarketplaceOffer, error) {
+	return b.WithPriority(Normal).GetMarketplaceOffers(filter)
+}
+
+// GetMyMarketplaceOffers returns our active marketplace offers
+func (b *OGame) GetMyMarketplaceOffers() ([]MarketplaceOffer, error) {
+	return b.WithPriority(Normal).GetMyMarketplaceOffers()
+}
+
+// CancelMarketplaceOffer cancels one of our active marketplace offers
+func (b *OGame) CancelMarketplaceOffer(offerID int64) error {
+	return b.WithPriority(Normal).CancelMarketplaceOffer(offerID)
+}
@@ -995,6 +995,28 @@ func TestExtractMarketplaceMessages(t *testing.T) {
 	assert.Equal(t, int64(27), msgs[3].Type)
 	assert.Equal(t, int64(1379), msgs[3].MarketTransactionID)
 	assert.Equal(t, "164ba9f6e5cbfdaa03c061730767d779", msgs[3].Token)
+	assert.Contains(t, msgs[3].Content, "You sold: 100 Metal\nYou received 54 Crystal on")
+}
+
+func TestExtractMarketplaceOffers(t *testing.T) {
+	pageHTMLBytes, _ := ioutil.ReadFile("samples/v7.2/en/marketplace_buying.html")
+	offers, nbPage, _ := NewExtractorV7().ExtractMarketplaceOffers(pageHTMLBytes)
+	assert.Equal(t, int64(3), nbPage)
+	assert.Equal(t, 3, len(offers))
+	assert.Equal(t, MarketplaceOffer{ID: 10521, Item: MarketplaceShip(SmallCargoID), Quantity: 1500, PriceType: MarketplaceMetal,
+		Price: 3000000, PricePerUnit: 2000, Player: "Bandit"}, offers[0])
+	assert.Equal(t, MarketplaceResources(MarketplaceDeuterium), offers[1].Item)
+	assert.Equal(t, 1.5, offers[1].PricePerUnit)
+	assert.Equal(t, MarketplaceItemRef("de922af379061263a56d7204d1c395cefcfb7d75"), offers[2].Item)
+	assert.Equal(t, MarketplaceDeuterium, offers[2].PriceType)
+
+	pageHTMLBytes, _ = ioutil.ReadFile("samples/v7.2/en/marketplace_history_selling.html")
+	offers, nbPage, _ = NewExtractorV7().ExtractMarketplaceOffers(pageHTMLBytes)
+	assert.Equal(t, int64(1), nbPage)
+	assert.Equal(t, 2, len(offers))
+	assert.True(t, offers[0].Cancelable)
+	assert.Equal(t, "5f7c0b4fc1d7e5a3c1b0d3e9f2a6b8c4", offers[0].Token)
+	assert.False(t, offers[1].Cancelable)
 }
 
 func TestExtractEspionageReportMessageIDs(t *testing.T) {
@@ -1297,6 +1319,186 @@ func TestExtractOfferOfTheDayPrice1(t *testing.T) {
 	assert.Equal(t, "2c829372796443bf6994cbfa051e4cd2", token)
 }
 
+func TestExtractResourceMerchant(t *testing.T) {
+	pageHTMLBytes, _ := ioutil.ReadFile("samples/v7.4/en/traderResources.html")
+	merchant, err := NewExtractorV7().ExtractResourceMerchant(pageHTMLBytes)
+	assert.NoError(t, err)
+	assert.Equal(t, CrystalMerchant, merchant.Resource)
+	assert.Equal(t, Multiplier{Metal: 1.53, Deuterium: 0.69}, merchant.Rates)
+	assert.Equal(t, int64(3500), merchant.CallCost)
+	assert.Equal(t, "0f1c6a0d9be7f2c1d3a4b5c6d7e8f901", merchant.Token)
+	assert.Equal(t, 2, len(merchant.PlanetResources))
+	assert.Equal(t, int64(921175), merchant.PlanetResources[33673090].Input.Metal)
+	assert.Equal(t, "Colony", merchant.PlanetResources[33677731].Name)
+}
+
+func TestExtractResourceMerchantV874(t *testing.T) {
+	pageHTMLBytes, _ := ioutil.ReadFile("samples/v8.7.4/en/traderResources.html")
+	merchant, err := NewExtractorV874().ExtractResourceMerchant(pageHTMLBytes)
+	assert.NoError(t, err)
+	assert.Equal(t, DeuteriumMerchant, merchant.Resource)
+	assert.Equal(t, Multiplier{Metal: 2.82, Crystal: 1.91}, merchant.Rates)
+	assert.Equal(t, "9a4f3e2d1c0b5a6978e6d5c4b3a29180", merchant.Token)
+	assert.Equal(t, int64(236743), merchant.PlanetResources[33673090].Input.Deuterium)
+
+	// Trader pages share the token and planetResources script variables, no merchant on this one
+	pageHTMLBytes, _ = ioutil.ReadFile("samples/v8.7.4/en/traderImportExport.html")
+	merchant, err = NewExtractorV874().ExtractResourceMerchant(pageHTMLBytes)
+	assert.NoError(t, err)
+	assert.Equal(t, MerchantResource(""), merchant.Resource)
+	assert.Equal(t, Multiplier{}, merchant.Rates)
+	assert.Equal(t, "2a38193e2fa6047e1d92d2f2c71c00fd", merchant.Token)
+	assert.Equal(t, int64(921175), merchant.PlanetResources[33673090].Input.Metal)
+}
+
+func TestExtractChatConversations(t *testing.T) {
+	pageHTMLBytes, _ := ioutil.ReadFile("samples/v7.2/en/chat.html")
+	conversations, err := NewExtractorV7().ExtractChatConversations(pageHTMLBytes, time.UTC)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(conversations))
+	assert.True(t, conversations[0].IsAlliance())
+	assert.Equal(t, int64(500123), conversations[0].AssociationID)
+	assert.Equal(t, int64(3), conversations[0].Unread)
+	assert.False(t, conversations[1].IsAlliance())
+	assert.Equal(t, int64(100234), conversations[1].PlayerID)
+	assert.Equal(t, "Deimos", conversations[1].PlayerName)
+	assert.Equal(t, "Thanks for the deut, see you tomorrow", conversations[1].LastMessage)
+	assert.Equal(t, time.Date(2020, 9, 13, 20, 47, 35, 0, time.UTC), conversations[1].LastDate)
+	assert.Equal(t, int64(1), conversations[1].Unread)
+	assert.Equal(t, int64(0), conversations[2].Unread)
+}
+
+func TestExtractChatConversations_chatBar(t *testing.T) {
+	pageHTMLBytes, _ := ioutil.ReadFile("samples/v7.2/en/create_offer.html")
+	conversations, err := NewExtractorV7().ExtractChatConversations(pageHTMLBytes, time.UTC)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(conversations))
+	assert.True(t, conversations[0].IsAlliance())
+	assert.Equal(t, int64(545), conversations[0].AssociationID)
+	assert.Equal(t, "Alliance Chat", conversations[0].PlayerName)
+	assert.Equal(t, "test", conversations[0].LastMessage)
+	assert.Equal(t, int64(106734), conversations[1].PlayerID)
+	assert.Equal(t, "Notriv", conversations[1].PlayerName)
+	assert.Equal(t, "got it", conversations[1].LastMessage)
+	assert.Equal(t, int64(0), conversations[1].Unread)
+}
+
+func TestExtractBuddies(t *testing.T) {
+	pageHTMLBytes, _ := ioutil.ReadFile("samples/v7.2/en/buddies.html")
+	buddies, err := NewExtractorV7().ExtractBuddies(pageHTMLBytes)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(buddies))
+	assert.Equal(t, Buddy{ID: 48211, PlayerID: 100234, PlayerName: "Deimos", Points: 1234567, Rank: 152,
+		AllianceName: "Frontier", Home: Coordinate{1, 42, 8, PlanetType}, Online: true}, buddies[0])
+	assert.Equal(t, int64(1044), buddies[1].Rank)
+	assert.Equal(t, "", buddies[1].AllianceName)
+	assert.False(t, buddies[1].Online)
+
+	ignored, err := NewExtractorV7().ExtractIgnoredPlayers(pageHTMLBytes)
+	assert.NoError(t, err)
+	assert.Equal(t, []IgnoredPlayer{{PlayerID: 101999, PlayerName: "Spammy"}}, ignored)
+
+	token, err := NewExtractorV7().ExtractBuddiesToken(pageHTMLBytes)
+	assert.NoError(t, err)
+	assert.Equal(t, "b7e2c9f04d1a3e5f6a7b8c9d0e1f2a3b", token)
+}
+
+func TestExtractMessages(t *testing.T) {
+	pageHTMLBytes, _ := ioutil.ReadFile("samples/messages.html")
+	msgs, nbPage, err := NewExtractorV6().ExtractMessages(pageHTMLBytes, time.UTC)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), nbPage)
+	assert.Equal(t, 2, len(msgs))
+	assert.Equal(t, int64(6384072), msgs[0].ID)
+	assert.Equal(t, EspionageMessagesTab, msgs[0].Tab)
+	assert.Equal(t, EspionageReportMessage, msgs[0].Type)
+
+	pageHTMLBytes, _ = ioutil.ReadFile("samples/v7.2/en/messages.html")
+	msgs, _, _ = NewExtractorV7().ExtractMessages(pageHTMLBytes, time.UTC)
+	assert.Equal(t, 6, len(msgs))
+	assert.Equal(t, int64(12692941), msgs[0].ID)
+	assert.Equal(t, "Fleet Command", msgs[0].Sender)
+	assert.Equal(t, Coordinate{4, 49, 9, MoonType}, msgs[0].Coordinate)
+	assert.Equal(t, time.Date(2020, 3, 26, 3, 56, 40, 0, time.UTC), msgs[0].CreatedAt)
+	assert.True(t, msgs[0].New)
+	assert.False(t, msgs[0].Favorite)
+}
+
+func TestExtractMessagesTransport(t *testing.T) {
+	pageHTMLBytes, _ := ioutil.ReadFile("samples/v7.2/en/messages_transport.html")
+	msgs, nbPage, _ := NewExtractorV874().ExtractMessages(pageHTMLBytes, time.UTC)
+	assert.Equal(t, int64(2), nbPage)
+	assert.Equal(t, 2, len(msgs))
+	assert.Equal(t, TransportMessagesTab, msgs[0].Tab)
+	assert.Equal(t, TransportMessage, msgs[0].Type)
+	assert.True(t, msgs[0].New)
+	assert.False(t, msgs[1].New)
+	assert.True(t, msgs[1].Favorite)
+	assert.Contains(t, msgs[0].Content, "delivers its goods:\nMetal: 125.000")
+}
+
+func TestExtractMessagesOther(t *testing.T) {
+	pageHTMLBytes, _ := ioutil.ReadFile("samples/v7.2/en/messages_other.html")
+	msgs, _, _ := NewExtractorV7().ExtractMessages(pageHTMLBytes, time.UTC)
+	assert.Equal(t, 4, len(msgs))
+	assert.Equal(t, HarvestMessage, msgs[0].Type)
+	assert.Equal(t, ColonizationMessage, msgs[1].Type)
+	assert.Equal(t, ColonizationMessage, msgs[2].Type)
+	assert.Equal(t, MissileAttackMessage, msgs[3].Type)
+	assert.Equal(t, Coordinate{2, 118, 4, PlanetType}, msgs[3].Coordinate)
+}
+
+func TestExtractAllianceOverview(t *testing.T) {
+	pageHTMLBytes, _ := ioutil.ReadFile("samples/v7.2/en/alliance_overview.html")
+	overview, err := NewExtractorV7().ExtractAllianceOverview(pageHTMLBytes, time.UTC)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(500123), overview.ID)
+	assert.Equal(t, "FRNT", overview.Tag)
+	assert.Equal(t, "Frontier", overview.Name)
+	assert.Equal(t, Warrior, overview.Class)
+	assert.Equal(t, "https://frontier.example.com", overview.Homepage)
+	assert.Equal(t, 3, len(overview.Members))
+	assert.Equal(t, AllianceMember{PlayerID: 100001, Name: "Zephyr", RankID: -1, RankName: "Founder", Points: 4512006,
+		Home: Coordinate{1, 40, 7, PlanetType}, JoinedAt: time.Date(2020, 3, 2, 18, 4, 11, 0, time.UTC),
+		Status: AllianceMemberOnline}, overview.Members[0])
+	assert.Equal(t, AllianceMemberRecent, overview.Members[1].Status)
+	assert.True(t, overview.Members[1].Kickable)
+	assert.Equal(t, AllianceMemberOffline, overview.Members[2].Status)
+
+	token, err := NewExtractorV7().ExtractAllianceToken(pageHTMLBytes)
+	assert.NoError(t, err)
+	assert.Equal(t, "c2d4e6f8a0b1c3d5e7f9a1b3c5d7e9f1", token)
+}
+
+func TestExtractAllianceRanks(t *testing.T) {
+	pageHTMLBytes, _ := ioutil.ReadFile("samples/v7.2/en/alliance_ranks.html")
+	ranks, err := NewExtractorV7().ExtractAllianceRanks(pageHTMLBytes)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(ranks))
+	assert.Equal(t, AllianceRank{ID: 2, Name: "Officer", Permissions: AlliancePermissions{SeeApplications: true,
+		EditApplications: true, SeeMembers: true, KickMembers: true, SeeOnlineStatus: true, CircularMessage: true}}, ranks[0])
+	assert.Equal(t, AlliancePermissions{SeeMembers: true, SeeOnlineStatus: true}, ranks[1].Permissions)
+}
+
+func TestExtractAllianceApplications(t *testing.T) {
+	pageHTMLBytes, _ := ioutil.ReadFile("samples/v7.2/en/alliance_applications.html")
+	applications, err := NewExtractorV7().ExtractAllianceApplications(pageHTMLBytes, time.UTC)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(applications))
+	assert.Equal(t, AllianceApplication{ID: 7781, PlayerID: 102345, PlayerName: "Orion", Points: 245800, Rank: 812,
+		Date: time.Date(2020, 9, 12, 14, 22, 8, 0, time.UTC), Message: "Hi, active miner looking for a home,\nonline every evening."}, applications[0])
+	assert.Equal(t, int64(3402), applications[1].Rank)
+	assert.Equal(t, "", applications[1].Message)
+}
+
+func TestExtractAlliancePublicInfo(t *testing.T) {
+	pageHTMLBytes, _ := ioutil.ReadFile("samples/allianceInfo.html")
+	info, err := NewExtractorV6().ExtractAlliancePublicInfo(pageHTMLBytes)
+	assert.NoError(t, err)
+	assert.Equal(t, AlliancePublicInfo{ID: 500456, Tag: "NOVA", Name: "Supernova Syndicate", Members: 37, Class: Trader,
+		Homepage: "https://nova.example.com", Description: "Recruiting miners and fleeters.\nApply in game.", ApplicationsOpen: true}, info)
+}
+
 func TestExtractAttacks(t *testing.T) {
 	clock := clockwork.NewFakeClockAt(time.Date(2016, 8, 23, 17, 48, 13, 0, time.UTC))
 	pageHTMLBytes, _ := ioutil.ReadFile("samples/event_list_attack.html")
@@ -0,0 +1,384 @@
+package ogame
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+// PhalanxObservation fleets seen by a phalanx scan
+type PhalanxObservation struct {
+	MoonID    MoonID
+	Target    Coordinate
+	ScannedAt time.Time
+	Fleets    []Fleet
+}
+
+// PhalanxLanding a fleet landing on the tracked target
+type PhalanxLanding struct {
+	Fleet Fleet
+	At    time.Time
+}
+
+// Interception launch plan so that an attack arrives right after the target fleet landed
+type Interception struct {
+	Origin     Celestial
+	Target     Coordinate
+	Speed      Speed
+	FlightTime int64
+	Fuel       int64
+	LaunchAt   time.Time
+	ArrivalAt  time.Time
+}
+
+type phalanxTarget struct {
+	interval time.Duration
+	lastScan time.Time
+}
+
+// PhalanxTracker periodically scans targets with our moons sensor phalanx and keeps the movement history
+type PhalanxTracker struct {
+	sync.Mutex
+	b              Wrapper
+	moons          []MoonID
+	reserve        int64
+	historySize    int
+	targets        map[Coordinate]*phalanxTarget
+	history        map[Coordinate][]PhalanxObservation
+	deuteriumSpent int64
+}
+
+// NewPhalanxTracker ...
+func NewPhalanxTracker(b Wrapper) *PhalanxTracker {
+	t := new(PhalanxTracker)
+	t.b = b
+	t.historySize = 100
+	t.targets = make(map[Coordinate]*phalanxTarget)
+	t.history = make(map[Coordinate][]PhalanxObservation)
+	return t
+}
+
+// SetMoons restricts the moons used to scan (all moons by default)
+func (t *PhalanxTracker) SetMoons(moons ...MoonID) *PhalanxTracker {
+	t.moons = moons
+	return t
+}
+
+// SetDeuteriumReserve sets the deuterium that must stay on the moon after a scan
+func (t *PhalanxTracker) SetDeuteriumReserve(reserve int64) *PhalanxTracker {
+	t.reserve = reserve
+	return t
+}
+
+// SetHistorySize sets the number of observations kept per target
+func (t *PhalanxTracker) SetHistorySize(size int) *PhalanxTracker {
+	t.historySize = size
+	return t
+}
+
+// AddTarget tracks a planet, scanning it every interval
+func (t *PhalanxTracker) AddTarget(coord Coordinate, interval time.Duration) *PhalanxTracker {
+	t.Lock()
+	defer t.Unlock()
+	coord.Type = PlanetType
+	t.targets[coord] = &phalanxTarget{interval: interval}
+	return t
+}
+
+// RemoveTarget stops tracking a planet, its history is kept
+func (t *PhalanxTracker) RemoveTarget(coord Coordinate) {
+	t.Lock()
+	defer t.Unlock()
+	coord.Type = PlanetType
+	delete(t.targets, coord)
+}
+
+// DeuteriumSpent returns the deuterium used by the scans
+func (t *PhalanxTracker) DeuteriumSpent() int64 {
+	t.Lock()
+	defer t.Unlock()
+	return t.deuteriumSpent
+}
+
+// History returns the observations of a target, oldest first
+func (t *PhalanxTracker) History(coord Coordinate) []PhalanxObservation {
+	t.Lock()
+	defer t.Unlock()
+	coord.Type = PlanetType
+	return append([]PhalanxObservation{}, t.history[coord]...)
+}
+
+// moonFor returns a moon having the target in its phalanx range and enough deuterium to scan
+func (t *PhalanxTracker) moonFor(coord Coordinate) (MoonID, error) {
+	moonIDs := t.moons
+	if moonIDs == nil {
+		for _, moon := range t.b.GetCachedMoons() {
+			moonIDs = append(moonIDs, moon.ID)
+		}
+	}
+	isDiscoverer := t.b.CharacterClass().IsDiscoverer()
+	for _, moonID := range moonIDs {
+		moon := t.b.GetCachedCelestial(moonID)
+		if moon == nil || moon.GetCoordinate().Galaxy != coord.Galaxy {
+			continue
+		}
+		facilities, err := t.b.GetFacilities(moonID.Celestial())
+		if err != nil {
+			return 0, err
+		}
+		phalanxRange := SensorPhalanx.GetRange(facilities.SensorPhalanx, isDiscoverer)
+		if facilities.SensorPhalanx == 0 ||
+			systemDistance(t.b.GetNbSystems(), moon.GetCoordinate().System, coord.System, t.b.IsDonutSystem()) > phalanxRange {
+			continue
+		}
+		resources, err := t.b.GetResources(moonID.Celestial())
+		if err != nil {
+			return 0, err
+		}
+		if resources.Deuterium-SensorPhalanx.ScanConsumption() < t.reserve {
+			continue
+		}
+		return moonID, nil
+	}
+	return 0, errors.New("no moon in range with enough deuterium")
+}
+
+// Scan scans a coordinate now and records the observation
+func (t *PhalanxTracker) Scan(coord Coordinate) (PhalanxObservation, error) {
+	t.Lock()
+	defer t.Unlock()
+	return t.scan(coord)
+}
+
+func (t *PhalanxTracker) scan(coord Coordinate) (PhalanxObservation, error) {
+	coord.Type = PlanetType
+	moonID, err := t.moonFor(coord)
+	if err != nil {
+		return PhalanxObservation{}, err
+	}
+	fleets, err := t.b.Phalanx(moonID, coord)
+	if err != nil {
+		return PhalanxObservation{}, err
+	}
+	t.deuteriumSpent += SensorPhalanx.ScanConsumption()
+	observation := PhalanxObservation{MoonID: moonID, Target: coord, ScannedAt: t.b.ServerTime(), Fleets: fleets}
+	history := append(t.history[coord], observation)
+	if len(history) > t.historySize {
+		history = history[len(history)-t.historySize:]
+	}
+	t.history[coord] = history
+	if target, ok := t.targets[coord]; ok {
+		target.lastScan = observation.ScannedAt
+	}
+	return observation, nil
+}
+
+// Tick scans the targets that are due
+func (t *PhalanxTracker) Tick() ([]PhalanxObservation, error) {
+	t.Lock()
+	defer t.Unlock()
+	now := t.b.ServerTime()
+	coords := make([]Coordinate, 0, len(t.targets))
+	for coord, target := range t.targets {
+		if target.lastScan.IsZero() || !now.Before(target.lastScan.Add(target.interval)) {
+			coords = append(coords, coord)
+		}
+	}
+	sort.Slice(coords, func(i, j int) bool {
+		if coords[i].Galaxy != coords[j].Galaxy {
+			return coords[i].Galaxy < coords[j].Galaxy
+		}
+		if coords[i].System != coords[j].System {
+			return coords[i].System < coords[j].System
+		}
+		return coords[i].Position < coords[j].Position
+	})
+	out := make([]PhalanxObservation, 0, len(coords))
+	for _, coord := range coords {
+		observation, err := t.scan(coord)
+		if err != nil {
+			return out, err
+		}
+		out = append(out, observation)
+	}
+	return out, nil
+}
+
+// Landings returns the fleets that will land on the target, from the last observation, soonest first.
+// Returning fleets land on their origin, deployed fleets land on their destination.
+func (t *PhalanxTracker) Landings(coord Coordinate) []PhalanxLanding {
+	t.Lock()
+	defer t.Unlock()
+	return t.landings(coord)
+}
+
+func (t *PhalanxTracker) landings(coord Coordinate) []PhalanxLanding {
+	coord.Type = PlanetType
+	history := t.history[coord]
+	if len(history) == 0 {
+		return nil
+	}
+	now := t.b.ServerTime()
+	samePlanet := func(c Coordinate) bool {
+		return c.Galaxy == coord.Galaxy && c.System == coord.System && c.Position == coord.Position
+	}
+	out := make([]PhalanxLanding, 0)
+	for _, fleet := range history[len(history)-1].Fleets {
+		lands := (fleet.ReturnFlight && samePlanet(fleet.Origin)) ||
+			(!fleet.ReturnFlight && fleet.Mission == Park && samePlanet(fleet.Destination))
+		if lands && fleet.ArrivalTime.After(now) {
+			out = append(out, PhalanxLanding{Fleet: fleet, At: fleet.ArrivalTime})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].At.Before(out[j].At) })
+	return out
+}
+
+// PredictLanding returns when the next fleet lands on the target
+func (t *PhalanxTracker) PredictLanding(coord Coordinate) (PhalanxLanding, bool) {
+	landings := t.Landings(coord)
+	if len(landings) == 0 {
+		return PhalanxLanding{}, false
+	}
+	return landings[0], true
+}
+
+// interceptionOrigins returns the origins (all celestials if none is given) having the ships
+func (t *PhalanxTracker) interceptionOrigins(ships ShipsInfos, origins []interface{}) ([]Celestial, error) {
+	var celestials []Celestial
+	for _, origin := range origins {
+		if c := t.b.GetCachedCelestial(origin); c != nil {
+			celestials = append(celestials, c)
+		}
+	}
+	if origins == nil {
+		celestials = t.b.GetCachedCelestials()
+	}
+	out := make([]Celestial, 0, len(celestials))
+	for _, origin := range celestials {
+		available, err := t.b.GetShips(origin.GetID())
+		if err != nil {
+			return nil, err
+		}
+		if available.HasShips() && shipsFit(ships, available) {
+			out = append(out, origin)
+		}
+	}
+	return out, nil
+}
+
+// PlanInterception computes, for every origin having the ships, the launch time so that the attack arrives delay
+// after landingAt, and keeps the latest one. The latest launch leaves the target the least time to react.
+// The attack is planned at 100% speed: a slower fleet has to leave earlier, giving the target more time.
+// Use PlanInterceptionAt to compute the speed for a given launch time.
+func (t *PhalanxTracker) PlanInterception(target Coordinate, landingAt time.Time, delay time.Duration, ships ShipsInfos, origins ...interface{}) (Interception, error) {
+	celestials, err := t.interceptionOrigins(ships, origins)
+	if err != nil {
+		return Interception{}, err
+	}
+	now := t.b.ServerTime()
+	arrivalAt := landingAt.Add(delay)
+	var best Interception
+	found := false
+	for _, origin := range celestials {
+		secs, fuel := t.b.FlightTime(origin.GetCoordinate(), target, HundredPercent, ships, Attack)
+		launchAt := arrivalAt.Add(-time.Duration(secs) * time.Second)
+		if launchAt.Before(now) {
+			continue
+		}
+		if !found || launchAt.After(best.LaunchAt) {
+			best = Interception{Origin: origin, Target: target, Speed: HundredPercent, FlightTime: secs, Fuel: fuel, LaunchAt: launchAt, ArrivalAt: arrivalAt}
+			found = true
+		}
+	}
+	if !found {
+		return best, errors.New("no origin can reach the target in time")
+	}
+	return best, nil
+}
+
+// PlanInterceptionAt computes, for every origin having the ships, the speed so that an attack launched at launchAt
+// arrives as close as possible after delay past landingAt, and keeps the origin arriving first.
+// Fleet speeds go by steps of 10% (5% for the general class), so the attack can arrive a bit later than asked.
+func (t *PhalanxTracker) PlanInterceptionAt(target Coordinate, landingAt time.Time, delay time.Duration, launchAt time.Time, ships ShipsInfos, origins ...interface{}) (Interception, error) {
+	celestials, err := t.interceptionOrigins(ships, origins)
+	if err != nil {
+		return Interception{}, err
+	}
+	if launchAt.Before(t.b.ServerTime()) {
+		return Interception{}, errors.New("launch time is in the past")
+	}
+	step := Speed(1)
+	if t.b.CharacterClass().IsGeneral() {
+		step = 0.5
+	}
+	arrivalAt := landingAt.Add(delay)
+	var best Interception
+	found := false
+	for _, origin := range celestials {
+		for speed := step; speed <= HundredPercent; speed += step {
+			secs, fuel := t.b.FlightTime(origin.GetCoordinate(), target, speed, ships, Attack)
+			at := launchAt.Add(time.Duration(secs) * time.Second)
+			if at.Before(arrivalAt) {
+				continue
+			}
+			if !found || at.Before(best.ArrivalAt) {
+				best = Interception{Origin: origin, Target: target, Speed: speed, FlightTime: secs, Fuel: fuel, LaunchAt: launchAt, ArrivalAt: at}
+				found = true
+			}
+		}
+	}
+	if !found {
+		return best, errors.New("no origin can reach the target after the landing from the launch time")
+	}
+	return best, nil
+}
+
+// Intercept plans an interception of the next fleet landing on the target
+func (t *PhalanxTracker) Intercept(target Coordinate, delay time.Duration, ships ShipsInfos, origins ...interface{}) (Interception, error) {
+	landing, ok := t.PredictLanding(target)
+	if !ok {
+		return Interception{}, errors.New("no fleet landing on target")
+	}
+	return t.PlanInterception(target, landing.At, delay, ships, origins...)
+}
+
+// LaunchInterception waits until the launch time and sends the attack.
+// Closing stop cancels the launch with ErrInterceptionStopped.
+func (t *PhalanxTracker) LaunchInterception(interception Interception, ships ShipsInfos, stop <-chan struct{}) (Fleet, error) {
+	if wait := interception.LaunchAt.Sub(t.b.ServerTime()); wait > 0 && !waitOrStop(wait, stop) {
+		return Fleet{}, ErrInterceptionStopped
+	}
+	return NewFleetBuilder(t.b).
+		SetOrigin(interception.Origin).
+		SetDestination(interception.Target).
+		SetMission(Attack).
+		SetSpeed(interception.Speed).
+		SetShips(ships).
+		SendNow()
+}
+
+// Run calls Tick every interval until stop is closed
+func (t *PhalanxTracker) Run(interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		_, _ = t.Tick()
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// shipsFit returns true if all the ships are available
+func shipsFit(ships, available ShipsInfos) bool {
+	for _, ship := range Ships {
+		if ships.ByID(ship.GetID()) > available.ByID(ship.GetID()) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,98 @@
+package ogame
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakePhalanxWrapper struct {
+	*fakeFleets
+	facilities   Facilities
+	phalanx      map[Coordinate][]Fleet
+	phalanxScans int
+}
+
+func (w *fakePhalanxWrapper) GetFacilities(CelestialID, ...Option) (Facilities, error) {
+	return w.facilities, nil
+}
+
+func (w *fakePhalanxWrapper) Phalanx(moonID MoonID, coord Coordinate) ([]Fleet, error) {
+	w.phalanxScans++
+	return w.phalanx[coord], nil
+}
+
+func TestPhalanxTracker(t *testing.T) {
+	w := &fakePhalanxWrapper{fakeFleets: newFakeFleets(), phalanx: make(map[Coordinate][]Fleet)}
+	planet := Planet{ID: 1, Coordinate: Coordinate{1, 100, 8, PlanetType}}
+	moon := Moon{ID: 2, Coordinate: Coordinate{1, 100, 8, MoonType}}
+	w.celestials = []Celestial{planet, moon}
+	w.ships[1] = ShipsInfos{LightFighter: 100}
+	w.facilities = Facilities{SensorPhalanx: 3}
+	w.resources = Resources{Deuterium: 6000}
+	target := Coordinate{1, 105, 4, PlanetType}
+	w.phalanx[target] = []Fleet{
+		{Mission: Attack, ReturnFlight: true, Origin: target, Destination: Coordinate{1, 110, 4, PlanetType}, ArrivalTime: w.now.Add(2 * time.Hour)},
+		{Mission: Park, Origin: Coordinate{1, 106, 4, MoonType}, Destination: target, ArrivalTime: w.now.Add(time.Hour)},
+		{Mission: Attack, Origin: target, Destination: Coordinate{1, 110, 4, PlanetType}, ArrivalTime: w.now.Add(30 * time.Minute)},
+	}
+	tracker := NewPhalanxTracker(w).AddTarget(target, 10*time.Minute)
+
+	observations, err := tracker.Tick()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(observations))
+	assert.Equal(t, MoonID(2), observations[0].MoonID)
+	assert.Equal(t, 3, len(observations[0].Fleets))
+	assert.Equal(t, int64(5000), tracker.DeuteriumSpent())
+
+	// Not due yet
+	_, _ = tracker.Tick()
+	assert.Equal(t, 1, w.phalanxScans)
+	w.now = w.now.Add(10 * time.Minute)
+	_, _ = tracker.Tick()
+	assert.Equal(t, 2, w.phalanxScans)
+	assert.Equal(t, 2, len(tracker.History(target)))
+
+	landings := tracker.Landings(target)
+	assert.Equal(t, 2, len(landings))
+	assert.Equal(t, Park, landings[0].Fleet.Mission)
+	assert.True(t, landings[1].Fleet.ReturnFlight)
+
+	ships := ShipsInfos{LightFighter: 10}
+	interception, err := tracker.Intercept(target, 5*time.Second, ships)
+	assert.NoError(t, err)
+	secs, _ := w.FlightTime(planet.Coordinate, target, HundredPercent, ships, Attack)
+	assert.Equal(t, planet.GetID(), interception.Origin.GetID())
+	assert.Equal(t, HundredPercent, interception.Speed)
+	assert.Equal(t, landings[0].At.Add(5*time.Second), interception.ArrivalAt)
+	assert.Equal(t, interception.ArrivalAt.Add(-time.Duration(secs)*time.Second), interception.LaunchAt)
+
+	// Launching now, the slowest speed still arriving after the landing is used
+	launchAt := landings[0].At.Add(-time.Duration(secs) * time.Second).Add(-30 * time.Minute)
+	interception, err = tracker.PlanInterceptionAt(target, landings[0].At, 5*time.Second, launchAt, ships)
+	assert.NoError(t, err)
+	assert.Equal(t, launchAt, interception.LaunchAt)
+	assert.True(t, interception.Speed < HundredPercent)
+	assert.False(t, interception.ArrivalAt.Before(landings[0].At.Add(5*time.Second)))
+	slower, _ := w.FlightTime(planet.Coordinate, target, interception.Speed-1, ships, Attack)
+	assert.True(t, launchAt.Add(time.Duration(slower)*time.Second).After(interception.ArrivalAt))
+
+	stop := make(chan struct{})
+	close(stop)
+	interception.LaunchAt = w.now.Add(time.Hour)
+	_, err = tracker.LaunchInterception(interception, ships, stop)
+	assert.Equal(t, ErrInterceptionStopped, err)
+
+	// Too late to intercept
+	_, err = tracker.PlanInterception(target, w.now.Add(time.Second), 0, ships)
+	assert.Error(t, err)
+
+	// Out of phalanx range
+	_, err = tracker.Scan(Coordinate{1, 150, 4, PlanetType})
+	assert.Error(t, err)
+
+	// Not enough deuterium left after the scan
+	_, err = tracker.SetDeuteriumReserve(2000).Scan(target)
+	assert.Error(t, err)
+}
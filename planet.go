@@ -27,6 +27,17 @@ func (t Temperature) Mean() int64 {
 	return int64(math.Round(float64(t.Min+t.Max) / 2))
 }
 
+// average max temperature of the planets by position, the min temperature is 40 degrees lower
+var positionMaxTemperatures = [15]int64{240, 190, 140, 90, 80, 70, 60, 50, 40, 30, 20, 10, -30, -70, -110}
+
+// PositionTemperature returns the average temperature of a planet at position, the actual temperature
+// of a planet is random within a range that depends on its position
+func PositionTemperature(position int64) Temperature {
+	position = Clamp(position, 1, 15)
+	max := positionMaxTemperatures[position-1]
+	return Temperature{Min: max - 40, Max: max}
+}
+
 // Planet ogame planet object
 type Planet struct {
 	ogame       *OGame
@@ -16,6 +16,13 @@ func TestTemperature_Mean(t *testing.T) {
 	assert.Equal(t, int64(0), Temperature{Min: -10, Max: 10}.Mean())
 }
 
+func TestPositionTemperature(t *testing.T) {
+	assert.Equal(t, Temperature{Min: 200, Max: 240}, PositionTemperature(1))
+	assert.Equal(t, Temperature{Min: 10, Max: 50}, PositionTemperature(8))
+	assert.Equal(t, Temperature{Min: -150, Max: -110}, PositionTemperature(15))
+	assert.Equal(t, Temperature{Min: -150, Max: -110}, PositionTemperature(16))
+}
+
 func TestPlanet_String(t *testing.T) {
 	assert.Equal(t, "Earth [P:1:1:3]", Planet{Name: "Earth", Coordinate: Coordinate{Galaxy: 1, System: 1, Position: 3, Type: PlanetType}}.String())
 }
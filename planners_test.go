@@ -0,0 +1,122 @@
+package ogame
+
+import (
+	"time"
+)
+
+// fakeBot answers the universe and account lookups of the planners from fixed values, the planner tests embed it
+// in a fake that adds the few calls each planner makes. Calling any other Wrapper method panics.
+type fakeBot struct {
+	Wrapper
+	now            time.Time
+	researches     Researches
+	characterClass CharacterClass
+	server         Server
+	celestials     []Celestial
+}
+
+func newFakeBot() *fakeBot {
+	b := &fakeBot{now: time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC)}
+	b.server.Settings.UniverseSize = 9
+	return b
+}
+
+func (b *fakeBot) ServerTime() time.Time            { return b.now }
+func (b *fakeBot) GetCachedResearch() Researches    { return b.researches }
+func (b *fakeBot) CharacterClass() CharacterClass   { return b.characterClass }
+func (b *fakeBot) GetServer() Server                { return b.server }
+func (b *fakeBot) IsPioneers() bool                 { return false }
+func (b *fakeBot) GetUniverseSpeed() int64          { return 1 }
+func (b *fakeBot) GetNbSystems() int64              { return 499 }
+func (b *fakeBot) IsDonutSystem() bool              { return true }
+func (b *fakeBot) Distance(c1, c2 Coordinate) int64 { return Distance(c1, c2, 9, 499, true, true) }
+func (b *fakeBot) FlightTime(origin, destination Coordinate, speed Speed, ships ShipsInfos, mission MissionID) (secs, fuel int64) {
+	return CalcFlightTime(origin, destination, 9, 499, true, true, 1, speed.Float64(), 1, ships, b.researches, b.characterClass)
+}
+
+func (b *fakeBot) GetCachedCelestials() []Celestial { return b.celestials }
+func (b *fakeBot) GetCachedCelestial(v interface{}) Celestial {
+	if c, ok := v.(Celestial); ok {
+		return c
+	}
+	switch id := v.(type) {
+	case PlanetID:
+		v = id.Celestial()
+	case MoonID:
+		v = id.Celestial()
+	}
+	for _, c := range b.celestials {
+		if c.GetID() == v {
+			return c
+		}
+	}
+	return nil
+}
+
+func (b *fakeBot) GetCachedMoons() []Moon {
+	moons := make([]Moon, 0)
+	for _, c := range b.celestials {
+		if moon, ok := c.(Moon); ok {
+			moons = append(moons, moon)
+		}
+	}
+	return moons
+}
+
+// fakeFleets keeps the ships and slots of the account and records the fleets sent by the planners
+type fakeFleets struct {
+	*fakeBot
+	slots      Slots
+	ships      map[CelestialID]ShipsInfos
+	resources  Resources
+	flightTime time.Duration
+	sentFleets []Fleet
+}
+
+func newFakeFleets() *fakeFleets {
+	f := &fakeFleets{fakeBot: newFakeBot(), ships: make(map[CelestialID]ShipsInfos)}
+	f.slots.Total = 10
+	return f
+}
+
+func (f *fakeFleets) GetSlots() Slots                             { return f.slots }
+func (f *fakeFleets) GetResearch() Researches                     { return f.researches }
+func (f *fakeFleets) GetResources(CelestialID) (Resources, error) { return f.resources, nil }
+func (f *fakeFleets) Tx(clb func(tx Prioritizable) error) error   { return clb(f) }
+
+func (f *fakeFleets) GetShips(celestialID CelestialID, opts ...Option) (ShipsInfos, error) {
+	return f.ships[celestialID], nil
+}
+
+func (f *fakeFleets) SendFleet(celestialID CelestialID, ships []Quantifiable, speed Speed, where Coordinate,
+	mission MissionID, resources Resources, holdingTime, unionID int64) (Fleet, error) {
+	if f.slots.InUse >= f.slots.Total {
+		return Fleet{}, ErrAllSlotsInUse
+	}
+	if mission == Expedition {
+		if f.slots.ExpInUse >= f.slots.ExpTotal {
+			return Fleet{}, ErrAllSlotsInUse
+		}
+		f.slots.ExpInUse++
+	}
+	f.slots.InUse++
+	fleet := Fleet{ID: FleetID(len(f.sentFleets) + 1), Mission: mission, Destination: where, Resources: resources,
+		Ships: ShipsInfos{}.FromQuantifiables(ships), StartTime: f.now, ArrivalTime: f.now.Add(f.flightTime), BackTime: f.now.Add(time.Hour + 2*f.flightTime)}
+	f.sentFleets = append(f.sentFleets, fleet)
+	return fleet, nil
+}
+
+func (f *fakeFleets) GetFleets(opts ...Option) ([]Fleet, Slots) {
+	fleets := make([]Fleet, 0)
+	for _, fleet := range f.sentFleets {
+		if f.now.Before(fleet.BackTime) {
+			fleets = append(fleets, fleet)
+		}
+	}
+	return fleets, f.slots
+}
+
+func (f *fakeFleets) EnsureFleet(celestialID CelestialID, ships []Quantifiable, speed Speed, where Coordinate,
+	mission MissionID, resources Resources, holdingTime, unionID int64) (Fleet, error) {
+	return f.SendFleet(celestialID, ships, speed, where, mission, resources, holdingTime, unionID)
+}
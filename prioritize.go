@@ -112,6 +112,62 @@ func (b *Prioritize) GetAlliancePageContent(vals url.Values) ([]byte, error) {
 	return b.bot.getAlliancePageContent(vals)
 }
 
+// GetAllianceOverview gets our alliance and its members
+func (b *Prioritize) GetAllianceOverview() (AllianceOverview, error) {
+	b.begin("GetAllianceOverview")
+	defer b.done()
+	return b.bot.getAllianceOverview()
+}
+
+// GetAllianceRanks gets the ranks of our alliance and their permissions
+func (b *Prioritize) GetAllianceRanks() ([]AllianceRank, error) {
+	b.begin("GetAllianceRanks")
+	defer b.done()
+	return b.bot.getAllianceRanks()
+}
+
+// GetAllianceApplications gets the pending applications to our alliance
+func (b *Prioritize) GetAllianceApplications() ([]AllianceApplication, error) {
+	b.begin("GetAllianceApplications")
+	defer b.done()
+	return b.bot.getAllianceApplications()
+}
+
+// AcceptAllianceApplication accepts an application to our alliance
+func (b *Prioritize) AcceptAllianceApplication(applicationID int64) error {
+	b.begin("AcceptAllianceApplication")
+	defer b.done()
+	return b.bot.acceptAllianceApplication(applicationID)
+}
+
+// DenyAllianceApplication denies an application to our alliance
+func (b *Prioritize) DenyAllianceApplication(applicationID int64, reason string) error {
+	b.begin("DenyAllianceApplication")
+	defer b.done()
+	return b.bot.denyAllianceApplication(applicationID, reason)
+}
+
+// SendAllianceCircular sends a circular message to the members having one of rankIDs, to all members if empty
+func (b *Prioritize) SendAllianceCircular(message string, rankIDs []int64) error {
+	b.begin("SendAllianceCircular")
+	defer b.done()
+	return b.bot.sendAllianceCircular(message, rankIDs)
+}
+
+// SetAllianceClass selects the class of our alliance
+func (b *Prioritize) SetAllianceClass(class AllianceClass) error {
+	b.begin("SetAllianceClass")
+	defer b.done()
+	return b.bot.setAllianceClass(class)
+}
+
+// GetAlliancePublicInfo gets the public information of any alliance
+func (b *Prioritize) GetAlliancePublicInfo(allianceID int64) (AlliancePublicInfo, error) {
+	b.begin("GetAlliancePublicInfo")
+	defer b.done()
+	return b.bot.getAlliancePublicInfo(allianceID)
+}
+
 // GetPageContent gets the html for a specific ogame page
 func (b *Prioritize) GetPageContent(vals url.Values) ([]byte, error) {
 	b.begin("GetPageContent")
@@ -222,6 +278,85 @@ func (b *Prioritize) SendMessageAlliance(associationID int64, message string) er
 	return b.bot.sendMessage(associationID, message, false)
 }
 
+// GetChatConversations gets the conversations listed on the chat page
+func (b *Prioritize) GetChatConversations() ([]ChatConversation, error) {
+	b.begin("GetChatConversations")
+	defer b.done()
+	return b.bot.getChatConversations()
+}
+
+// GetChatHistory gets the messages of the conversation with playerID older than lastMessageID,
+// the most recent messages if lastMessageID is 0. Returns an empty slice when there are no more messages.
+func (b *Prioritize) GetChatHistory(playerID, lastMessageID int64) ([]ChatMsg, error) {
+	b.begin("GetChatHistory")
+	defer b.done()
+	return b.bot.getChatHistory(playerID, lastMessageID, true, false)
+}
+
+// GetAllianceChatHistory gets the messages of the alliance chat older than lastMessageID,
+// the most recent messages if lastMessageID is 0
+func (b *Prioritize) GetAllianceChatHistory(associationID, lastMessageID int64) ([]ChatMsg, error) {
+	b.begin("GetAllianceChatHistory")
+	defer b.done()
+	return b.bot.getChatHistory(associationID, lastMessageID, false, false)
+}
+
+// MarkChatAsRead marks the conversation with playerID as read
+func (b *Prioritize) MarkChatAsRead(playerID int64) error {
+	b.begin("MarkChatAsRead")
+	defer b.done()
+	return b.bot.markChatAsRead(playerID, true)
+}
+
+// MarkAllianceChatAsRead marks the alliance chat as read
+func (b *Prioritize) MarkAllianceChatAsRead(associationID int64) error {
+	b.begin("MarkAllianceChatAsRead")
+	defer b.done()
+	return b.bot.markChatAsRead(associationID, false)
+}
+
+// GetBuddies gets our buddy list
+func (b *Prioritize) GetBuddies() ([]Buddy, error) {
+	b.begin("GetBuddies")
+	defer b.done()
+	return b.bot.getBuddies()
+}
+
+// AddBuddy sends a buddy request to playerID
+func (b *Prioritize) AddBuddy(playerID int64, message string) error {
+	b.begin("AddBuddy")
+	defer b.done()
+	return b.bot.addBuddy(playerID, message)
+}
+
+// RemoveBuddy removes a buddy from our buddy list, buddyID is Buddy.ID
+func (b *Prioritize) RemoveBuddy(buddyID int64) error {
+	b.begin("RemoveBuddy")
+	defer b.done()
+	return b.bot.removeBuddy(buddyID)
+}
+
+// GetIgnoredPlayers gets our ignore list
+func (b *Prioritize) GetIgnoredPlayers() ([]IgnoredPlayer, error) {
+	b.begin("GetIgnoredPlayers")
+	defer b.done()
+	return b.bot.getIgnoredPlayers()
+}
+
+// IgnorePlayer adds playerID to our ignore list
+func (b *Prioritize) IgnorePlayer(playerID int64) error {
+	b.begin("IgnorePlayer")
+	defer b.done()
+	return b.bot.ignorePlayer(playerID)
+}
+
+// UnignorePlayer removes playerID from our ignore list
+func (b *Prioritize) UnignorePlayer(playerID int64) error {
+	b.begin("UnignorePlayer")
+	defer b.done()
+	return b.bot.unignorePlayer(playerID)
+}
+
 // GetFleets get the player's own fleets activities
 func (b *Prioritize) GetFleets(opts ...Option) ([]Fleet, Slots) {
 	b.begin("GetFleets")
@@ -457,6 +592,13 @@ func (b *Prioritize) SendIPM(planetID PlanetID, coord Coordinate, nbr int64, pri
 	return b.bot.sendIPM(planetID, coord, nbr, priority)
 }
 
+// GetCombatReportMessages gets the summary of each combat reports
+func (b *Prioritize) GetCombatReportMessages() ([]CombatReportSummary, error) {
+	b.begin("GetCombatReportMessages")
+	defer b.done()
+	return b.bot.getCombatReportMessages()
+}
+
 // GetCombatReportSummaryFor gets the latest combat report for a given coordinate
 func (b *Prioritize) GetCombatReportSummaryFor(coord Coordinate) (CombatReportSummary, error) {
 	b.begin("GetCombatReportSummaryFor")
@@ -514,6 +656,34 @@ func (b *Prioritize) GetEspionageReport(msgID int64) (EspionageReport, error) {
 	return b.bot.getEspionageReport(msgID)
 }
 
+// GetMessages gets a page of messages of a tab, and the number of pages
+func (b *Prioritize) GetMessages(tab MessagesTab, page int64) ([]Message, int64, error) {
+	b.begin("GetMessages")
+	defer b.done()
+	return b.bot.getMessages(tab, page)
+}
+
+// GetAllMessages gets the messages of every page of a tab
+func (b *Prioritize) GetAllMessages(tab MessagesTab) ([]Message, error) {
+	b.begin("GetAllMessages")
+	defer b.done()
+	return b.bot.getAllMessages(tab)
+}
+
+// FavoriteMessage marks a message as favorite
+func (b *Prioritize) FavoriteMessage(msgID int64) error {
+	b.begin("FavoriteMessage")
+	defer b.done()
+	return b.bot.favoriteMessage(msgID)
+}
+
+// UnfavoriteMessage removes a message from the favorites
+func (b *Prioritize) UnfavoriteMessage(msgID int64) error {
+	b.begin("UnfavoriteMessage")
+	defer b.done()
+	return b.bot.unfavoriteMessage(msgID)
+}
+
 // DeleteMessage deletes a message from the mail box
 func (b *Prioritize) DeleteMessage(msgID int64) error {
 	b.begin("DeleteMessage")
@@ -633,6 +803,27 @@ func (b *Prioritize) DoAuction(bid map[CelestialID]Resources) error {
 	return b.bot.doAuction(CelestialID(0), bid)
 }
 
+// GetResourceMerchant gets the resource market of a celestial
+func (b *Prioritize) GetResourceMerchant(celestialID CelestialID) (ResourceMerchant, error) {
+	b.begin("GetResourceMerchant")
+	defer b.done()
+	return b.bot.getResourceMerchant(celestialID)
+}
+
+// CallResourceMerchant calls a merchant selling resource
+func (b *Prioritize) CallResourceMerchant(celestialID CelestialID, resource MerchantResource) (ResourceMerchant, error) {
+	b.begin("CallResourceMerchant")
+	defer b.done()
+	return b.bot.callResourceMerchant(celestialID, resource)
+}
+
+// TradeResourceMerchant buys amount of the merchant resource with the resources of celestialID
+func (b *Prioritize) TradeResourceMerchant(celestialID CelestialID, amount int64) (Resources, error) {
+	b.begin("TradeResourceMerchant")
+	defer b.done()
+	return b.bot.tradeResourceMerchant(celestialID, amount)
+}
+
 // Highscore ...
 func (b *Prioritize) Highscore(category, typ, page int64) (Highscore, error) {
 	b.begin("Highscore")
@@ -690,6 +881,8 @@ func (b *Prioritize) BuyMarketplace(itemID int64, celestialID CelestialID) error
 }
 
 // OfferSellMarketplace ...
+//
+// Deprecated: use CreateMarketplaceOffer, it takes a typed item
 func (b *Prioritize) OfferSellMarketplace(itemID interface{}, quantity, priceType, price, priceRange int64, celestialID CelestialID) error {
 	b.begin("OfferSellMarketplace")
 	defer b.done()
@@ -697,8 +890,52 @@ func (b *Prioritize) OfferSellMarketplace(itemID interface{}, quantity, priceTyp
 }
 
 // OfferBuyMarketplace ...
+//
+// Deprecated: use CreateMarketplaceOffer, it takes a typed item
 func (b *Prioritize) OfferBuyMarketplace(itemID interface{}, quantity, priceType, price, priceRange int64, celestialID CelestialID) error {
 	b.begin("OfferBuyMarketplace")
 	defer b.done()
 	return b.bot.offerMarketplace(3, itemID, quantity, priceType, price, priceRange, celestialID)
 }
+
+// CreateMarketplaceOffer ...
+func (b *Prioritize) CreateMarketplaceOffer(offer MarketplaceOfferRequest) error {
+	b.begin("CreateMarketplaceOffer")
+	defer b.done()
+	return b.bot.createMarketplaceOffer(offer)
+}
+
+// SellMarketplace sell to a request of the marketplace selling tab
+func (b *Prioritize) SellMarketplace(itemID int64, celestialID CelestialID) error {
+	b.begin("SellMarketplace")
+	defer b.done()
+	return b.bot.sellMarketplace(itemID, celestialID)
+}
+
+// GetMarketplaceMessages returns the marketplace purchases and sales messages
+func (b *Prioritize) GetMarketplaceMessages() ([]MarketplaceMessage, error) {
+	b.begin("GetMarketplaceMessages")
+	defer b.done()
+	return b.bot.getMarketplaceMessagesAll()
+}
+
+// GetMarketplaceOffers returns the marketplace offers matching the filter
+func (b *Prioritize) GetMarketplaceOffers(filter MarketplaceFilter) ([]MarketplaceOffer, error) {
+	b.begin("GetMarketplaceOffers")
+	defer b.done()
+	return b.bot.getMarketplaceOffers(filter)
+}
+
+// GetMyMarketplaceOffers returns our active marketplace offers
+func (b *Prioritize) GetMyMarketplaceOffers() ([]MarketplaceOffer, error) {
+	b.begin("GetMyMarketplaceOffers")
+	defer b.done()
+	return b.bot.getMyMarketplaceOffers()
+}
+
+// CancelMarketplaceOffer cancels one of our active marketplace offers
+func (b *Prioritize) CancelMarketplaceOffer(offerID int64) error {
+	b.begin("CancelMarketplaceOffer")
+	defer b.done()
+	return b.bot.cancelMarketplaceOffer(offerID)
+}
@@ -0,0 +1,442 @@
+package ogame
+
+import (
+	"encoding/xml"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Update intervals of the public xml api files
+const (
+	PlayersUpdateInterval      = 24 * time.Hour
+	AlliancesUpdateInterval    = 24 * time.Hour
+	UniverseUpdateInterval     = 7 * 24 * time.Hour
+	HighscoreUpdateInterval    = time.Hour
+	PlayerDataUpdateInterval   = 7 * 24 * time.Hour
+	LocalizationUpdateInterval = 30 * 24 * time.Hour
+	ServerDataUpdateInterval   = 24 * time.Hour
+)
+
+// publicAPIMinRefresh minimum delay before fetching a file again, when the server is late regenerating it
+const publicAPIMinRefresh = 5 * time.Minute
+
+// APIPlayers players.xml
+type APIPlayers struct {
+	Timestamp int64       `xml:"timestamp,attr"`
+	ServerID  string      `xml:"serverId,attr"`
+	Players   []APIPlayer `xml:"player"`
+}
+
+// ByID returns the players indexed by id
+func (p APIPlayers) ByID() map[int64]APIPlayer {
+	out := make(map[int64]APIPlayer, len(p.Players))
+	for _, player := range p.Players {
+		out[player.ID] = player
+	}
+	return out
+}
+
+// APIPlayer player of players.xml
+type APIPlayer struct {
+	ID         int64  `xml:"id,attr"`
+	Name       string `xml:"name,attr"`
+	Status     string `xml:"status,attr"` // Combination of a, b, i, I, o, v, empty for an active player
+	AllianceID int64  `xml:"alliance,attr"`
+}
+
+// IsInactive returns either or not the player is inactive for more than 7 days
+func (p APIPlayer) IsInactive() bool {
+	return strings.ContainsAny(p.Status, "iI")
+}
+
+// IsLongInactive returns either or not the player is inactive for more than 28 days
+func (p APIPlayer) IsLongInactive() bool {
+	return strings.Contains(p.Status, "I")
+}
+
+// IsVacation returns either or not the player is in vacation mode
+func (p APIPlayer) IsVacation() bool {
+	return strings.Contains(p.Status, "v")
+}
+
+// IsBanned returns either or not the player is banned
+func (p APIPlayer) IsBanned() bool {
+	return strings.Contains(p.Status, "b")
+}
+
+// IsOutlaw returns either or not the player is an outlaw
+func (p APIPlayer) IsOutlaw() bool {
+	return strings.Contains(p.Status, "o")
+}
+
+// IsAdmin returns either or not the player is a game administrator
+func (p APIPlayer) IsAdmin() bool {
+	return strings.Contains(p.Status, "a")
+}
+
+// APIAlliances alliances.xml
+type APIAlliances struct {
+	Timestamp int64         `xml:"timestamp,attr"`
+	ServerID  string        `xml:"serverId,attr"`
+	Alliances []APIAlliance `xml:"alliance"`
+}
+
+// ByID returns the alliances indexed by id
+func (a APIAlliances) ByID() map[int64]APIAlliance {
+	out := make(map[int64]APIAlliance, len(a.Alliances))
+	for _, alliance := range a.Alliances {
+		out[alliance.ID] = alliance
+	}
+	return out
+}
+
+// APIAlliance alliance of alliances.xml
+type APIAlliance struct {
+	ID        int64               `xml:"id,attr"`
+	Name      string              `xml:"name,attr"`
+	Tag       string              `xml:"tag,attr"`
+	FounderID int64               `xml:"founder,attr"`
+	FoundDate int64               `xml:"foundDate,attr"`
+	Logo      string              `xml:"logo,attr"`
+	Homepage  string              `xml:"homepage,attr"`
+	Open      bool                `xml:"open,attr"`
+	Members   []APIAllianceMember `xml:"player"`
+}
+
+// APIAllianceMember member of an alliance of alliances.xml
+type APIAllianceMember struct {
+	ID int64 `xml:"id,attr"`
+}
+
+// APIUniverse universe.xml
+type APIUniverse struct {
+	Timestamp int64       `xml:"timestamp,attr"`
+	ServerID  string      `xml:"serverId,attr"`
+	Planets   []APIPlanet `xml:"planet"`
+}
+
+// ByPlayer returns the planets indexed by owner id
+func (u APIUniverse) ByPlayer() map[int64][]APIPlanet {
+	out := make(map[int64][]APIPlanet)
+	for _, planet := range u.Planets {
+		out[planet.PlayerID] = append(out[planet.PlayerID], planet)
+	}
+	return out
+}
+
+// APIPlanet planet of universe.xml and playerData.xml
+type APIPlanet struct {
+	ID       int64    `xml:"id,attr"`
+	PlayerID int64    `xml:"player,attr"` // Not set in playerData.xml
+	Name     string   `xml:"name,attr"`
+	Coords   string   `xml:"coords,attr"` // 1:2:3
+	Moon     *APIMoon `xml:"moon"`
+}
+
+// Coordinate returns the coordinate of the planet
+func (p APIPlanet) Coordinate() Coordinate {
+	coord := extractCoordV6("[" + p.Coords + "]")
+	coord.Type = PlanetType
+	return coord
+}
+
+// APIMoon moon of a planet of universe.xml and playerData.xml
+type APIMoon struct {
+	ID   int64  `xml:"id,attr"`
+	Name string `xml:"name,attr"`
+	Size int64  `xml:"size,attr"`
+}
+
+// APIHighscore highscore.xml
+type APIHighscore struct {
+	Category  int64               `xml:"category,attr"`
+	Type      int64               `xml:"type,attr"`
+	Timestamp int64               `xml:"timestamp,attr"`
+	ServerID  string              `xml:"serverId,attr"`
+	Players   []APIHighscoreEntry `xml:"player"`   // Players category
+	Alliances []APIHighscoreEntry `xml:"alliance"` // Alliances category
+}
+
+// APIHighscoreEntry entry of highscore.xml
+type APIHighscoreEntry struct {
+	Position int64 `xml:"position,attr"`
+	ID       int64 `xml:"id,attr"`
+	Score    int64 `xml:"score,attr"`
+	Ships    int64 `xml:"ships,attr"` // Military type only
+}
+
+// APIPlayerData playerData.xml
+type APIPlayerData struct {
+	ID        int64               `xml:"id,attr"`
+	Name      string              `xml:"name,attr"`
+	Timestamp int64               `xml:"timestamp,attr"`
+	ServerID  string              `xml:"serverId,attr"`
+	Positions []APIPlayerPosition `xml:"positions>position"`
+	Planets   []APIPlanet         `xml:"planets>planet"`
+	Alliance  *APIPlayerAlliance  `xml:"alliance"`
+}
+
+// Position returns the highscore position of the given type, nil if not ranked
+func (p APIPlayerData) Position(typ int64) *APIPlayerPosition {
+	for i := range p.Positions {
+		if p.Positions[i].Type == typ {
+			return &p.Positions[i]
+		}
+	}
+	return nil
+}
+
+// APIPlayerPosition highscore position of playerData.xml
+type APIPlayerPosition struct {
+	Type     int64 `xml:"type,attr"`
+	Score    int64 `xml:"score,attr"`
+	Ships    int64 `xml:"ships,attr"` // Military type only
+	Position int64 `xml:",chardata"`
+}
+
+// APIPlayerAlliance alliance of playerData.xml
+type APIPlayerAlliance struct {
+	ID   int64  `xml:"id,attr"`
+	Name string `xml:"name"`
+	Tag  string `xml:"tag"`
+}
+
+// APILocalization localization.xml
+type APILocalization struct {
+	Timestamp int64                 `xml:"timestamp,attr"`
+	ServerID  string                `xml:"serverId,attr"`
+	Techs     []APILocalizationName `xml:"techs>name"`
+	Missions  []APILocalizationName `xml:"missions>name"`
+}
+
+// TechName returns the translated name of a building, research, ship or defense
+func (l APILocalization) TechName(id ID) string {
+	for _, name := range l.Techs {
+		if name.ID == int64(id) {
+			return name.Name
+		}
+	}
+	return ""
+}
+
+// MissionName returns the translated name of a mission
+func (l APILocalization) MissionName(mission MissionID) string {
+	for _, name := range l.Missions {
+		if name.ID == int64(mission) {
+			return name.Name
+		}
+	}
+	return ""
+}
+
+// APILocalizationName translated name of localization.xml
+type APILocalizationName struct {
+	ID   int64  `xml:"id,attr"`
+	Name string `xml:",chardata"`
+}
+
+type publicAPICacheEntry struct {
+	by        []byte
+	expiresAt time.Time
+}
+
+// PublicAPI client of the public xml api of a server, it does not need to be logged in.
+// Files are cached until the server regenerates them.
+type PublicAPI struct {
+	sync.Mutex
+	client   *http.Client
+	baseURL  string
+	dir      string // Offline mode, files are read from dir
+	cacheDir string
+	cache    map[string]publicAPICacheEntry
+	fileLock map[string]*sync.Mutex
+}
+
+// NewPublicAPI creates a client for the api of the server number and lang, eg: 157, "ru"
+func NewPublicAPI(serverNumber int64, lang string) *PublicAPI {
+	return &PublicAPI{
+		client:   &http.Client{Timeout: 30 * time.Second},
+		baseURL:  "https://s" + strconv.FormatInt(serverNumber, 10) + "-" + lang + ".ogame.gameforge.com/api/",
+		cache:    make(map[string]publicAPICacheEntry),
+		fileLock: make(map[string]*sync.Mutex),
+	}
+}
+
+// NewOfflinePublicAPI creates a client reading previously saved files from dir,
+// eg: players.xml, highscore_1_0.xml, playerData_100123.xml
+func NewOfflinePublicAPI(dir string) *PublicAPI {
+	return &PublicAPI{dir: dir, cache: make(map[string]publicAPICacheEntry), fileLock: make(map[string]*sync.Mutex)}
+}
+
+// SetClient sets the http client used to fetch the files
+func (a *PublicAPI) SetClient(client *http.Client) *PublicAPI {
+	a.client = client
+	return a
+}
+
+// SetBaseURL sets the url of the api, eg: https://s157-ru.ogame.gameforge.com/api/
+func (a *PublicAPI) SetBaseURL(baseURL string) *PublicAPI {
+	a.baseURL = strings.TrimSuffix(baseURL, "/") + "/"
+	return a
+}
+
+// SetCacheDir saves the fetched files in dir, they are reused across restarts until they expire.
+// The directory can later be loaded with NewOfflinePublicAPI.
+func (a *PublicAPI) SetCacheDir(dir string) *PublicAPI {
+	a.cacheDir = dir
+	return a
+}
+
+// publicAPIExpiration returns when a file generated at its timestamp will be regenerated
+func publicAPIExpiration(by []byte, fetchedAt time.Time, interval time.Duration) time.Time {
+	var root struct {
+		Timestamp int64 `xml:"timestamp,attr"`
+	}
+	expiresAt := fetchedAt.Add(interval)
+	if err := xml.Unmarshal(by, &root); err == nil && root.Timestamp > 0 {
+		expiresAt = time.Unix(root.Timestamp, 0).Add(interval)
+	}
+	if minExpiration := fetchedAt.Add(publicAPIMinRefresh); expiresAt.Before(minExpiration) {
+		expiresAt = minExpiration
+	}
+	return expiresAt
+}
+
+// lockFile locks the file so it is fetched only once at a time, other files can be fetched meanwhile
+func (a *PublicAPI) lockFile(filename string) *sync.Mutex {
+	a.Lock()
+	mu, ok := a.fileLock[filename]
+	if !ok {
+		mu = &sync.Mutex{}
+		a.fileLock[filename] = mu
+	}
+	a.Unlock()
+	mu.Lock()
+	return mu
+}
+
+func (a *PublicAPI) getCache(filename string) (publicAPICacheEntry, bool) {
+	a.Lock()
+	defer a.Unlock()
+	entry, ok := a.cache[filename]
+	return entry, ok
+}
+
+func (a *PublicAPI) setCache(filename string, entry publicAPICacheEntry) {
+	a.Lock()
+	defer a.Unlock()
+	a.cache[filename] = entry
+}
+
+// get returns the content of a file, from the cache if it did not expire
+func (a *PublicAPI) get(endpoint, filename string, interval time.Duration) ([]byte, error) {
+	if a.dir != "" {
+		return ioutil.ReadFile(filepath.Join(a.dir, filename))
+	}
+	defer a.lockFile(filename).Unlock()
+	now := time.Now()
+	if entry, ok := a.getCache(filename); ok && now.Before(entry.expiresAt) {
+		return entry.by, nil
+	}
+	if a.cacheDir != "" {
+		cacheFile := filepath.Join(a.cacheDir, filename)
+		if fi, err := os.Stat(cacheFile); err == nil {
+			if by, err := ioutil.ReadFile(cacheFile); err == nil {
+				entry := publicAPICacheEntry{by: by, expiresAt: publicAPIExpiration(by, fi.ModTime(), interval)}
+				if now.Before(entry.expiresAt) {
+					a.setCache(filename, entry)
+					return by, nil
+				}
+			}
+		}
+	}
+	req, err := http.NewRequest("GET", a.baseURL+endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("Accept-Encoding", "gzip")
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	by, _, err := readBody(resp)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.New("failed to get " + endpoint + " : " + resp.Status)
+	}
+	a.setCache(filename, publicAPICacheEntry{by: by, expiresAt: publicAPIExpiration(by, now, interval)})
+	if a.cacheDir != "" {
+		if err := ioutil.WriteFile(filepath.Join(a.cacheDir, filename), by, 0644); err != nil {
+			return by, err
+		}
+	}
+	return by, nil
+}
+
+func (a *PublicAPI) getXML(endpoint, filename string, interval time.Duration, v interface{}) error {
+	by, err := a.get(endpoint, filename, interval)
+	if err != nil {
+		return err
+	}
+	return xml.Unmarshal(by, v)
+}
+
+// GetPlayers gets players.xml
+func (a *PublicAPI) GetPlayers() (out APIPlayers, err error) {
+	err = a.getXML("players.xml", "players.xml", PlayersUpdateInterval, &out)
+	return
+}
+
+// GetAlliances gets alliances.xml
+func (a *PublicAPI) GetAlliances() (out APIAlliances, err error) {
+	err = a.getXML("alliances.xml", "alliances.xml", AlliancesUpdateInterval, &out)
+	return
+}
+
+// GetUniverse gets universe.xml
+func (a *PublicAPI) GetUniverse() (out APIUniverse, err error) {
+	err = a.getXML("universe.xml", "universe.xml", UniverseUpdateInterval, &out)
+	return
+}
+
+// GetHighscore gets highscore.xml for a category (1:Player, 2:Alliance) and a type (0-7)
+func (a *PublicAPI) GetHighscore(category, typ int64) (out APIHighscore, err error) {
+	if category < 1 || category > 2 {
+		return out, errors.New("category must be in [1, 2] (1:player, 2:alliance)")
+	}
+	if typ < 0 || typ > 7 {
+		return out, errors.New("typ must be in [0, 7]")
+	}
+	c, t := strconv.FormatInt(category, 10), strconv.FormatInt(typ, 10)
+	err = a.getXML("highscore.xml?category="+c+"&type="+t, "highscore_"+c+"_"+t+".xml", HighscoreUpdateInterval, &out)
+	return
+}
+
+// GetPlayerData gets playerData.xml of a player
+func (a *PublicAPI) GetPlayerData(playerID int64) (out APIPlayerData, err error) {
+	id := strconv.FormatInt(playerID, 10)
+	err = a.getXML("playerData.xml?id="+id, "playerData_"+id+".xml", PlayerDataUpdateInterval, &out)
+	return
+}
+
+// GetLocalization gets localization.xml
+func (a *PublicAPI) GetLocalization() (out APILocalization, err error) {
+	err = a.getXML("localization.xml", "localization.xml", LocalizationUpdateInterval, &out)
+	return
+}
+
+// GetServerData gets serverData.xml
+func (a *PublicAPI) GetServerData() (out ServerData, err error) {
+	err = a.getXML("serverData.xml", "serverData.xml", ServerDataUpdateInterval, &out)
+	return
+}
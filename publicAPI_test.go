@@ -0,0 +1,160 @@
+package ogame
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOfflinePublicAPI(t *testing.T) {
+	api := NewOfflinePublicAPI("samples/api")
+
+	players, err := api.GetPlayers()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1601280000), players.Timestamp)
+	assert.Equal(t, 5, len(players.Players))
+	assert.Equal(t, APIPlayer{ID: 100001, Name: "Zephyr", AllianceID: 500123}, players.Players[0])
+	byID := players.ByID()
+	assert.True(t, byID[100234].IsVacation())
+	assert.True(t, byID[100871].IsInactive())
+	assert.True(t, byID[100871].IsLongInactive())
+	assert.True(t, byID[101002].IsInactive())
+	assert.False(t, byID[101002].IsLongInactive())
+	assert.True(t, byID[1].IsAdmin())
+	assert.False(t, byID[100001].IsInactive())
+
+	alliances, err := api.GetAlliances()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(alliances.Alliances))
+	frontier := alliances.ByID()[500123]
+	assert.Equal(t, "FRNT", frontier.Tag)
+	assert.Equal(t, int64(100001), frontier.FounderID)
+	assert.True(t, frontier.Open)
+	assert.Equal(t, []APIAllianceMember{{ID: 100001}, {ID: 100234}}, frontier.Members)
+	assert.False(t, alliances.ByID()[500456].Open)
+
+	universe, err := api.GetUniverse()
+	assert.NoError(t, err)
+	assert.Equal(t, 4, len(universe.Planets))
+	assert.Equal(t, Coordinate{1, 40, 7, PlanetType}, universe.Planets[0].Coordinate())
+	assert.Equal(t, &APIMoon{ID: 33620110, Name: "Moon", Size: 8602}, universe.Planets[0].Moon)
+	assert.Nil(t, universe.Planets[1].Moon)
+	assert.Equal(t, 2, len(universe.ByPlayer()[100001]))
+
+	highscore, err := api.GetHighscore(PlayerHighscore, MilitaryHighscore)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), highscore.Type)
+	assert.Equal(t, APIHighscoreEntry{Position: 1, ID: 100001, Score: 2451200, Ships: 18420}, highscore.Players[0])
+	highscore, err = api.GetHighscore(AllianceHighscore, TotalHighscore)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(highscore.Players))
+	assert.Equal(t, APIHighscoreEntry{Position: 2, ID: 500456, Score: 1050}, highscore.Alliances[1])
+	_, err = api.GetHighscore(3, 0)
+	assert.Error(t, err)
+
+	playerData, err := api.GetPlayerData(100001)
+	assert.NoError(t, err)
+	assert.Equal(t, "Zephyr", playerData.Name)
+	assert.Equal(t, 8, len(playerData.Positions))
+	assert.Equal(t, APIPlayerPosition{Type: 3, Score: 2451200, Ships: 18420, Position: 1}, *playerData.Position(MilitaryHighscore))
+	assert.Equal(t, 2, len(playerData.Planets))
+	assert.Equal(t, Coordinate{1, 42, 9, PlanetType}, playerData.Planets[1].Coordinate())
+	assert.Equal(t, APIPlayerAlliance{ID: 500123, Name: "Frontier", Tag: "FRNT"}, *playerData.Alliance)
+	_, err = api.GetPlayerData(123)
+	assert.Error(t, err)
+
+	localization, err := api.GetLocalization()
+	assert.NoError(t, err)
+	assert.Equal(t, "Small Cargo", localization.TechName(SmallCargoID))
+	assert.Equal(t, "Expedition", localization.MissionName(Expedition))
+	assert.Equal(t, "", localization.TechName(DeathstarID))
+
+	serverData, err := api.GetServerData()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(499), serverData.Systems)
+	assert.True(t, serverData.DonutSystem)
+}
+
+func TestPublicAPI_Cache(t *testing.T) {
+	players, _ := ioutil.ReadFile("samples/api/players.xml")
+	hits := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		if r.URL.Path != "/api/players.xml" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(players)
+	}))
+	defer srv.Close()
+
+	dir, _ := ioutil.TempDir("", "publicapi")
+	defer os.RemoveAll(dir)
+	api := NewPublicAPI(157, "ru").SetBaseURL(srv.URL + "/api").SetCacheDir(dir)
+	res, err := api.GetPlayers()
+	assert.NoError(t, err)
+	assert.Equal(t, 5, len(res.Players))
+	_, _ = api.GetPlayers()
+	assert.Equal(t, 1, hits)
+	_, err = os.Stat(filepath.Join(dir, "players.xml"))
+	assert.NoError(t, err)
+
+	// A new client reuses the files of the cache directory
+	api = NewPublicAPI(157, "ru").SetBaseURL(srv.URL + "/api").SetCacheDir(dir)
+	_, _ = api.GetPlayers()
+	assert.Equal(t, 1, hits)
+
+	_, err = api.GetAlliances()
+	assert.Error(t, err)
+	assert.Equal(t, 2, hits)
+}
+
+func TestPublicAPIExpiration(t *testing.T) {
+	fetchedAt := time.Unix(1601280000, 0)
+	by := []byte(`<players timestamp="1601280000"></players>`)
+	assert.Equal(t, time.Unix(1601280000, 0).Add(24*time.Hour), publicAPIExpiration(by, fetchedAt.Add(time.Hour), PlayersUpdateInterval))
+	assert.Equal(t, fetchedAt.Add(48*time.Hour+publicAPIMinRefresh), publicAPIExpiration(by, fetchedAt.Add(48*time.Hour), PlayersUpdateInterval))
+	assert.Equal(t, fetchedAt.Add(time.Hour), publicAPIExpiration([]byte(`<serverData></serverData>`), fetchedAt, time.Hour))
+}
+
+func TestPublicAPI_ConcurrentFiles(t *testing.T) {
+	players, _ := ioutil.ReadFile("samples/api/players.xml")
+	alliances, _ := ioutil.ReadFile("samples/api/alliances.xml")
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/players.xml" {
+			<-release
+			_, _ = w.Write(players)
+			return
+		}
+		_, _ = w.Write(alliances)
+	}))
+	defer srv.Close()
+
+	api := NewPublicAPI(157, "ru").SetBaseURL(srv.URL + "/api")
+	playersDone := make(chan error)
+	go func() {
+		_, err := api.GetPlayers()
+		playersDone <- err
+	}()
+	// A slow file does not block the others
+	alliancesDone := make(chan error)
+	go func() {
+		_, err := api.GetAlliances()
+		alliancesDone <- err
+	}()
+	select {
+	case err := <-alliancesDone:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("alliances blocked by players")
+	}
+	close(release)
+	assert.NoError(t, <-playersDone)
+}
@@ -0,0 +1,281 @@
+package ogame
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// RaidTarget a target to be raided repeatedly
+type RaidTarget struct {
+	Coordinate   Coordinate
+	ExpectedLoot Resources
+}
+
+// RaidPlan a fleet that is (or would be in dry-run) sent to a target
+type RaidPlan struct {
+	Target     RaidTarget
+	Origin     Celestial
+	Ships      ShipsInfos
+	FlightTime int64
+	Fuel       int64
+}
+
+// RaidRecord outcome of a raid
+type RaidRecord struct {
+	Target     RaidTarget
+	Origin     Celestial
+	Fleet      Fleet
+	ActualLoot Resources
+	ReturnedAt time.Time
+	Err        error
+}
+
+type ongoingRaid struct {
+	plan  RaidPlan
+	fleet Fleet
+}
+
+// RaidScheduler sends attack missions on a set of targets, and re-queues them when the fleets return
+type RaidScheduler struct {
+	sync.Mutex
+	b             Wrapper
+	origins       []Celestial
+	queue         []RaidTarget
+	ongoing       []ongoingRaid
+	history       []RaidRecord
+	speed         Speed
+	keepSlots     int64
+	dryRun        bool
+	reportTimeout time.Duration
+}
+
+// NewRaidScheduler ...
+func NewRaidScheduler(b Wrapper, origins ...Celestial) *RaidScheduler {
+	s := new(RaidScheduler)
+	s.b = b
+	s.origins = origins
+	s.speed = HundredPercent
+	s.reportTimeout = 15 * time.Minute
+	return s
+}
+
+// SetSpeed ...
+func (s *RaidScheduler) SetSpeed(speed Speed) *RaidScheduler {
+	s.speed = speed
+	return s
+}
+
+// SetKeepSlots number of fleet slots that must stay free
+func (s *RaidScheduler) SetKeepSlots(keepSlots int64) *RaidScheduler {
+	s.keepSlots = keepSlots
+	return s
+}
+
+// SetDryRun when enabled, Tick only returns the plan without sending any fleet
+func (s *RaidScheduler) SetDryRun(dryRun bool) *RaidScheduler {
+	s.dryRun = dryRun
+	return s
+}
+
+// SetReportTimeout how long after a fleet came back to keep looking for its combat report
+func (s *RaidScheduler) SetReportTimeout(reportTimeout time.Duration) *RaidScheduler {
+	s.reportTimeout = reportTimeout
+	return s
+}
+
+// AddTargets ...
+func (s *RaidScheduler) AddTargets(targets ...RaidTarget) *RaidScheduler {
+	s.Lock()
+	defer s.Unlock()
+	s.queue = append(s.queue, targets...)
+	return s
+}
+
+// AddFarmTargets adds the farm targets that have a loot
+func (s *RaidScheduler) AddFarmTargets(targets ...FarmTarget) *RaidScheduler {
+	for _, target := range targets {
+		if target.Loot.Total() > 0 {
+			s.AddTargets(RaidTarget{Coordinate: target.Coordinate, ExpectedLoot: target.Loot})
+		}
+	}
+	return s
+}
+
+// Queue returns the targets waiting to be raided
+func (s *RaidScheduler) Queue() []RaidTarget {
+	s.Lock()
+	defer s.Unlock()
+	return append([]RaidTarget{}, s.queue...)
+}
+
+// History returns the raids that came back
+func (s *RaidScheduler) History() []RaidRecord {
+	s.Lock()
+	defer s.Unlock()
+	return append([]RaidRecord{}, s.history...)
+}
+
+// cargoShipsFor picks large cargos first, then small cargos, from the available ships to carry the loot.
+// Returns false if the available ships cannot carry the whole loot.
+func cargoShipsFor(loot Resources, available ShipsInfos, techs Researches, probeRaids, isCollector, isPioneers bool) (out ShipsInfos, ok bool) {
+	need := loot.Total()
+	if need <= 0 {
+		return out, false
+	}
+	lcCapacity := LargeCargo.GetCargoCapacity(techs, probeRaids, isCollector, isPioneers)
+	scCapacity := SmallCargo.GetCargoCapacity(techs, probeRaids, isCollector, isPioneers)
+	nbLC := MinInt(available.LargeCargo, need/lcCapacity)
+	remaining := need - nbLC*lcCapacity
+	var nbSC int64
+	if remaining > 0 {
+		nbSC = (remaining + scCapacity - 1) / scCapacity
+		if nbSC > available.SmallCargo || nbSC*scCapacity > lcCapacity {
+			if nbLC < available.LargeCargo {
+				nbLC++
+				nbSC = 0
+			} else if nbSC > available.SmallCargo {
+				return out, false
+			}
+		}
+	}
+	out.LargeCargo = nbLC
+	out.SmallCargo = nbSC
+	return out, out.HasShips()
+}
+
+// Plan computes which origin and ships would be used for every queued target, without sending anything
+func (s *RaidScheduler) Plan() ([]RaidPlan, error) {
+	s.Lock()
+	queue := append([]RaidTarget{}, s.queue...)
+	s.Unlock()
+	plans, _, err := s.plan(queue)
+	return plans, err
+}
+
+func (s *RaidScheduler) plan(queue []RaidTarget) (plans []RaidPlan, unplanned []RaidTarget, err error) {
+	if len(s.origins) == 0 {
+		return nil, queue, errors.New("no origin")
+	}
+	techs := s.b.GetCachedResearch()
+	probeRaids := s.b.GetServer().Settings.EspionageProbeRaids == 1
+	isCollector := s.b.CharacterClass().IsCollector()
+	isPioneers := s.b.IsPioneers()
+	available := make([]ShipsInfos, len(s.origins))
+	for i, origin := range s.origins {
+		if available[i], err = s.b.GetShips(origin.GetID()); err != nil {
+			return nil, queue, err
+		}
+	}
+	slots := s.b.GetSlots()
+	freeSlots := slots.Total - slots.InUse - s.keepSlots
+	for _, target := range queue {
+		if int64(len(plans)) >= freeSlots {
+			unplanned = append(unplanned, target)
+			continue
+		}
+		best := -1
+		var bestPlan RaidPlan
+		for i, origin := range s.origins {
+			ships, ok := cargoShipsFor(target.ExpectedLoot, available[i], techs, probeRaids, isCollector, isPioneers)
+			if !ok {
+				continue
+			}
+			secs, fuel := s.b.FlightTime(origin.GetCoordinate(), target.Coordinate, s.speed, ships, Attack)
+			if best == -1 || secs < bestPlan.FlightTime {
+				best = i
+				bestPlan = RaidPlan{Target: target, Origin: origin, Ships: ships, FlightTime: secs, Fuel: fuel}
+			}
+		}
+		if best == -1 {
+			unplanned = append(unplanned, target)
+			continue
+		}
+		available[best].SubShips(LargeCargoID, bestPlan.Ships.LargeCargo)
+		available[best].SubShips(SmallCargoID, bestPlan.Ships.SmallCargo)
+		plans = append(plans, bestPlan)
+	}
+	return plans, unplanned, nil
+}
+
+// collect records the raids whose fleets came back and put their targets back in the queue.
+// A raid is only collected once the combat report of its attack is available, the re-queued target
+// then expects the loot that was actually taken. Targets that gave no loot are dropped from the queue.
+func (s *RaidScheduler) collect() {
+	now := s.b.ServerTime()
+	ongoing := make([]ongoingRaid, 0, len(s.ongoing))
+	for _, raid := range s.ongoing {
+		if now.Before(raid.fleet.BackTime) {
+			ongoing = append(ongoing, raid)
+			continue
+		}
+		target := raid.plan.Target
+		record := RaidRecord{Target: target, Origin: raid.plan.Origin, Fleet: raid.fleet, ReturnedAt: now}
+		summary, err := s.b.GetCombatReportSummaryFor(target.Coordinate)
+		if err == nil && summary.CreatedAt.Before(raid.fleet.ArrivalTime) {
+			err = errors.New("no combat report since the raid arrived on " + target.Coordinate.String())
+		}
+		if err != nil {
+			if now.Before(raid.fleet.BackTime.Add(s.reportTimeout)) {
+				ongoing = append(ongoing, raid)
+				continue
+			}
+			record.Err = err
+		} else {
+			record.ActualLoot = Resources{Metal: summary.Metal, Crystal: summary.Crystal, Deuterium: summary.Deuterium}
+			target.ExpectedLoot = record.ActualLoot
+		}
+		s.history = append(s.history, record)
+		if record.Err == nil && record.ActualLoot.Total() <= 0 {
+			continue
+		}
+		s.queue = append(s.queue, target)
+	}
+	s.ongoing = ongoing
+}
+
+// Tick collects returned fleets, then sends attacks on queued targets as long as slots and ships are available.
+// Returns the raids that were sent (or planned in dry-run).
+func (s *RaidScheduler) Tick() ([]RaidPlan, error) {
+	s.Lock()
+	defer s.Unlock()
+	if !s.dryRun {
+		s.collect()
+	}
+	plans, unplanned, err := s.plan(s.queue)
+	if err != nil || s.dryRun {
+		return plans, err
+	}
+	sent := make([]RaidPlan, 0, len(plans))
+	for _, plan := range plans {
+		fleet, err := NewFleetBuilder(s.b).
+			SetOrigin(plan.Origin).
+			SetDestination(plan.Target.Coordinate).
+			SetMission(Attack).
+			SetSpeed(s.speed).
+			SetShips(plan.Ships).
+			SendNow()
+		if err != nil {
+			unplanned = append(unplanned, plan.Target)
+			continue
+		}
+		s.ongoing = append(s.ongoing, ongoingRaid{plan: plan, fleet: fleet})
+		sent = append(sent, plan)
+	}
+	s.queue = unplanned
+	return sent, nil
+}
+
+// Run calls Tick every interval until stop is closed
+func (s *RaidScheduler) Run(interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		_, _ = s.Tick()
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
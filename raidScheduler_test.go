@@ -0,0 +1,111 @@
+package ogame
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeRaidWrapper struct {
+	*fakeFleets
+	combatReports map[Coordinate]CombatReportSummary
+}
+
+func (w *fakeRaidWrapper) GetCombatReportSummaryFor(coord Coordinate) (CombatReportSummary, error) {
+	report, ok := w.combatReports[coord]
+	if !ok {
+		return CombatReportSummary{}, errors.New("combat report not found for " + coord.String())
+	}
+	return report, nil
+}
+
+func TestCargoShipsFor(t *testing.T) {
+	var researches Researches
+	ships, ok := cargoShipsFor(Resources{Metal: 30000}, ShipsInfos{LargeCargo: 5, SmallCargo: 10}, researches, false, false, false)
+	assert.True(t, ok)
+	assert.Equal(t, ShipsInfos{LargeCargo: 1, SmallCargo: 1}, ships)
+
+	ships, ok = cargoShipsFor(Resources{Metal: 45000}, ShipsInfos{LargeCargo: 1, SmallCargo: 10}, researches, false, false, false)
+	assert.True(t, ok)
+	assert.Equal(t, ShipsInfos{LargeCargo: 1, SmallCargo: 4}, ships)
+
+	ships, ok = cargoShipsFor(Resources{Metal: 20000}, ShipsInfos{SmallCargo: 4}, researches, false, false, false)
+	assert.True(t, ok)
+	assert.Equal(t, ShipsInfos{SmallCargo: 4}, ships)
+
+	_, ok = cargoShipsFor(Resources{Metal: 20000}, ShipsInfos{SmallCargo: 3}, researches, false, false, false)
+	assert.False(t, ok)
+
+	_, ok = cargoShipsFor(Resources{}, ShipsInfos{SmallCargo: 3}, researches, false, false, false)
+	assert.False(t, ok)
+}
+
+func TestRaidScheduler_Tick(t *testing.T) {
+	w := &fakeRaidWrapper{fakeFleets: newFakeFleets(), combatReports: make(map[Coordinate]CombatReportSummary)}
+	w.slots.Total = 2
+	near := Planet{ID: 1, Coordinate: Coordinate{1, 100, 8, PlanetType}}
+	far := Planet{ID: 2, Coordinate: Coordinate{2, 100, 8, PlanetType}}
+	w.ships[near.GetID()] = ShipsInfos{SmallCargo: 4}
+	w.ships[far.GetID()] = ShipsInfos{LargeCargo: 10}
+	target1 := RaidTarget{Coordinate: Coordinate{1, 101, 1, PlanetType}, ExpectedLoot: Resources{Metal: 20000}}
+	target2 := RaidTarget{Coordinate: Coordinate{1, 102, 1, PlanetType}, ExpectedLoot: Resources{Metal: 20000}}
+	target3 := RaidTarget{Coordinate: Coordinate{1, 103, 1, PlanetType}, ExpectedLoot: Resources{Metal: 20000}}
+
+	s := NewRaidScheduler(w, near, far).AddTargets(target1, target2, target3).SetDryRun(true)
+	plans, err := s.Tick()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(plans))
+	assert.Equal(t, near.GetID(), plans[0].Origin.GetID())
+	assert.Equal(t, far.GetID(), plans[1].Origin.GetID())
+	assert.Equal(t, int64(1), plans[1].Ships.LargeCargo)
+	assert.Equal(t, 0, len(w.sentFleets))
+	assert.Equal(t, 3, len(s.Queue()))
+
+	sent, err := s.SetDryRun(false).Tick()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(sent))
+	assert.Equal(t, 2, len(w.sentFleets))
+	assert.Equal(t, Attack, w.sentFleets[0].Mission)
+	assert.Equal(t, []RaidTarget{target3}, s.Queue())
+
+	w.now = w.sentFleets[0].BackTime
+	w.slots.InUse = 2
+	w.combatReports[target1.Coordinate] = CombatReportSummary{Metal: 19000, CreatedAt: w.sentFleets[0].ArrivalTime.Add(-time.Hour)}
+	_, _ = s.Tick()
+	assert.Equal(t, 0, len(s.History()))
+
+	w.combatReports[target1.Coordinate] = CombatReportSummary{Metal: 19000, CreatedAt: w.sentFleets[0].ArrivalTime}
+	_, _ = s.Tick()
+	history := s.History()
+	assert.Equal(t, 1, len(history))
+	assert.Equal(t, int64(19000), history[0].ActualLoot.Metal)
+	assert.Equal(t, []RaidTarget{target3, {Coordinate: target1.Coordinate, ExpectedLoot: Resources{Metal: 19000}}}, s.Queue())
+
+	w.now = w.now.Add(time.Hour)
+	_, _ = s.Tick()
+	history = s.History()
+	assert.Equal(t, 2, len(history))
+	assert.Error(t, history[1].Err)
+	assert.Equal(t, 3, len(s.Queue()))
+}
+
+func TestRaidScheduler_EmptyRaidDropped(t *testing.T) {
+	w := &fakeRaidWrapper{fakeFleets: newFakeFleets(), combatReports: make(map[Coordinate]CombatReportSummary)}
+	origin := Planet{ID: 1, Coordinate: Coordinate{1, 100, 8, PlanetType}}
+	w.ships[origin.GetID()] = ShipsInfos{LargeCargo: 10}
+	target := RaidTarget{Coordinate: Coordinate{1, 101, 1, PlanetType}, ExpectedLoot: Resources{Metal: 20000}}
+	s := NewRaidScheduler(w, origin).AddTargets(target)
+	_, _ = s.Tick()
+	assert.Equal(t, 1, len(w.sentFleets))
+
+	w.now = w.sentFleets[0].BackTime
+	w.combatReports[target.Coordinate] = CombatReportSummary{CreatedAt: w.sentFleets[0].ArrivalTime}
+	sent, err := s.Tick()
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(sent))
+	assert.Equal(t, 1, len(s.History()))
+	assert.Equal(t, Resources{}, s.History()[0].ActualLoot)
+	assert.Equal(t, 0, len(s.Queue()))
+}
@@ -73,6 +73,44 @@ func (s Researches) ByID(id ID) int64 {
 	return 0
 }
 
+// Set sets the player research level by research id
+func (s *Researches) Set(id ID, val int64) {
+	switch id {
+	case EnergyTechnologyID:
+		s.EnergyTechnology = val
+	case LaserTechnologyID:
+		s.LaserTechnology = val
+	case IonTechnologyID:
+		s.IonTechnology = val
+	case HyperspaceTechnologyID:
+		s.HyperspaceTechnology = val
+	case PlasmaTechnologyID:
+		s.PlasmaTechnology = val
+	case CombustionDriveID:
+		s.CombustionDrive = val
+	case ImpulseDriveID:
+		s.ImpulseDrive = val
+	case HyperspaceDriveID:
+		s.HyperspaceDrive = val
+	case EspionageTechnologyID:
+		s.EspionageTechnology = val
+	case ComputerTechnologyID:
+		s.ComputerTechnology = val
+	case AstrophysicsID:
+		s.Astrophysics = val
+	case IntergalacticResearchNetworkID:
+		s.IntergalacticResearchNetwork = val
+	case GravitonTechnologyID:
+		s.GravitonTechnology = val
+	case WeaponsTechnologyID:
+		s.WeaponsTechnology = val
+	case ShieldingTechnologyID:
+		s.ShieldingTechnology = val
+	case ArmourTechnologyID:
+		s.ArmourTechnology = val
+	}
+}
+
 func (s Researches) String() string {
 	return "\n" +
 		"             Energy Technology: " + strconv.FormatInt(s.EnergyTechnology, 10) + "\n" +
@@ -0,0 +1,70 @@
+package ogame
+
+import (
+	"errors"
+	"math"
+	"strconv"
+)
+
+// MerchantResource resource sold by the resource merchant
+type MerchantResource string
+
+// Merchant resources
+const (
+	MetalMerchant     MerchantResource = "metal"
+	CrystalMerchant   MerchantResource = "crystal"
+	DeuteriumMerchant MerchantResource = "deuterium"
+)
+
+// IsValid returns either or not the merchant resource is valid
+func (r MerchantResource) IsValid() bool {
+	return r == MetalMerchant || r == CrystalMerchant || r == DeuteriumMerchant
+}
+
+// ResourceMerchant state of the resource market
+type ResourceMerchant struct {
+	Resource        MerchantResource // Resource sold by the merchant, empty if no merchant was called
+	Rates           Multiplier       // Amount of each resource paid for one unit of the merchant resource
+	CallCost        int64            // Dark matter needed to call a merchant
+	Token           string
+	PlanetResources PlanetResources
+}
+
+// Payment returns the resources to pay from celestialID to receive amount of the merchant resource.
+// Metal is spent first, then crystal, then deuterium.
+func (m ResourceMerchant) Payment(celestialID CelestialID, amount int64) (Resources, error) {
+	if !m.Resource.IsValid() {
+		return Resources{}, ErrNoMerchantCalled
+	}
+	if amount <= 0 {
+		return Resources{}, errors.New("invalid amount")
+	}
+	res, ok := m.PlanetResources[celestialID]
+	if !ok {
+		return Resources{}, ErrInvalidPlanetID
+	}
+	available := Resources{Metal: res.Input.Metal, Crystal: res.Input.Crystal, Deuterium: res.Input.Deuterium}
+	rates := [3]float64{m.Rates.Metal, m.Rates.Crystal, m.Rates.Deuterium}
+	payment := Resources{}
+	left := amount
+	for i, rate := range rates {
+		if left <= 0 {
+			break
+		}
+		if rate <= 0 {
+			continue
+		}
+		needed := int64(math.Ceil(float64(left) * rate))
+		paid := MinInt(resourceAt(available, i), needed)
+		payment = payment.Add(resourceAmount(i, paid))
+		if paid == needed {
+			left = 0
+		} else {
+			left -= int64(float64(paid) / rate)
+		}
+	}
+	if left > 0 {
+		return payment, errors.New("not enough resources to buy " + strconv.FormatInt(amount, 10) + " " + string(m.Resource))
+	}
+	return payment, nil
+}
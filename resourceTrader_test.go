@@ -0,0 +1,33 @@
+package ogame
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResourceMerchantPayment(t *testing.T) {
+	pageHTMLBytes, _ := ioutil.ReadFile("samples/v8.7.4/en/traderResources.html")
+	merchant, _ := NewExtractorV874().ExtractResourceMerchant(pageHTMLBytes)
+
+	payment, err := merchant.Payment(33673090, 1000)
+	assert.NoError(t, err)
+	assert.Equal(t, Resources{Metal: 2820}, payment)
+
+	// Not enough metal, the rest is paid with crystal
+	payment, err = merchant.Payment(33677731, 5000)
+	assert.NoError(t, err)
+	assert.Equal(t, Resources{Metal: 10000, Crystal: 2778}, payment)
+
+	_, err = merchant.Payment(33677731, 10000)
+	assert.Error(t, err)
+
+	_, err = merchant.Payment(123, 1000)
+	assert.Equal(t, ErrInvalidPlanetID, err)
+
+	pageHTMLBytes, _ = ioutil.ReadFile("samples/v8.7.4/en/traderResources_noMerchant.html")
+	merchant, _ = NewExtractorV874().ExtractResourceMerchant(pageHTMLBytes)
+	_, err = merchant.Payment(33673090, 1000)
+	assert.Equal(t, ErrNoMerchantCalled, err)
+}
@@ -99,6 +99,12 @@ func (s ShipsInfos) ToQuantifiables() []Quantifiable {
 	return out
 }
 
+// newShipsInfos returns a ShipsInfos containing nbr ships of shipID
+func newShipsInfos(shipID ID, nbr int64) (out ShipsInfos) {
+	out.Set(shipID, nbr)
+	return
+}
+
 // FromQuantifiables convert an array of Quantifiable to a ShipsInfos
 func (s ShipsInfos) FromQuantifiables(in []Quantifiable) (out ShipsInfos) {
 	for _, item := range in {
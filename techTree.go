@@ -0,0 +1,86 @@
+package ogame
+
+import (
+	"errors"
+	"time"
+)
+
+// TechPlanStep a single upgrade (or batch of units) of a tech plan
+type TechPlanStep struct {
+	ID       ID
+	Nbr      int64 // Level reached for buildings and technologies, number of units for ships and defenses
+	Price    Resources
+	Duration time.Duration
+	StartIn  time.Duration // Start of the step when buildings, researches and shipyard are used in parallel
+}
+
+// TechPlan ordered list of upgrades needed to reach a target
+type TechPlan struct {
+	Steps            []TechPlanStep
+	Price            Resources
+	Duration         time.Duration // Sum of the construction times, if everything is built one after the other
+	ParallelDuration time.Duration // Duration when buildings, researches and shipyard are used in parallel
+}
+
+// TechTreeParams parameters used to compute the construction times
+type TechTreeParams struct {
+	UniverseSpeed int64
+	HasTechnocrat bool
+	IsDiscoverer  bool
+}
+
+// ResolveTechTree returns every upgrade needed to get the target id at the given level (or nbr units for ships and
+// defenses), starting from the provided levels. Construction times take into account the robotics factory,
+// nanite factory, shipyard and research lab upgrades made along the way.
+func ResolveTechTree(id ID, nbr int64, resBuildings ResourcesBuildings, facilities Facilities, researches Researches, params TechTreeParams) (TechPlan, error) {
+	var plan TechPlan
+	obj := Objs.ByID(id)
+	if obj == nil {
+		return plan, errors.New("invalid id " + id.String())
+	}
+	if params.UniverseSpeed <= 0 {
+		params.UniverseSpeed = 1
+	}
+	levels := techLevels{resBuildings: resBuildings, facilities: facilities, researches: researches}
+
+	targets := missingRequirements(id, levels.ByID)
+	targets = append(targets, Quantifiable{ID: id, Nbr: nbr})
+
+	laneFree := make(map[buildLane]time.Duration)
+	doneAt := make(map[Quantifiable]time.Duration) // When a planned level is reached
+	addStep := func(id ID, nbr int64) {
+		obj := Objs.ByID(id)
+		lane := laneOf(id)
+		step := TechPlanStep{ID: id, Nbr: nbr, Price: obj.GetPrice(nbr)}
+		step.Duration = obj.ConstructionTime(nbr, params.UniverseSpeed, levels.facilities, params.HasTechnocrat, params.IsDiscoverer)
+		step.StartIn = laneFree[lane]
+		for reqID, reqLvl := range obj.GetRequirements() {
+			if at := doneAt[Quantifiable{ID: reqID, Nbr: reqLvl}]; at > step.StartIn {
+				step.StartIn = at
+			}
+		}
+		end := step.StartIn + step.Duration
+		laneFree[lane] = end
+		doneAt[Quantifiable{ID: id, Nbr: nbr}] = end
+		plan.Steps = append(plan.Steps, step)
+		plan.Price = plan.Price.Add(step.Price)
+		plan.Duration += step.Duration
+		if end > plan.ParallelDuration {
+			plan.ParallelDuration = end
+		}
+	}
+
+	for _, target := range targets {
+		if laneOf(target.ID) == shipyardLane {
+			if target.Nbr > 0 {
+				addStep(target.ID, target.Nbr)
+			}
+			continue
+		}
+		for lvl := levels.ByID(target.ID) + 1; lvl <= target.Nbr; lvl++ {
+			addStep(target.ID, lvl)
+			levels.Set(target.ID, lvl)
+		}
+	}
+	return plan, nil
+}
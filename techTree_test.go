@@ -0,0 +1,33 @@
+package ogame
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveTechTree(t *testing.T) {
+	plan, err := ResolveTechTree(ShipyardID, 1, ResourcesBuildings{}, Facilities{}, Researches{}, TechTreeParams{UniverseSpeed: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(plan.Steps))
+	assert.Equal(t, TechPlanStep{ID: RoboticsFactoryID, Nbr: 1, Price: Resources{Metal: 400, Crystal: 120, Deuterium: 200},
+		Duration: RoboticsFactory.ConstructionTime(1, 1, Facilities{}, false, false)}, plan.Steps[0])
+	assert.Equal(t, RoboticsFactoryID, plan.Steps[1].ID)
+	assert.Equal(t, int64(2), plan.Steps[1].Nbr)
+	assert.Equal(t, Shipyard.ConstructionTime(1, 1, Facilities{RoboticsFactory: 2}, false, false), plan.Steps[2].Duration)
+	assert.Equal(t, Resources{Metal: 400 + 800 + 400, Crystal: 120 + 240 + 200, Deuterium: 200 + 400 + 100}, plan.Price)
+	assert.Equal(t, plan.Duration, plan.ParallelDuration)
+
+	plan, _ = ResolveTechTree(GravitonTechnologyID, 1, ResourcesBuildings{}, Facilities{ResearchLab: 10}, Researches{}, TechTreeParams{UniverseSpeed: 1})
+	assert.Equal(t, 3, len(plan.Steps))
+	assert.Equal(t, int64(300000), plan.Steps[2].Price.Energy)
+
+	plan, _ = ResolveTechTree(DeathstarID, 2, ResourcesBuildings{}, Facilities{}, Researches{}, TechTreeParams{UniverseSpeed: 1})
+	last := plan.Steps[len(plan.Steps)-1]
+	assert.Equal(t, TechPlanStep{ID: DeathstarID, Nbr: 2, Price: Deathstar.Price.Mul(2), Duration: last.Duration, StartIn: last.StartIn}, last)
+	assert.True(t, plan.ParallelDuration < plan.Duration)
+	assert.Equal(t, plan.ParallelDuration, last.StartIn+last.Duration)
+
+	_, err = ResolveTechTree(ID(1234), 1, ResourcesBuildings{}, Facilities{}, Researches{}, TechTreeParams{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,243 @@
+package ogame
+
+import (
+	"errors"
+	"sort"
+	"time"
+)
+
+// TransportOrder resources sent from one celestial
+type TransportOrder struct {
+	Origin      Celestial
+	Ships       ShipsInfos
+	Resources   Resources
+	FlightTime  int64
+	Fuel        int64
+	ArrivalTime time.Time
+	Fleet       Fleet
+	Err         error
+}
+
+// TransportPlan all the transports needed to gather resources on a destination
+type TransportPlan struct {
+	Orders      []TransportOrder
+	Total       Resources // Resources transported
+	Missing     Resources // Resources that could not be transported
+	Fuel        int64
+	ArrivalTime time.Time // Arrival of the last fleet
+}
+
+// TransportPlanner gathers resources from many celestials into one
+type TransportPlanner struct {
+	b           Wrapper
+	destination Celestial
+	origins     []Celestial
+	amount      *Resources
+	reserve     Resources
+	cargoShips  []ID
+	speed       Speed
+}
+
+// NewTransportPlanner ...
+func NewTransportPlanner(b Wrapper, destination interface{}) *TransportPlanner {
+	p := new(TransportPlanner)
+	p.b = b
+	p.destination = b.GetCachedCelestial(destination)
+	p.cargoShips = []ID{LargeCargoID, SmallCargoID}
+	p.speed = HundredPercent
+	return p
+}
+
+// SetAmount sets the resources to bring to the destination, by default everything above the reserve is sent
+func (p *TransportPlanner) SetAmount(amount Resources) *TransportPlanner {
+	p.amount = &amount
+	return p
+}
+
+// SetReserve sets the resources to keep on every origin
+func (p *TransportPlanner) SetReserve(reserve Resources) *TransportPlanner {
+	p.reserve = reserve
+	return p
+}
+
+// SetOrigins restricts the celestials resources are taken from (all celestials by default)
+func (p *TransportPlanner) SetOrigins(origins ...interface{}) *TransportPlanner {
+	p.origins = make([]Celestial, 0, len(origins))
+	for _, origin := range origins {
+		if c := p.b.GetCachedCelestial(origin); c != nil {
+			p.origins = append(p.origins, c)
+		}
+	}
+	return p
+}
+
+// SetCargoShips sets the ships used to carry the resources, in order of preference
+func (p *TransportPlanner) SetCargoShips(ids ...ID) *TransportPlanner {
+	p.cargoShips = ids
+	return p
+}
+
+// SetSpeed ...
+func (p *TransportPlanner) SetSpeed(speed Speed) *TransportPlanner {
+	p.speed = speed
+	return p
+}
+
+// transportShipsFor picks ships, in order of preference, to carry load
+func (p *TransportPlanner) transportShipsFor(load Resources, available ShipsInfos, techs Researches) (ships ShipsInfos, capacity int64) {
+	probeRaids := p.b.GetServer().Settings.EspionageProbeRaids == 1
+	isCollector := p.b.CharacterClass().IsCollector()
+	isPioneers := p.b.IsPioneers()
+	remaining := load.Total()
+	for _, id := range p.cargoShips {
+		ship, ok := Objs.ByID(id).(Ship)
+		if !ok || remaining <= 0 {
+			continue
+		}
+		nbr := MinInt(available.ByID(id), Resources{Metal: remaining}.FitsIn(ship, techs, probeRaids, isCollector, isPioneers))
+		if nbr <= 0 {
+			continue
+		}
+		ships.Set(id, nbr)
+		shipCapacity := ship.GetCargoCapacity(techs, probeRaids, isCollector, isPioneers) * nbr
+		capacity += shipCapacity
+		remaining -= shipCapacity
+	}
+	return ships, capacity
+}
+
+// take removes from the available resources, deuterium first, then crystal, then metal, up to the capacity
+func take(needed, available Resources, capacity int64) (out Resources) {
+	out.Deuterium = MinInt(MinInt(needed.Deuterium, available.Deuterium), capacity)
+	capacity -= out.Deuterium
+	out.Crystal = MinInt(MinInt(needed.Crystal, available.Crystal), capacity)
+	capacity -= out.Crystal
+	out.Metal = MinInt(MinInt(needed.Metal, available.Metal), capacity)
+	return
+}
+
+// Plan computes the transports, using first the origins that cost the less fuel per cargo capacity
+func (p *TransportPlanner) Plan() (TransportPlan, error) {
+	var plan TransportPlan
+	if p.destination == nil {
+		return plan, errors.New("invalid destination")
+	}
+	allResources, err := p.b.GetAllResources()
+	if err != nil {
+		return plan, err
+	}
+	origins := p.origins
+	if origins == nil {
+		origins = p.b.GetCachedCelestials()
+	}
+	techs := p.b.GetCachedResearch()
+	destination := p.destination.GetCoordinate()
+
+	type candidate struct {
+		origin    Celestial
+		ships     ShipsInfos
+		available Resources
+		fuelRatio float64 // Fuel per unit of cargo capacity
+	}
+	candidates := make([]candidate, 0, len(origins))
+	for _, origin := range origins {
+		if origin.GetID() == p.destination.GetID() {
+			continue
+		}
+		ships, err := p.b.GetShips(origin.GetID())
+		if err != nil {
+			return plan, err
+		}
+		available := allResources[origin.GetID()].Sub(p.reserve)
+		allShips, capacity := p.transportShipsFor(Resources{Metal: 1 << 50}, ships, techs)
+		if capacity <= 0 || available.Total() <= 0 {
+			continue
+		}
+		_, fuel := p.b.FlightTime(origin.GetCoordinate(), destination, p.speed, allShips, Transport)
+		candidates = append(candidates, candidate{origin: origin, ships: ships, available: available, fuelRatio: float64(fuel) / float64(capacity)})
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].fuelRatio < candidates[j].fuelRatio })
+
+	needed := Resources{Metal: 1 << 50, Crystal: 1 << 50, Deuterium: 1 << 50}
+	if p.amount != nil {
+		needed = *p.amount
+	}
+	now := p.b.ServerTime()
+	for _, c := range candidates {
+		if needed.Total() <= 0 {
+			break
+		}
+		load := take(needed, c.available, needed.Total())
+		// The fuel takes cargo space, ships are added until they can carry the load and the fuel
+		var ships ShipsInfos
+		var secs, fuel int64
+		for {
+			prevShips := ships
+			var capacity int64
+			ships, capacity = p.transportShipsFor(Resources{Metal: load.Total() + fuel}, c.ships, techs)
+			secs, fuel = p.b.FlightTime(c.origin.GetCoordinate(), destination, p.speed, ships, Transport)
+			if load.Total()+fuel <= capacity {
+				break
+			}
+			if ships == prevShips {
+				load = take(load, load, MaxInt(capacity-fuel, 0))
+				break
+			}
+		}
+		// Fuel is paid with the deuterium of the origin
+		if c.available.Deuterium-load.Deuterium < fuel {
+			load.Deuterium = MaxInt(c.available.Deuterium-fuel, 0)
+			if c.available.Deuterium < fuel {
+				continue
+			}
+		}
+		if load.Total() <= 0 {
+			continue
+		}
+		order := TransportOrder{Origin: c.origin, Ships: ships, Resources: load, FlightTime: secs, Fuel: fuel}
+		order.ArrivalTime = now.Add(time.Duration(secs) * time.Second)
+		plan.Orders = append(plan.Orders, order)
+		plan.Total = plan.Total.Add(load)
+		plan.Fuel += fuel
+		if order.ArrivalTime.After(plan.ArrivalTime) {
+			plan.ArrivalTime = order.ArrivalTime
+		}
+		needed = needed.Sub(load)
+	}
+	if p.amount != nil {
+		plan.Missing = needed
+	}
+	return plan, nil
+}
+
+// Send computes the plan and sends all the transports
+func (p *TransportPlanner) Send() (TransportPlan, error) {
+	plan, err := p.Plan()
+	if err != nil {
+		return plan, err
+	}
+	plan.ArrivalTime = time.Time{}
+	for i, order := range plan.Orders {
+		fleet, err := NewFleetBuilder(p.b).
+			SetOrigin(order.Origin).
+			SetDestination(p.destination).
+			SetMission(Transport).
+			SetSpeed(p.speed).
+			SetShips(order.Ships).
+			SetResources(order.Resources).
+			SendNow()
+		plan.Orders[i].Fleet, plan.Orders[i].Err = fleet, err
+		if err != nil {
+			plan.Total = plan.Total.Sub(order.Resources)
+			plan.Missing = plan.Missing.Add(order.Resources)
+			continue
+		}
+		if !fleet.ArrivalTime.IsZero() {
+			plan.Orders[i].ArrivalTime = fleet.ArrivalTime
+		}
+		if plan.Orders[i].ArrivalTime.After(plan.ArrivalTime) {
+			plan.ArrivalTime = plan.Orders[i].ArrivalTime
+		}
+	}
+	return plan, nil
+}
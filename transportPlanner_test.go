@@ -0,0 +1,62 @@
+package ogame
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeTransportWrapper struct {
+	*fakeFleets
+	allResources map[CelestialID]Resources
+}
+
+func (w *fakeTransportWrapper) GetAllResources() (map[CelestialID]Resources, error) {
+	return w.allResources, nil
+}
+
+func TestTransportPlanner(t *testing.T) {
+	w := &fakeTransportWrapper{fakeFleets: newFakeFleets()}
+	destination := Planet{ID: 1, Coordinate: Coordinate{1, 100, 8, PlanetType}}
+	near := Planet{ID: 2, Coordinate: Coordinate{1, 101, 8, PlanetType}}
+	far := Planet{ID: 3, Coordinate: Coordinate{3, 100, 8, PlanetType}}
+	w.celestials = []Celestial{destination, far, near}
+	w.allResources = map[CelestialID]Resources{
+		1: {Metal: 1000000},
+		2: {Metal: 100000, Crystal: 50000, Deuterium: 20000},
+		3: {Metal: 100000, Crystal: 50000, Deuterium: 40000},
+	}
+	w.ships[1] = ShipsInfos{LargeCargo: 100}
+	w.ships[2] = ShipsInfos{LargeCargo: 10}
+	w.ships[3] = ShipsInfos{LargeCargo: 10, SmallCargo: 10}
+
+	plan, err := NewTransportPlanner(w, CelestialID(1)).SetAmount(Resources{Metal: 150000, Crystal: 10000}).Plan()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(plan.Orders))
+	assert.Equal(t, near.GetID(), plan.Orders[0].Origin.GetID())
+	assert.Equal(t, Resources{Metal: 100000, Crystal: 10000}, plan.Orders[0].Resources)
+	assert.Equal(t, ShipsInfos{LargeCargo: 5}, plan.Orders[0].Ships)
+	assert.Equal(t, far.GetID(), plan.Orders[1].Origin.GetID())
+	// 2 large cargos would carry the metal, a third one is needed for the fuel
+	assert.Equal(t, ShipsInfos{LargeCargo: 3}, plan.Orders[1].Ships)
+	assert.True(t, plan.Orders[1].Resources.Total()+plan.Orders[1].Fuel <= 3*LargeCargo.BaseCargoCapacity)
+	assert.Equal(t, Resources{Metal: 150000, Crystal: 10000}, plan.Total)
+	assert.Equal(t, Resources{}, plan.Missing)
+	assert.Equal(t, plan.Orders[0].Fuel+plan.Orders[1].Fuel, plan.Fuel)
+	assert.Equal(t, w.now.Add(time.Duration(plan.Orders[1].FlightTime)*time.Second), plan.ArrivalTime)
+
+	plan, err = NewTransportPlanner(w, destination).
+		SetOrigins(CelestialID(2)).
+		SetReserve(Resources{Metal: 90000}).
+		Send()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(plan.Orders))
+	assert.Equal(t, int64(10000), plan.Orders[0].Resources.Metal)
+	assert.Equal(t, int64(50000), plan.Orders[0].Resources.Crystal)
+	assert.Equal(t, int64(20000), plan.Orders[0].Resources.Deuterium+plan.Orders[0].Fuel)
+	assert.NoError(t, plan.Orders[0].Err)
+	assert.Equal(t, 1, len(w.sentFleets))
+	assert.Equal(t, Transport, w.sentFleets[0].Mission)
+	assert.Equal(t, destination.Coordinate, w.sentFleets[0].Destination)
+}
@@ -5,6 +5,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 	"unicode"
 
 	"golang.org/x/text/runes"
@@ -516,6 +517,21 @@ func Clamp(val, min, max int64) int64 {
 	return val
 }
 
+// waitOrStop waits for d, returns false if stop is closed first
+func waitOrStop(d time.Duration, stop <-chan struct{}) bool {
+	select {
+	case <-stop:
+		return false
+	default:
+	}
+	select {
+	case <-stop:
+		return false
+	case <-time.After(d):
+		return true
+	}
+}
+
 // GetFleetSpeedForMission ...
 func GetFleetSpeedForMission(serverData ServerData, missionID MissionID) int64 {
 	if missionID == Attack || missionID == GroupedAttack || missionID == Destroy || missionID == MissileAttack || missionID == RecycleDebrisField {